@@ -1,52 +1,65 @@
 package main
 
 import (
-	"SongsLibrary/internal/constants"
+	"SongsLibrary/internal/config"
 	"SongsLibrary/internal/server"
-	logrusCustom "SongsLibrary/pkg/logger"
+	"errors"
+	"flag"
 	"github.com/joho/godotenv"
 	"github.com/sirupsen/logrus"
+	"io/fs"
 	"os"
 )
 
+// @title Songs Library API
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description Static API key from API_KEYS
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT signed with a key from JWKS_FILE, sent as "Bearer <token>"
 func main() {
-	logrusCustom.InitLogger()
-
+	// Settings can also come from a YAML file, the environment or flags, so
+	// the .env file is optional. Until the configured logger exists, errors go
+	// to the logrus standard logger.
 	err := godotenv.Load()
-	if err != nil {
-		logrusCustom.Logger.Fatalf("Error loading .env file")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logrus.Fatalf("Error loading .env file: %s", err.Error())
 	}
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, "Successfully loaded environment variables")
-
-	var protocol constants.Protocol = constants.Protocol(os.Getenv("PROTOCOL"))
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := server.RunImport(os.Args[2:], os.Stdout); err != nil {
+			logrus.Fatalf("Import failed: %s", err.Error())
+		}
+		return
+	}
 
-	switch protocol {
-	case constants.HTTP:
-		startHTTPServer()
-	case constants.GRPC:
-		startGRPCServer()
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := server.RunMigrate(os.Args[2:], os.Stdout); err != nil {
+			logrus.Fatalf("Migration failed: %s", err.Error())
+		}
+		return
 	}
-}
 
-func startHTTPServer() {
-	app, err := server.NewApp()
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:])
 	if err != nil {
-		logrusCustom.Logger.Fatalf("Failed to create App")
+		logrus.Fatalf("Failed to load configuration: %s", err.Error())
 	}
 
-	if err := app.Run(os.Getenv("APP_PORT")); err != nil {
-		logrusCustom.Logger.Fatalf("Error when running server: %s", err.Error())
+	log, logCloser, err := server.NewLogger(cfg)
+	if err != nil {
+		logrus.Fatalf("Failed to create logger: %s", err.Error())
 	}
-}
+	defer logCloser.Close()
 
-func startGRPCServer() {
-	app, err := server.NewAppGRPC()
+	app, err := server.NewApp(cfg, log)
 	if err != nil {
-		logrusCustom.Logger.Fatalf("Failed to create App")
+		log.Fatalf("Failed to create App: %s", err.Error())
 	}
 
-	if err := app.Run(os.Getenv("APP_PORT")); err != nil {
-		logrusCustom.Logger.Fatalf("Error when running server: %s", err.Error())
+	if err := app.Run(); err != nil {
+		log.Fatalf("Error when running server: %s", err.Error())
 	}
 }
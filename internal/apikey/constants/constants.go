@@ -0,0 +1,25 @@
+package constants
+
+import "time"
+
+const (
+	// KeyPrefix marks keys issued by the server, so that other API keys are
+	// left to the static authenticator.
+	KeyPrefix   = "sl_"
+	PrefixBytes = 6
+	SecretBytes = 32
+
+	QuotaPeriodMinute = "minute"
+	QuotaPeriodHour   = "hour"
+	QuotaPeriodDay    = "day"
+
+	DefaultQuotaPeriod = QuotaPeriodDay
+
+	DbUniqueConstrintErr = "23505"
+)
+
+var QuotaPeriods = map[string]time.Duration{
+	QuotaPeriodMinute: time.Minute,
+	QuotaPeriodHour:   time.Hour,
+	QuotaPeriodDay:    24 * time.Hour,
+}
@@ -0,0 +1,201 @@
+package http
+
+import (
+	"SongsLibrary/internal/apikey"
+	"SongsLibrary/internal/apikey/dtos"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"time"
+)
+
+type Handler struct {
+	useCase apikey.UseCase
+}
+
+func NewHandler(useCase apikey.UseCase) *Handler {
+	return &Handler{
+		useCase: useCase,
+	}
+}
+
+// CreateAPIKey
+// @Summary Issue a new API key
+// @Description Create an API key with the given scopes, optional expiry and optional quota of requests per minute, hour or day. The plaintext key is returned only in this response; only its hash is stored.
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Param apiKey body dtos.CreateAPIKeyDTO true "Name, scopes (reader, editor, admin), expires_at, quota_limit and quota_period (minute, hour, day)"
+// @Success 201 {object} dtos.IssuedAPIKey "Issued key"
+// @Failure 400 {object} string "Invalid input data"
+// @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/admin/api-keys [post]
+func (h *Handler) CreateAPIKey(c *gin.Context) {
+	var createAPIKeyDTO dtos.CreateAPIKeyDTO
+
+	if err := c.ShouldBindJSON(&createAPIKeyDTO); err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": apikey.InvalidInputData.Error()})
+		return
+	}
+
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered CreateAPIKey Hanlder with parameters: name:%s, scopes:%v", createAPIKeyDTO.Name, createAPIKeyDTO.Scopes))
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	issuedKey, err := h.useCase.CreateAPIKey(ctx, &createAPIKeyDTO)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		if err.Error() == apikey.InvalidInputData.Error() {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": apikey.InvalidInputData.Error()})
+			return
+		}
+
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"apiKey": issuedKey.APIKey, "key": issuedKey.Key})
+}
+
+// GetAPIKeys
+// @Summary List API keys
+// @Description Retrieve every issued API key, including revoked and expired ones. Secrets are never returned.
+// @Tags API Keys
+// @Produce json
+// @Success 200 {array} models.APIKey "List of API keys"
+// @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/admin/api-keys [get]
+func (h *Handler) GetAPIKeys(c *gin.Context) {
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, "Entered GetAPIKeys Hanlder")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	keys, err := h.useCase.GetAPIKeys(ctx)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"apiKeys": keys})
+}
+
+// RotateAPIKey
+// @Summary Rotate an API key
+// @Description Replace the secret of an API key, keeping its scopes, quota and expiry. The previous key stops working immediately and the new plaintext key is returned only in this response.
+// @Tags API Keys
+// @Produce json
+// @Param id path string true "UUID of the API key" format(uuid)
+// @Success 200 {object} dtos.IssuedAPIKey "Rotated key"
+// @Failure 400 {object} string "Invalid API key ID format"
+// @Failure 404 {object} string "API key not found"
+// @Failure 409 {object} string "API key revoked"
+// @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/admin/api-keys/{id}/rotate [post]
+func (h *Handler) RotateAPIKey(c *gin.Context) {
+	id := c.Param("id")
+
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered RotateAPIKey Hanlder with parameter: %s", id))
+
+	convertedId, err := uuid.Parse(id)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": apikey.InvalidAPIKeyIdFormat.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	issuedKey, err := h.useCase.RotateAPIKey(ctx, convertedId)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		switch {
+		case errors.Is(err, apikey.APIKeyNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": apikey.APIKeyNotFound.Error()})
+		case errors.Is(err, apikey.APIKeyRevoked):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": apikey.APIKeyRevoked.Error()})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"apiKey": issuedKey.APIKey, "key": issuedKey.Key})
+}
+
+// RevokeAPIKey
+// @Summary Revoke an API key
+// @Description Revoke an API key so it can no longer authenticate. Revoking an already revoked key has no effect.
+// @Tags API Keys
+// @Produce json
+// @Param id path string true "UUID of the API key" format(uuid)
+// @Success 200 {object} models.APIKey "Revoked key"
+// @Failure 400 {object} string "Invalid API key ID format"
+// @Failure 404 {object} string "API key not found"
+// @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/admin/api-keys/{id} [delete]
+func (h *Handler) RevokeAPIKey(c *gin.Context) {
+	id := c.Param("id")
+
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered RevokeAPIKey Hanlder with parameter: %s", id))
+
+	convertedId, err := uuid.Parse(id)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": apikey.InvalidAPIKeyIdFormat.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	revokedKey, err := h.useCase.RevokeAPIKey(ctx, convertedId)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		if err.Error() == apikey.APIKeyNotFound.Error() {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": apikey.APIKeyNotFound.Error()})
+			return
+		}
+
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revokedApiKey": revokedKey})
+}
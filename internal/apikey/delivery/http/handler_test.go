@@ -0,0 +1,94 @@
+package http
+
+import (
+	"SongsLibrary/internal/apikey"
+	"SongsLibrary/internal/apikey/dtos"
+	"SongsLibrary/internal/apikey/usecase"
+	"SongsLibrary/internal/auth/authenticator"
+	authhttp "SongsLibrary/internal/auth/delivery/http"
+	"SongsLibrary/internal/db/models"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func setup() (*gin.Engine, *usecase.MockAPIKeyUseCase) {
+	gin.SetMode(gin.TestMode)
+
+	mockUseCase := new(usecase.MockAPIKeyUseCase)
+
+	r := gin.Default()
+	RegisterHTTPEndpoints(r, mockUseCase, authhttp.NewMiddleware(authenticator.Disabled{}, nil, nil))
+
+	return r, mockUseCase
+}
+
+func TestCreateAPIKeyHandler_Success(t *testing.T) {
+	r, mockUseCase := setup()
+
+	issuedKey := &dtos.IssuedAPIKey{APIKey: &models.APIKey{ID: uuid.New(), Name: "ci", Scopes: "reader"}, Key: "sl_0123456789ab_secret"}
+
+	mockUseCase.On("CreateAPIKey", mock.Anything, &dtos.CreateAPIKeyDTO{Name: "ci", Scopes: []string{"reader"}, QuotaLimit: 100, QuotaPeriod: "hour"}).Return(issuedKey, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/admin/api-keys", strings.NewReader(`{"name":"ci","scopes":["reader"],"quota_limit":100,"quota_period":"hour"}`))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response struct {
+		APIKey models.APIKey `json:"apiKey"`
+		Key    string        `json:"key"`
+	}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	assert.Equal(t, issuedKey.Key, response.Key)
+	assert.Equal(t, issuedKey.APIKey.ID, response.APIKey.ID)
+	assert.NotContains(t, w.Body.String(), "SecretHash")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestCreateAPIKeyHandler_InvalidScope(t *testing.T) {
+	r, mockUseCase := setup()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/admin/api-keys", strings.NewReader(`{"name":"ci","scopes":["owner"]}`))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUseCase.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything)
+}
+
+func TestRotateAPIKeyHandler_Revoked(t *testing.T) {
+	r, mockUseCase := setup()
+
+	id := uuid.New()
+	mockUseCase.On("RotateAPIKey", mock.Anything, id).Return(nil, apikey.APIKeyRevoked)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/admin/api-keys/"+id.String()+"/rotate", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestRevokeAPIKeyHandler_NotFound(t *testing.T) {
+	r, mockUseCase := setup()
+
+	id := uuid.New()
+	mockUseCase.On("RevokeAPIKey", mock.Anything, id).Return(nil, apikey.APIKeyNotFound)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/admin/api-keys/"+id.String(), nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
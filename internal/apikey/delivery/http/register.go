@@ -0,0 +1,20 @@
+package http
+
+import (
+	"SongsLibrary/internal/apikey"
+	"SongsLibrary/internal/auth/constants"
+	authhttp "SongsLibrary/internal/auth/delivery/http"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterHTTPEndpoints(router *gin.Engine, uc apikey.UseCase, authMiddleware *authhttp.Middleware) {
+	h := NewHandler(uc)
+
+	adminEndPoints := router.Group("/api/admin", authMiddleware.Authenticate(), authMiddleware.RequireRole(constants.RoleAdmin))
+	{
+		adminEndPoints.POST("/api-keys", h.CreateAPIKey)
+		adminEndPoints.GET("/api-keys", h.GetAPIKeys)
+		adminEndPoints.POST("/api-keys/:id/rotate", h.RotateAPIKey)
+		adminEndPoints.DELETE("/api-keys/:id", h.RevokeAPIKey)
+	}
+}
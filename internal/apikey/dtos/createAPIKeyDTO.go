@@ -0,0 +1,21 @@
+package dtos
+
+import (
+	"SongsLibrary/internal/db/models"
+	"time"
+)
+
+type CreateAPIKeyDTO struct {
+	Name        string     `json:"name" binding:"required,max=100"`
+	Scopes      []string   `json:"scopes" binding:"required,min=1,dive,oneof=reader editor admin"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	QuotaLimit  int64      `json:"quota_limit" binding:"omitempty,min=0"`
+	QuotaPeriod string     `json:"quota_period" binding:"omitempty,oneof=minute hour day"`
+}
+
+// IssuedAPIKey is returned when a key is created or rotated. Key is the
+// plaintext credential; it is not stored and cannot be retrieved again.
+type IssuedAPIKey struct {
+	APIKey *models.APIKey
+	Key    string
+}
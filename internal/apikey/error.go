@@ -0,0 +1,12 @@
+package apikey
+
+import "errors"
+
+var (
+	APIKeyNotFound        = errors.New("api key not found")
+	APIKeyRevoked         = errors.New("api key revoked")
+	APIKeyExpired         = errors.New("api key expired")
+	InvalidAPIKeyIdFormat = errors.New("invalid api key id format")
+	InvalidInputData      = errors.New("invalid input data")
+	DuplicatePrefix       = errors.New("api key prefix already in use")
+)
@@ -0,0 +1,17 @@
+package apikey
+
+import (
+	"SongsLibrary/internal/db/models"
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Repository interface {
+	CreateAPIKey(context.Context, *models.APIKey) (*models.APIKey, error)
+	GetAPIKeys(context.Context) ([]models.APIKey, error)
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	RotateAPIKey(ctx context.Context, id uuid.UUID, prefix, secretHash string) (*models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error)
+	IncrementUsage(ctx context.Context, id uuid.UUID, windowStart time.Time) (int64, error)
+}
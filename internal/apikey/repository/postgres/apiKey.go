@@ -0,0 +1,191 @@
+package postgres
+
+import (
+	"SongsLibrary/internal/apikey"
+	"SongsLibrary/internal/apikey/constants"
+	"SongsLibrary/internal/db/models"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"time"
+)
+
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+func (ar *APIKeyRepository) CreateAPIKey(ctx context.Context, keyToCreate *models.APIKey) (*models.APIKey, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered CreateAPIKey Repository with parameters: name:%s, prefix:%s", keyToCreate.Name, keyToCreate.Prefix))
+
+	if err := ar.db.WithContext(ctx).Create(keyToCreate).Error; err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		if isUniqueViolation(err) {
+			return nil, apikey.DuplicatePrefix
+		}
+
+		return nil, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting CreateAPIKey Repository with created key: %s", keyToCreate.ID))
+
+	return keyToCreate, nil
+}
+
+func (ar *APIKeyRepository) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, "Entered GetAPIKeys Repository")
+
+	var keys []models.APIKey
+	if err := ar.db.WithContext(ctx).Order("created_at").Find(&keys).Error; err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetAPIKeys Repository with %d keys", len(keys)))
+
+	return keys, nil
+}
+
+func (ar *APIKeyRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+
+	var keyToGet models.APIKey
+	if err := ar.db.WithContext(ctx).First(&keyToGet, "prefix = ?", prefix).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apikey.APIKeyNotFound
+		}
+
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+		return nil, err
+	}
+
+	return &keyToGet, nil
+}
+
+// RotateAPIKey replaces the secret of a key that has not been revoked. The old
+// secret stops working immediately.
+func (ar *APIKeyRepository) RotateAPIKey(ctx context.Context, id uuid.UUID, prefix, secretHash string) (*models.APIKey, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered RotateAPIKey Repository with parameter: id:%s", id))
+
+	var rotatedKey models.APIKey
+
+	err := ar.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := findAPIKey(tx, id, &rotatedKey); err != nil {
+			return err
+		}
+
+		if rotatedKey.RevokedAt != nil {
+			return apikey.APIKeyRevoked
+		}
+
+		rotatedKey.Prefix = prefix
+		rotatedKey.SecretHash = secretHash
+
+		return tx.Model(&rotatedKey).Updates(map[string]interface{}{"prefix": prefix, "secret_hash": secretHash}).Error
+	})
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		if isUniqueViolation(err) {
+			return nil, apikey.DuplicatePrefix
+		}
+
+		return nil, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting RotateAPIKey Repository with new prefix: %s", rotatedKey.Prefix))
+
+	return &rotatedKey, nil
+}
+
+// RevokeAPIKey marks a key as revoked. Revoking a revoked key keeps its
+// original revocation time.
+func (ar *APIKeyRepository) RevokeAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered RevokeAPIKey Repository with parameter: id:%s", id))
+
+	var revokedKey models.APIKey
+
+	err := ar.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := findAPIKey(tx, id, &revokedKey); err != nil {
+			return err
+		}
+
+		if revokedKey.RevokedAt != nil {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		revokedKey.RevokedAt = &now
+
+		return tx.Model(&revokedKey).Update("revoked_at", now).Error
+	})
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting RevokeAPIKey Repository with revoked key: %s", revokedKey.ID))
+
+	return &revokedKey, nil
+}
+
+// IncrementUsage counts one request in the period starting at windowStart and
+// returns the number of requests made in it so far. The upsert is atomic, so
+// replicas sharing the database share the quota. The first request of a new
+// period removes the counters of the previous ones.
+func (ar *APIKeyRepository) IncrementUsage(ctx context.Context, id uuid.UUID, windowStart time.Time) (int64, error) {
+
+	var requests int64
+
+	err := ar.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw(`INSERT INTO api_key_usage (api_key_id, window_start, requests) VALUES (?, ?, 1)
+			ON CONFLICT (api_key_id, window_start) DO UPDATE SET requests = api_key_usage.requests + 1
+			RETURNING requests`, id, windowStart).Scan(&requests).Error; err != nil {
+			return err
+		}
+
+		if requests == 1 {
+			return tx.Where("api_key_id = ? AND window_start < ?", id, windowStart).Delete(&models.APIKeyUsage{}).Error
+		}
+
+		return nil
+	})
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return 0, err
+	}
+
+	return requests, nil
+}
+
+func findAPIKey(db *gorm.DB, id uuid.UUID, keyToFind *models.APIKey) error {
+	if err := db.First(keyToFind, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apikey.APIKeyNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == constants.DbUniqueConstrintErr
+}
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"SongsLibrary/internal/db/models"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) CreateAPIKey(ctx context.Context, keyToCreate *models.APIKey) (*models.APIKey, error) {
+	args := m.Called(ctx, keyToCreate)
+	if createdKey, ok := args.Get(0).(*models.APIKey); ok {
+		return createdKey, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRepository) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	args := m.Called(ctx)
+	if keys, ok := args.Get(0).([]models.APIKey); ok {
+		return keys, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	args := m.Called(ctx, prefix)
+	if keyToGet, ok := args.Get(0).(*models.APIKey); ok {
+		return keyToGet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRepository) RotateAPIKey(ctx context.Context, id uuid.UUID, prefix, secretHash string) (*models.APIKey, error) {
+	args := m.Called(ctx, id, prefix, secretHash)
+	if rotatedKey, ok := args.Get(0).(*models.APIKey); ok {
+		return rotatedKey, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRepository) RevokeAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	args := m.Called(ctx, id)
+	if revokedKey, ok := args.Get(0).(*models.APIKey); ok {
+		return revokedKey, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRepository) IncrementUsage(ctx context.Context, id uuid.UUID, windowStart time.Time) (int64, error) {
+	args := m.Called(ctx, id, windowStart)
+	return args.Get(0).(int64), args.Error(1)
+}
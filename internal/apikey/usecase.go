@@ -0,0 +1,21 @@
+package apikey
+
+import (
+	"SongsLibrary/internal/apikey/dtos"
+	"SongsLibrary/internal/auth"
+	"SongsLibrary/internal/db/models"
+	"context"
+	"github.com/google/uuid"
+)
+
+// UseCase manages issued API keys. It also authenticates them and enforces
+// their quotas for the auth middleware and interceptor.
+type UseCase interface {
+	auth.Authenticator
+	auth.QuotaEnforcer
+
+	CreateAPIKey(context.Context, *dtos.CreateAPIKeyDTO) (*dtos.IssuedAPIKey, error)
+	GetAPIKeys(context.Context) ([]models.APIKey, error)
+	RotateAPIKey(ctx context.Context, id uuid.UUID) (*dtos.IssuedAPIKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error)
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/apikey/dtos"
+	"SongsLibrary/internal/auth"
+	"SongsLibrary/internal/db/models"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAPIKeyUseCase struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyUseCase) Authenticate(ctx context.Context, credentials auth.Credentials) (*auth.Principal, error) {
+	args := m.Called(ctx, credentials)
+	if principal, ok := args.Get(0).(*auth.Principal); ok {
+		return principal, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAPIKeyUseCase) ConsumeQuota(ctx context.Context, quota *auth.Quota) (*auth.QuotaStatus, error) {
+	args := m.Called(ctx, quota)
+	if quotaStatus, ok := args.Get(0).(*auth.QuotaStatus); ok {
+		return quotaStatus, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAPIKeyUseCase) CreateAPIKey(ctx context.Context, cakdto *dtos.CreateAPIKeyDTO) (*dtos.IssuedAPIKey, error) {
+	args := m.Called(ctx, cakdto)
+	if issuedKey, ok := args.Get(0).(*dtos.IssuedAPIKey); ok {
+		return issuedKey, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAPIKeyUseCase) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	args := m.Called(ctx)
+	if keys, ok := args.Get(0).([]models.APIKey); ok {
+		return keys, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAPIKeyUseCase) RotateAPIKey(ctx context.Context, id uuid.UUID) (*dtos.IssuedAPIKey, error) {
+	args := m.Called(ctx, id)
+	if issuedKey, ok := args.Get(0).(*dtos.IssuedAPIKey); ok {
+		return issuedKey, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAPIKeyUseCase) RevokeAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	args := m.Called(ctx, id)
+	if revokedKey, ok := args.Get(0).(*models.APIKey); ok {
+		return revokedKey, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
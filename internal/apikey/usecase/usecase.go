@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/apikey"
+	"SongsLibrary/internal/apikey/constants"
+	"SongsLibrary/internal/apikey/dtos"
+	"SongsLibrary/internal/auth"
+	"SongsLibrary/internal/db/models"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxPrefixAttempts bounds the retries when a freshly generated prefix is
+// already taken.
+const maxPrefixAttempts = 3
+
+type APIKeyUseCase struct {
+	apiKeyRepo apikey.Repository
+	now        func() time.Time
+}
+
+func NewAPIKeyUseCase(apiKeyRepo apikey.Repository) *APIKeyUseCase {
+	return &APIKeyUseCase{apiKeyRepo: apiKeyRepo, now: time.Now}
+}
+
+func (auc *APIKeyUseCase) CreateAPIKey(ctx context.Context, cakdto *dtos.CreateAPIKeyDTO) (*dtos.IssuedAPIKey, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered CreateAPIKey UseCase with parameters: name:%s, scopes:%v", cakdto.Name, cakdto.Scopes))
+
+	if cakdto.ExpiresAt != nil && !cakdto.ExpiresAt.After(auc.now()) {
+		return nil, apikey.InvalidInputData
+	}
+
+	quotaPeriod := cakdto.QuotaPeriod
+	if quotaPeriod == "" {
+		quotaPeriod = constants.DefaultQuotaPeriod
+	}
+
+	scopes := slices.Clone(cakdto.Scopes)
+	slices.Sort(scopes)
+
+	for attempt := 1; ; attempt++ {
+		prefix, key, secretHash, err := generateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		createdKey, err := auc.apiKeyRepo.CreateAPIKey(ctx, &models.APIKey{
+			ID:          uuid.New(),
+			Name:        cakdto.Name,
+			Prefix:      prefix,
+			SecretHash:  secretHash,
+			Scopes:      strings.Join(slices.Compact(scopes), ","),
+			QuotaLimit:  cakdto.QuotaLimit,
+			QuotaPeriod: quotaPeriod,
+			ExpiresAt:   cakdto.ExpiresAt,
+		})
+		if errors.Is(err, apikey.DuplicatePrefix) && attempt < maxPrefixAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting CreateAPIKey UseCase with created key: %s", createdKey.ID))
+
+		return &dtos.IssuedAPIKey{APIKey: createdKey, Key: key}, nil
+	}
+}
+
+func (auc *APIKeyUseCase) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, "Entered GetAPIKeys UseCase")
+
+	return auc.apiKeyRepo.GetAPIKeys(ctx)
+}
+
+// RotateAPIKey issues a new secret for a key, keeping its scopes, quota and
+// expiry.
+func (auc *APIKeyUseCase) RotateAPIKey(ctx context.Context, id uuid.UUID) (*dtos.IssuedAPIKey, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered RotateAPIKey UseCase with parameter: %s", id))
+
+	for attempt := 1; ; attempt++ {
+		prefix, key, secretHash, err := generateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		rotatedKey, err := auc.apiKeyRepo.RotateAPIKey(ctx, id, prefix, secretHash)
+		if errors.Is(err, apikey.DuplicatePrefix) && attempt < maxPrefixAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting RotateAPIKey UseCase with new prefix: %s", rotatedKey.Prefix))
+
+		return &dtos.IssuedAPIKey{APIKey: rotatedKey, Key: key}, nil
+	}
+}
+
+func (auc *APIKeyUseCase) RevokeAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered RevokeAPIKey UseCase with parameter: %s", id))
+
+	return auc.apiKeyRepo.RevokeAPIKey(ctx, id)
+}
+
+// Authenticate resolves keys issued by CreateAPIKey. Other API keys are left
+// to the next authenticator of the chain.
+func (auc *APIKeyUseCase) Authenticate(ctx context.Context, credentials auth.Credentials) (*auth.Principal, error) {
+	prefix, ok := parseKey(credentials.APIKey)
+	if !ok {
+		return nil, auth.MissingCredentials
+	}
+
+	issuedKey, err := auc.apiKeyRepo.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, apikey.APIKeyNotFound) {
+			return nil, auth.InvalidAPIKey
+		}
+
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashKey(credentials.APIKey)), []byte(issuedKey.SecretHash)) != 1 {
+		return nil, auth.InvalidAPIKey
+	}
+
+	if issuedKey.RevokedAt != nil {
+		return nil, fmt.Errorf("%w: %w", auth.InvalidAPIKey, apikey.APIKeyRevoked)
+	}
+
+	if issuedKey.ExpiresAt != nil && !issuedKey.ExpiresAt.After(auc.now()) {
+		return nil, fmt.Errorf("%w: %w", auth.InvalidAPIKey, apikey.APIKeyExpired)
+	}
+
+	principal := &auth.Principal{
+		Subject: "api-key:" + issuedKey.ID.String(),
+		Roles:   strings.Split(issuedKey.Scopes, ","),
+	}
+
+	if period, ok := constants.QuotaPeriods[issuedKey.QuotaPeriod]; ok && issuedKey.QuotaLimit > 0 {
+		principal.Quota = &auth.Quota{KeyId: issuedKey.ID, Limit: issuedKey.QuotaLimit, Period: period}
+	}
+
+	return principal, nil
+}
+
+// ConsumeQuota counts the request in the current fixed period of the quota.
+// Periods are aligned to UTC, so a daily quota resets at midnight UTC.
+func (auc *APIKeyUseCase) ConsumeQuota(ctx context.Context, quota *auth.Quota) (*auth.QuotaStatus, error) {
+	windowStart := auc.now().UTC().Truncate(quota.Period)
+
+	requests, err := auc.apiKeyRepo.IncrementUsage(ctx, quota.KeyId, windowStart)
+	if err != nil {
+		return nil, err
+	}
+
+	quotaStatus := &auth.QuotaStatus{
+		Limit:     quota.Limit,
+		Remaining: max(quota.Limit-requests, 0),
+		Reset:     windowStart.Add(quota.Period),
+	}
+
+	if requests > quota.Limit {
+		return quotaStatus, auth.QuotaExceeded
+	}
+
+	return quotaStatus, nil
+}
+
+// generateKey returns a new key of the form sl_<prefix>_<secret> together with
+// its prefix and the hash to store.
+func generateKey() (prefix, key, secretHash string, err error) {
+	prefixBytes := make([]byte, constants.PrefixBytes)
+	secretBytes := make([]byte, constants.SecretBytes)
+
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return "", "", "", err
+	}
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", "", "", err
+	}
+
+	prefix = hex.EncodeToString(prefixBytes)
+	key = constants.KeyPrefix + prefix + "_" + base64.RawURLEncoding.EncodeToString(secretBytes)
+
+	return prefix, key, hashKey(key), nil
+}
+
+func parseKey(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, constants.KeyPrefix)
+	if !ok {
+		return "", false
+	}
+
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != 2*constants.PrefixBytes || secret == "" {
+		return "", false
+	}
+
+	return prefix, true
+}
+
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/apikey"
+	"SongsLibrary/internal/apikey/constants"
+	"SongsLibrary/internal/apikey/dtos"
+	"SongsLibrary/internal/apikey/repository/postgres"
+	"SongsLibrary/internal/auth"
+	"SongsLibrary/internal/db/models"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateAPIKeyUseCase_Success(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	auc := NewAPIKeyUseCase(mockRepo)
+
+	createdKey := &models.APIKey{}
+	mockRepo.On("CreateAPIKey", mock.Anything, mock.MatchedBy(func(k *models.APIKey) bool {
+		return k.Name == "ci" && k.Scopes == "editor,reader" && k.QuotaPeriod == constants.QuotaPeriodDay && len(k.Prefix) == 2*constants.PrefixBytes
+	})).Run(func(args mock.Arguments) {
+		*createdKey = *args.Get(1).(*models.APIKey)
+	}).Return(createdKey, nil)
+
+	issuedKey, err := auc.CreateAPIKey(context.Background(), &dtos.CreateAPIKeyDTO{Name: "ci", Scopes: []string{"reader", "editor", "reader"}, QuotaLimit: 100})
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(issuedKey.Key, constants.KeyPrefix+issuedKey.APIKey.Prefix+"_"))
+	assert.Equal(t, hashKey(issuedKey.Key), issuedKey.APIKey.SecretHash)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateAPIKeyUseCase_DuplicatePrefixRetried(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	auc := NewAPIKeyUseCase(mockRepo)
+
+	mockRepo.On("CreateAPIKey", mock.Anything, mock.Anything).Return(nil, apikey.DuplicatePrefix).Once()
+	mockRepo.On("CreateAPIKey", mock.Anything, mock.Anything).Return(&models.APIKey{ID: uuid.New()}, nil).Once()
+
+	_, err := auc.CreateAPIKey(context.Background(), &dtos.CreateAPIKeyDTO{Name: "ci", Scopes: []string{"reader"}})
+
+	assert.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "CreateAPIKey", 2)
+}
+
+func TestCreateAPIKeyUseCase_ExpiredInPast(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	auc := NewAPIKeyUseCase(mockRepo)
+
+	expiresAt := time.Now().Add(-time.Hour)
+	_, err := auc.CreateAPIKey(context.Background(), &dtos.CreateAPIKeyDTO{Name: "ci", Scopes: []string{"reader"}, ExpiresAt: &expiresAt})
+
+	assert.Equal(t, apikey.InvalidInputData, err)
+	mockRepo.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything)
+}
+
+func issueKey(t *testing.T, issuedKey *models.APIKey) string {
+	prefix, key, secretHash, err := generateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuedKey.Prefix, issuedKey.SecretHash = prefix, secretHash
+
+	return key
+}
+
+func TestAuthenticateUseCase_Success(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+	auc := NewAPIKeyUseCase(mockRepo)
+
+	issuedKey := &models.APIKey{ID: uuid.New(), Scopes: "reader,editor", QuotaLimit: 10, QuotaPeriod: constants.QuotaPeriodHour}
+	key := issueKey(t, issuedKey)
+
+	mockRepo.On("GetAPIKeyByPrefix", mock.Anything, issuedKey.Prefix).Return(issuedKey, nil)
+
+	principal, err := auc.Authenticate(context.Background(), auth.Credentials{APIKey: key})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "api-key:"+issuedKey.ID.String(), principal.Subject)
+	assert.Equal(t, []string{"reader", "editor"}, principal.Roles)
+	assert.Equal(t, &auth.Quota{KeyId: issuedKey.ID, Limit: 10, Period: time.Hour}, principal.Quota)
+}
+
+func TestAuthenticateUseCase_NotIssuedKey(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+	auc := NewAPIKeyUseCase(mockRepo)
+
+	for _, key := range []string{"", "change-me", "sl_short_secret"} {
+		_, err := auc.Authenticate(context.Background(), auth.Credentials{APIKey: key})
+
+		assert.Equal(t, auth.MissingCredentials, err, key)
+	}
+	mockRepo.AssertNotCalled(t, "GetAPIKeyByPrefix", mock.Anything, mock.Anything)
+}
+
+func TestAuthenticateUseCase_Rejected(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+
+	for name, issuedKey := range map[string]*models.APIKey{
+		"revoked": {ID: uuid.New(), Scopes: "reader", RevokedAt: &past},
+		"expired": {ID: uuid.New(), Scopes: "reader", ExpiresAt: &past},
+	} {
+		mockRepo := new(postgres.MockRepository)
+		auc := NewAPIKeyUseCase(mockRepo)
+
+		key := issueKey(t, issuedKey)
+		mockRepo.On("GetAPIKeyByPrefix", mock.Anything, issuedKey.Prefix).Return(issuedKey, nil)
+
+		_, err := auc.Authenticate(context.Background(), auth.Credentials{APIKey: key})
+
+		assert.True(t, errors.Is(err, auth.InvalidAPIKey), name)
+	}
+}
+
+func TestAuthenticateUseCase_WrongSecret(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+	auc := NewAPIKeyUseCase(mockRepo)
+
+	issuedKey := &models.APIKey{ID: uuid.New(), Scopes: "admin"}
+	key := issueKey(t, issuedKey)
+
+	mockRepo.On("GetAPIKeyByPrefix", mock.Anything, issuedKey.Prefix).Return(issuedKey, nil)
+
+	_, err := auc.Authenticate(context.Background(), auth.Credentials{APIKey: key + "x"})
+
+	assert.Equal(t, auth.InvalidAPIKey, err)
+}
+
+func TestConsumeQuotaUseCase(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+	auc := NewAPIKeyUseCase(mockRepo)
+	auc.now = func() time.Time { return time.Date(2024, 5, 1, 10, 42, 0, 0, time.UTC) }
+
+	quota := &auth.Quota{KeyId: uuid.New(), Limit: 2, Period: time.Hour}
+	windowStart := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	mockRepo.On("IncrementUsage", mock.Anything, quota.KeyId, windowStart).Return(int64(2), nil).Once()
+	mockRepo.On("IncrementUsage", mock.Anything, quota.KeyId, windowStart).Return(int64(3), nil).Once()
+
+	quotaStatus, err := auc.ConsumeQuota(context.Background(), quota)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &auth.QuotaStatus{Limit: 2, Remaining: 0, Reset: windowStart.Add(time.Hour)}, quotaStatus)
+
+	_, err = auc.ConsumeQuota(context.Background(), quota)
+
+	assert.Equal(t, auth.QuotaExceeded, err)
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"SongsLibrary/internal/auth/constants"
+	"context"
+	"errors"
+	"strings"
+)
+
+// Credentials are the raw credentials of a request, whatever the transport.
+type Credentials struct {
+	APIKey      string
+	BearerToken string
+}
+
+// Authenticator resolves credentials to a principal. It returns
+// MissingCredentials when the credentials it handles are absent, so that
+// authenticators can be chained.
+type Authenticator interface {
+	Authenticate(ctx context.Context, credentials Credentials) (*Principal, error)
+}
+
+// QuotaEnforcer charges one request to a quota. It returns QuotaExceeded, along
+// with the status, once the quota is used up for the current period.
+type QuotaEnforcer interface {
+	ConsumeQuota(ctx context.Context, quota *Quota) (*QuotaStatus, error)
+}
+
+// BearerToken extracts the token of an "Authorization: Bearer <token>" value.
+func BearerToken(authorization string) string {
+	if len(authorization) <= len(constants.BearerPrefix) || !strings.EqualFold(authorization[:len(constants.BearerPrefix)], constants.BearerPrefix) {
+		return ""
+	}
+
+	return strings.TrimSpace(authorization[len(constants.BearerPrefix):])
+}
+
+// PublicError is the error reported to a caller that failed authentication.
+// It hides why a token was rejected; the reason is only logged.
+func PublicError(err error) error {
+	switch {
+	case errors.Is(err, InvalidToken):
+		return InvalidToken
+	case errors.Is(err, InvalidAPIKey):
+		return InvalidAPIKey
+	default:
+		return MissingCredentials
+	}
+}
@@ -0,0 +1,71 @@
+package authenticator
+
+import (
+	"SongsLibrary/internal/auth"
+	"SongsLibrary/internal/auth/constants"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+)
+
+type staticKey struct {
+	hash [sha256.Size]byte
+	role string
+}
+
+// APIKeyAuthenticator accepts a fixed set of API keys, each granting one role.
+// Keys are kept as SHA-256 hashes and compared in constant time.
+type APIKeyAuthenticator struct {
+	keys []staticKey
+}
+
+// NewAPIKeyAuthenticator parses a comma separated list of key:role pairs, e.g.
+// "s3cr3t:admin,partner-key:reader".
+func NewAPIKeyAuthenticator(spec string) (*APIKeyAuthenticator, error) {
+	var keys []staticKey
+
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, role, ok := strings.Cut(pair, ":")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid API_KEYS entry, expected key:role")
+		}
+		if !auth.ValidRole(role) {
+			return nil, fmt.Errorf("%w: %s", auth.UnknownRole, role)
+		}
+
+		keys = append(keys, staticKey{hash: sha256.Sum256([]byte(key)), role: role})
+	}
+
+	return &APIKeyAuthenticator{keys: keys}, nil
+}
+
+func (a *APIKeyAuthenticator) Authenticate(_ context.Context, credentials auth.Credentials) (*auth.Principal, error) {
+	if credentials.APIKey == "" {
+		return nil, auth.MissingCredentials
+	}
+
+	hash := sha256.Sum256([]byte(credentials.APIKey))
+
+	for i, key := range a.keys {
+		if subtle.ConstantTimeCompare(hash[:], key.hash[:]) == 1 {
+			return &auth.Principal{Subject: fmt.Sprintf("api-key:%d", i+1), Roles: []string{key.role}}, nil
+		}
+	}
+
+	return nil, auth.InvalidAPIKey
+}
+
+// Disabled lets every request through as an anonymous admin. It is only used
+// when AUTH_DISABLED is set, e.g. for local development.
+type Disabled struct{}
+
+func (Disabled) Authenticate(context.Context, auth.Credentials) (*auth.Principal, error) {
+	return &auth.Principal{Subject: constants.AnonymousSubject, Roles: []string{constants.RoleAdmin}}, nil
+}
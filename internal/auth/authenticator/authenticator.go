@@ -0,0 +1,73 @@
+package authenticator
+
+import (
+	"SongsLibrary/internal/auth"
+	"context"
+	"errors"
+)
+
+// Chain tries each authenticator in turn until one of them finds the
+// credentials it handles.
+type Chain []auth.Authenticator
+
+func (c Chain) Authenticate(ctx context.Context, credentials auth.Credentials) (*auth.Principal, error) {
+	for _, authenticator := range c {
+		principal, err := authenticator.Authenticate(ctx, credentials)
+		if errors.Is(err, auth.MissingCredentials) {
+			continue
+		}
+
+		return principal, err
+	}
+
+	return nil, auth.MissingCredentials
+}
+
+// Config selects the authenticators to build. APIKeys uses the format of
+// NewAPIKeyAuthenticator; JWKSFile enables bearer tokens. Issued resolves the
+// keys managed through the admin API and is tried first.
+type Config struct {
+	Disabled    bool
+	Issued      auth.Authenticator
+	APIKeys     string
+	JWKSFile    string
+	JWTIssuer   string
+	JWTAudience string
+}
+
+// New builds the authenticator described by config. At least one kind of
+// static credentials has to be configured unless authentication is disabled,
+// otherwise no admin could issue the first managed key.
+func New(config Config) (auth.Authenticator, error) {
+	if config.Disabled {
+		return Disabled{}, nil
+	}
+
+	var chain Chain
+
+	if config.APIKeys != "" {
+		apiKeys, err := NewAPIKeyAuthenticator(config.APIKeys)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, apiKeys)
+	}
+
+	if config.JWKSFile != "" {
+		jwtAuthenticator, err := NewJWTAuthenticator(config.JWKSFile, config.JWTIssuer, config.JWTAudience)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, jwtAuthenticator)
+	}
+
+	if len(chain) == 0 {
+		return nil, auth.NoAuthenticators
+	}
+
+	if config.Issued != nil {
+		chain = append(Chain{config.Issued}, chain...)
+	}
+
+	return chain, nil
+}
@@ -0,0 +1,179 @@
+package authenticator
+
+import (
+	"SongsLibrary/internal/auth"
+	"SongsLibrary/internal/auth/constants"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var hmacSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func writeJWKS(t *testing.T, keys ...map[string]string) string {
+	data, err := json.Marshal(map[string]interface{}{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"alg": "RS256",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func octJWK(kid string) map[string]string {
+	return map[string]string{"kty": "oct", "kid": kid, "alg": "HS256", "k": base64.RawURLEncoding.EncodeToString(hmacSecret)}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signed
+}
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	authenticator, err := NewAPIKeyAuthenticator("admin-key:admin, reader-key:reader")
+	assert.NoError(t, err)
+
+	principal, err := authenticator.Authenticate(context.Background(), auth.Credentials{APIKey: "reader-key"})
+	assert.NoError(t, err)
+	assert.True(t, principal.HasRole(constants.RoleReader))
+	assert.False(t, principal.HasRole(constants.RoleEditor))
+
+	_, err = authenticator.Authenticate(context.Background(), auth.Credentials{APIKey: "wrong"})
+	assert.Equal(t, auth.InvalidAPIKey, err)
+
+	_, err = authenticator.Authenticate(context.Background(), auth.Credentials{})
+	assert.Equal(t, auth.MissingCredentials, err)
+}
+
+func TestAPIKeyAuthenticator_UnknownRole(t *testing.T) {
+	_, err := NewAPIKeyAuthenticator("key:owner")
+
+	assert.True(t, errors.Is(err, auth.UnknownRole))
+}
+
+func TestJWTAuthenticator_HS256AndRS256(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authenticator, err := NewJWTAuthenticator(writeJWKS(t, octJWK("shared"), rsaJWK("rsa-1", rsaKey)), "songs-library", "")
+	assert.NoError(t, err)
+
+	expires := time.Now().Add(time.Hour).Unix()
+
+	hsToken := sign(t, jwt.SigningMethodHS256, "shared", hmacSecret, jwt.MapClaims{"sub": "editor-user", "iss": "songs-library", "exp": expires, "roles": []string{"editor"}})
+	principal, err := authenticator.Authenticate(context.Background(), auth.Credentials{BearerToken: hsToken})
+	assert.NoError(t, err)
+	assert.Equal(t, "editor-user", principal.Subject)
+	assert.True(t, principal.HasRole(constants.RoleEditor))
+	assert.False(t, principal.HasRole(constants.RoleAdmin))
+
+	rsToken := sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, jwt.MapClaims{"sub": "admin-user", "iss": "songs-library", "exp": expires, "roles": []string{"admin"}})
+	principal, err = authenticator.Authenticate(context.Background(), auth.Credentials{BearerToken: rsToken})
+	assert.NoError(t, err)
+	assert.True(t, principal.HasRole(constants.RoleAdmin))
+}
+
+func TestJWTAuthenticator_Rejects(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authenticator, err := NewJWTAuthenticator(writeJWKS(t, rsaJWK("rsa-1", rsaKey)), "", "")
+	assert.NoError(t, err)
+
+	publicKeyAsSecret := []byte(base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()))
+
+	tokens := map[string]string{
+		"expired":            sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()}),
+		"no expiry":          sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, jwt.MapClaims{"sub": "user"}),
+		"unknown kid":        sign(t, jwt.SigningMethodRS256, "rsa-2", rsaKey, jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix()}),
+		"algorithm mismatch": sign(t, jwt.SigningMethodHS256, "rsa-1", publicKeyAsSecret, jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix()}),
+		"garbage":            "not.a.token",
+	}
+
+	for name, token := range tokens {
+		_, err := authenticator.Authenticate(context.Background(), auth.Credentials{BearerToken: token})
+
+		assert.True(t, errors.Is(err, auth.InvalidToken), name)
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Config{})
+	assert.Equal(t, auth.NoAuthenticators, err)
+
+	chain, err := New(Config{APIKeys: "key:editor", JWKSFile: writeJWKS(t, octJWK("shared"))})
+	assert.NoError(t, err)
+
+	principal, err := chain.Authenticate(context.Background(), auth.Credentials{APIKey: "key"})
+	assert.NoError(t, err)
+	assert.True(t, principal.HasRole(constants.RoleEditor))
+
+	_, err = chain.Authenticate(context.Background(), auth.Credentials{})
+	assert.Equal(t, auth.MissingCredentials, err)
+}
+
+// issuedAuthenticator stands in for the managed keys, which leave keys
+// without their prefix to the rest of the chain.
+type issuedAuthenticator struct{}
+
+func (issuedAuthenticator) Authenticate(ctx context.Context, credentials auth.Credentials) (*auth.Principal, error) {
+	if !strings.HasPrefix(credentials.APIKey, "sl_") {
+		return nil, auth.MissingCredentials
+	}
+
+	return &auth.Principal{Subject: "issued", Roles: []string{constants.RoleAdmin}}, nil
+}
+
+func TestNew_Issued(t *testing.T) {
+	issued := issuedAuthenticator{}
+
+	_, err := New(Config{Issued: issued})
+	assert.Equal(t, auth.NoAuthenticators, err)
+
+	chain, err := New(Config{Issued: issued, APIKeys: "key:reader"})
+	assert.NoError(t, err)
+
+	principal, err := chain.Authenticate(context.Background(), auth.Credentials{APIKey: "sl_issued"})
+	assert.NoError(t, err)
+	assert.True(t, principal.HasRole(constants.RoleAdmin))
+
+	principal, err = chain.Authenticate(context.Background(), auth.Credentials{APIKey: "key"})
+	assert.NoError(t, err)
+	assert.False(t, principal.HasRole(constants.RoleEditor))
+}
@@ -0,0 +1,101 @@
+package authenticator
+
+import (
+	"SongsLibrary/internal/auth"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+// verificationKey is a key from the JWKS file together with the only signing
+// method it may verify: HS256 for "oct" keys and RS256 for "RSA" keys.
+type verificationKey struct {
+	kid    string
+	alg    string
+	secret []byte
+	public *rsa.PublicKey
+}
+
+// loadJWKS reads the keys of a JSON Web Key Set file. Keys meant for
+// encryption are skipped.
+func loadJWKS(path string) ([]verificationKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("%w: %s", auth.InvalidJWKS, err.Error())
+	}
+
+	var keys []verificationKey
+	for _, jwk := range set.Keys {
+		if jwk.Use == "enc" {
+			continue
+		}
+
+		key, err := parseJWK(jwk)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %q: %s", auth.InvalidJWKS, jwk.Kid, err.Error())
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no signing keys", auth.InvalidJWKS)
+	}
+
+	return keys, nil
+}
+
+func parseJWK(jwk jsonWebKey) (verificationKey, error) {
+	switch jwk.Kty {
+	case "oct":
+		if jwk.Alg != "" && jwk.Alg != "HS256" {
+			return verificationKey{}, fmt.Errorf("unsupported alg %s", jwk.Alg)
+		}
+
+		secret, err := base64.RawURLEncoding.DecodeString(jwk.K)
+		if err != nil || len(secret) == 0 {
+			return verificationKey{}, fmt.Errorf("invalid k")
+		}
+
+		return verificationKey{kid: jwk.Kid, alg: "HS256", secret: secret}, nil
+	case "RSA":
+		if jwk.Alg != "" && jwk.Alg != "RS256" {
+			return verificationKey{}, fmt.Errorf("unsupported alg %s", jwk.Alg)
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil || len(n) == 0 {
+			return verificationKey{}, fmt.Errorf("invalid n")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return verificationKey{}, fmt.Errorf("invalid e")
+		}
+
+		return verificationKey{kid: jwk.Kid, alg: "RS256", public: &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}}, nil
+	default:
+		return verificationKey{}, fmt.Errorf("unsupported kty %s", jwk.Kty)
+	}
+}
@@ -0,0 +1,92 @@
+package authenticator
+
+import (
+	"SongsLibrary/internal/auth"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTAuthenticator verifies HS256 and RS256 bearer tokens against the keys of
+// a local JWKS file. The roles of the caller come from the "roles" claim.
+type JWTAuthenticator struct {
+	keys    []verificationKey
+	options []jwt.ParserOption
+}
+
+// NewJWTAuthenticator loads the JWKS file at path. issuer and audience are
+// only checked when set.
+func NewJWTAuthenticator(path, issuer, audience string) (*JWTAuthenticator, error) {
+	keys, err := loadJWKS(path)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"HS256", "RS256"}),
+		jwt.WithExpirationRequired(),
+	}
+	if issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+
+	return &JWTAuthenticator{keys: keys, options: options}, nil
+}
+
+type claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles"`
+}
+
+func (a *JWTAuthenticator) Authenticate(_ context.Context, credentials auth.Credentials) (*auth.Principal, error) {
+	if credentials.BearerToken == "" {
+		return nil, auth.MissingCredentials
+	}
+
+	var tokenClaims claims
+	if _, err := jwt.ParseWithClaims(credentials.BearerToken, &tokenClaims, a.keyFunc, a.options...); err != nil {
+		return nil, fmt.Errorf("%w: %s", auth.InvalidToken, err.Error())
+	}
+
+	var roles []string
+	for _, role := range tokenClaims.Roles {
+		if auth.ValidRole(role) {
+			roles = append(roles, role)
+		}
+	}
+
+	return &auth.Principal{Subject: tokenClaims.Subject, Roles: roles}, nil
+}
+
+// keyFunc picks the key named by the "kid" header, or the only key usable
+// with the token algorithm when the header has no kid. The algorithm has to
+// match the key type, so an RSA public key can never be used as an HMAC
+// secret.
+func (a *JWTAuthenticator) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	alg := token.Method.Alg()
+
+	var found *verificationKey
+	for i, key := range a.keys {
+		if key.alg != alg || (kid != "" && key.kid != kid) {
+			continue
+		}
+		if found != nil {
+			return nil, errors.New("token has no kid and several keys match")
+		}
+		found = &a.keys[i]
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("no %s key for kid %q", alg, kid)
+	}
+
+	if found.public != nil {
+		return found.public, nil
+	}
+	return found.secret, nil
+}
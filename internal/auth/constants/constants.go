@@ -0,0 +1,23 @@
+package constants
+
+const (
+	RoleReader = "reader"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+
+	APIKeyHeader        = "X-API-Key"
+	AuthorizationHeader = "Authorization"
+	BearerPrefix        = "Bearer "
+
+	// APIKeyMetadata and AuthorizationMetadata are the gRPC metadata keys,
+	// which are always lower case.
+	APIKeyMetadata        = "x-api-key"
+	AuthorizationMetadata = "authorization"
+
+	AnonymousSubject = "anonymous"
+
+	QuotaLimitHeader     = "X-RateLimit-Limit"
+	QuotaRemainingHeader = "X-RateLimit-Remaining"
+	QuotaResetHeader     = "X-RateLimit-Reset"
+	RetryAfterHeader     = "Retry-After"
+)
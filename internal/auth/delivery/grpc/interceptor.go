@@ -0,0 +1,153 @@
+package grpc
+
+import (
+	"SongsLibrary/internal/auth"
+	"SongsLibrary/internal/auth/constants"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"strings"
+)
+
+// Throttle limits the calls of authenticated principals. AllowPrincipal
+// returns the status error to fail the call with once principal is over its
+// limit.
+type Throttle interface {
+	AllowPrincipal(ctx context.Context, method string, principal *auth.Principal) error
+}
+
+// Interceptor authenticates gRPC calls like the HTTP middleware and checks the
+// role each method requires. methodRoles maps full method names to roles; an
+// empty role makes a method public, and methods missing from the map require
+// the admin role.
+type Interceptor struct {
+	authenticator auth.Authenticator
+	quotas        auth.QuotaEnforcer
+	throttle      Throttle
+	methodRoles   map[string]string
+}
+
+// NewInterceptor takes the same optional quotas and throttle as the HTTP
+// middleware.
+func NewInterceptor(authenticator auth.Authenticator, quotas auth.QuotaEnforcer, throttle Throttle, methodRoles map[string]string) *Interceptor {
+	return &Interceptor{authenticator: authenticator, quotas: quotas, throttle: throttle, methodRoles: methodRoles}
+}
+
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := i.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authorize(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+func (i *Interceptor) authorize(ctx context.Context, method string) (context.Context, error) {
+	role, ok := i.methodRoles[method]
+	if !ok {
+		role = constants.RoleAdmin
+	}
+	if role == "" {
+		return ctx, nil
+	}
+
+	principal, err := i.authenticator.Authenticate(ctx, credentials(ctx))
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, fmt.Sprintf("Authentication failed for %s: %s", method, err.Error()))
+
+		return nil, status.Error(codes.Unauthenticated, auth.PublicError(err).Error())
+	}
+
+	if !principal.HasRole(role) {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, fmt.Sprintf("Denied %s, %s role required", method, role))
+
+		return nil, status.Error(codes.PermissionDenied, auth.Forbidden.Error())
+	}
+
+	if i.throttle != nil {
+		if err := i.throttle.AllowPrincipal(ctx, method, principal); err != nil {
+			return nil, err
+		}
+	}
+
+	if principal.Quota != nil && i.quotas != nil {
+		if err := i.consumeQuota(ctx, method, principal.Quota); err != nil {
+			return nil, err
+		}
+	}
+
+	return auth.NewContext(ctx, principal), nil
+}
+
+// consumeQuota charges the call to quota and sends the quota state as
+// x-ratelimit-* header metadata.
+func (i *Interceptor) consumeQuota(ctx context.Context, method string, quota *auth.Quota) error {
+	quotaStatus, err := i.quotas.ConsumeQuota(ctx, quota)
+	if quotaStatus != nil {
+		_ = grpc.SetHeader(ctx, metadata.Pairs(
+			strings.ToLower(constants.QuotaLimitHeader), strconv.FormatInt(quotaStatus.Limit, 10),
+			strings.ToLower(constants.QuotaRemainingHeader), strconv.FormatInt(quotaStatus.Remaining, 10),
+			strings.ToLower(constants.QuotaResetHeader), strconv.FormatInt(quotaStatus.Reset.Unix(), 10),
+		))
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	logrusCustom.Log(ctx, logrus.ErrorLevel, fmt.Sprintf("Quota check failed for %s with key %s: %s", method, quota.KeyId, err.Error()))
+
+	if errors.Is(err, auth.QuotaExceeded) {
+		return status.Error(codes.ResourceExhausted, auth.QuotaExceeded.Error())
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func credentials(ctx context.Context) auth.Credentials {
+	var credentials auth.Credentials
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return credentials
+	}
+
+	if values := md.Get(constants.APIKeyMetadata); len(values) > 0 {
+		credentials.APIKey = values[0]
+	}
+
+	if values := md.Get(constants.AuthorizationMetadata); len(values) > 0 {
+		credentials.BearerToken = auth.BearerToken(values[0])
+	}
+
+	return credentials
+}
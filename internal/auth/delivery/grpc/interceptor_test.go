@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"SongsLibrary/internal/auth"
+	"SongsLibrary/internal/auth/authenticator"
+	"SongsLibrary/internal/auth/constants"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func setup(t *testing.T) grpc.UnaryServerInterceptor {
+
+	apiKeys, err := authenticator.NewAPIKeyAuthenticator("reader-key:reader")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewInterceptor(apiKeys, nil, nil, map[string]string{
+		"/songsLibrary.Song/GetSongs":   constants.RoleReader,
+		"/songsLibrary.Song/DeleteSong": constants.RoleAdmin,
+		"/grpc.health.v1.Health/Check":  "",
+	}).Unary()
+}
+
+func call(interceptor grpc.UnaryServerInterceptor, method, apiKey string) (string, error) {
+	ctx := context.Background()
+	if apiKey != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(constants.APIKeyMetadata, apiKey))
+	}
+
+	res, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		if principal := auth.FromContext(ctx); principal != nil {
+			return principal.Subject, nil
+		}
+		return "", nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return res.(string), nil
+}
+
+func TestInterceptor(t *testing.T) {
+	interceptor := setup(t)
+
+	_, err := call(interceptor, "/songsLibrary.Song/GetSongs", "")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	subject, err := call(interceptor, "/songsLibrary.Song/GetSongs", "reader-key")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, subject)
+
+	_, err = call(interceptor, "/songsLibrary.Song/DeleteSong", "reader-key")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = call(interceptor, "/songsLibrary.Song/Unlisted", "reader-key")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = call(interceptor, "/grpc.health.v1.Health/Check", "")
+	assert.NoError(t, err)
+}
+
+type quotaAuthenticator struct{}
+
+func (quotaAuthenticator) Authenticate(ctx context.Context, credentials auth.Credentials) (*auth.Principal, error) {
+	return &auth.Principal{Subject: "api-key", Roles: []string{constants.RoleReader}, Quota: &auth.Quota{Limit: 1, Period: time.Hour}}, nil
+}
+
+type exhaustedEnforcer struct{}
+
+func (exhaustedEnforcer) ConsumeQuota(ctx context.Context, quota *auth.Quota) (*auth.QuotaStatus, error) {
+	return &auth.QuotaStatus{Limit: quota.Limit, Reset: time.Now().Add(time.Minute)}, auth.QuotaExceeded
+}
+
+func TestInterceptor_QuotaExceeded(t *testing.T) {
+
+	interceptor := NewInterceptor(quotaAuthenticator{}, exhaustedEnforcer{}, nil, map[string]string{
+		"/songsLibrary.Song/GetSongs": constants.RoleReader,
+	}).Unary()
+
+	_, err := call(interceptor, "/songsLibrary.Song/GetSongs", "")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
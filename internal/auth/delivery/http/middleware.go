@@ -0,0 +1,117 @@
+package http
+
+import (
+	"SongsLibrary/internal/auth"
+	"SongsLibrary/internal/auth/constants"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Throttle limits the requests of authenticated principals. AllowPrincipal
+// aborts the request and returns false once principal is over its limit.
+type Throttle interface {
+	AllowPrincipal(c *gin.Context, principal *auth.Principal) bool
+}
+
+type Middleware struct {
+	authenticator auth.Authenticator
+	quotas        auth.QuotaEnforcer
+	throttle      Throttle
+}
+
+// NewMiddleware authenticates with authenticator, limits principals with
+// throttle and charges metered principals to quotas. quotas and throttle may
+// be nil.
+func NewMiddleware(authenticator auth.Authenticator, quotas auth.QuotaEnforcer, throttle Throttle) *Middleware {
+	return &Middleware{authenticator: authenticator, quotas: quotas, throttle: throttle}
+}
+
+// Authenticate resolves the X-API-Key header or the bearer token of the
+// request to a principal and stores it in the request context. Requests
+// without valid credentials are rejected with 401, and requests over the rate
+// limit of their principal or the quota of their credential with 429.
+// Throttled requests are not charged to the quota.
+func (m *Middleware) Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, err := m.authenticator.Authenticate(c.Request.Context(), credentials(c.Request))
+		if err != nil {
+			logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, fmt.Sprintf("Authentication failed for %s %s: %s", c.Request.Method, c.FullPath(), err.Error()))
+
+			c.Header("WWW-Authenticate", `Bearer realm="api"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": auth.PublicError(err).Error()})
+			return
+		}
+
+		if m.throttle != nil && !m.throttle.AllowPrincipal(c, principal) {
+			return
+		}
+
+		if principal.Quota != nil && m.quotas != nil && !m.consumeQuota(c, principal.Quota) {
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.NewContext(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// RequireRole rejects with 403 requests whose principal ranks below role. It
+// has to run after Authenticate.
+func (m *Middleware) RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := auth.FromContext(c.Request.Context())
+		if principal == nil || !principal.HasRole(role) {
+			logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, fmt.Sprintf("Denied %s %s, %s role required", c.Request.Method, c.FullPath(), role))
+
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": auth.Forbidden.Error()})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// consumeQuota charges the request to quota and reports the quota state in
+// the X-RateLimit headers. It aborts the request and returns false when the
+// quota is used up or cannot be checked.
+func (m *Middleware) consumeQuota(c *gin.Context, quota *auth.Quota) bool {
+	quotaStatus, err := m.quotas.ConsumeQuota(c.Request.Context(), quota)
+	if quotaStatus != nil {
+		c.Header(constants.QuotaLimitHeader, strconv.FormatInt(quotaStatus.Limit, 10))
+		c.Header(constants.QuotaRemainingHeader, strconv.FormatInt(quotaStatus.Remaining, 10))
+		c.Header(constants.QuotaResetHeader, strconv.FormatInt(quotaStatus.Reset.Unix(), 10))
+	}
+
+	if err == nil {
+		return true
+	}
+
+	logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, fmt.Sprintf("Quota check failed for key %s: %s", quota.KeyId, err.Error()))
+
+	if errors.Is(err, auth.QuotaExceeded) {
+		c.Header(constants.RetryAfterHeader, strconv.Itoa(retryAfterSeconds(quotaStatus.Reset)))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": auth.QuotaExceeded.Error()})
+		return false
+	}
+
+	c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	return false
+}
+
+func retryAfterSeconds(reset time.Time) int {
+	return max(int(math.Ceil(time.Until(reset).Seconds())), 1)
+}
+
+func credentials(r *http.Request) auth.Credentials {
+	return auth.Credentials{
+		APIKey:      r.Header.Get(constants.APIKeyHeader),
+		BearerToken: auth.BearerToken(r.Header.Get(constants.AuthorizationHeader)),
+	}
+}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"SongsLibrary/internal/auth"
+	"SongsLibrary/internal/auth/authenticator"
+	"SongsLibrary/internal/auth/constants"
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func setup(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	apiKeys, err := authenticator.NewAPIKeyAuthenticator("reader-key:reader,admin-key:admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewMiddleware(apiKeys, nil, nil)
+
+	r := gin.New()
+	group := r.Group("/api", m.Authenticate())
+	group.GET("/songs", m.RequireRole(constants.RoleReader), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"subject": auth.FromContext(c.Request.Context()).Subject})
+	})
+	group.DELETE("/songs/:id", m.RequireRole(constants.RoleAdmin), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	return r
+}
+
+func performRequest(r *gin.Engine, method, url, apiKey string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, url, nil)
+	if apiKey != "" {
+		req.Header.Set(constants.APIKeyHeader, apiKey)
+	}
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestMiddleware_Unauthenticated(t *testing.T) {
+	r := setup(t)
+
+	w := performRequest(r, http.MethodGet, "/api/songs", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+
+	w = performRequest(r, http.MethodGet, "/api/songs", "wrong-key")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestMiddleware_Roles(t *testing.T) {
+	r := setup(t)
+
+	assert.Equal(t, http.StatusOK, performRequest(r, http.MethodGet, "/api/songs", "reader-key").Code)
+	assert.Equal(t, http.StatusForbidden, performRequest(r, http.MethodDelete, "/api/songs/1", "reader-key").Code)
+	assert.Equal(t, http.StatusOK, performRequest(r, http.MethodDelete, "/api/songs/1", "admin-key").Code)
+}
+
+type quotaAuthenticator struct{}
+
+func (quotaAuthenticator) Authenticate(ctx context.Context, credentials auth.Credentials) (*auth.Principal, error) {
+	return &auth.Principal{Subject: "api-key", Roles: []string{constants.RoleReader}, Quota: &auth.Quota{Limit: 1, Period: time.Hour}}, nil
+}
+
+type countingEnforcer struct {
+	requests int64
+}
+
+func (e *countingEnforcer) ConsumeQuota(ctx context.Context, quota *auth.Quota) (*auth.QuotaStatus, error) {
+	e.requests++
+
+	quotaStatus := &auth.QuotaStatus{Limit: quota.Limit, Remaining: max(quota.Limit-e.requests, 0), Reset: time.Now().Add(time.Minute)}
+	if e.requests > quota.Limit {
+		return quotaStatus, auth.QuotaExceeded
+	}
+
+	return quotaStatus, nil
+}
+
+func TestMiddleware_Quota(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m := NewMiddleware(quotaAuthenticator{}, &countingEnforcer{}, nil)
+
+	r := gin.New()
+	r.GET("/api/songs", m.Authenticate(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := performRequest(r, http.MethodGet, "/api/songs", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get(constants.QuotaLimitHeader))
+	assert.Equal(t, "0", w.Header().Get(constants.QuotaRemainingHeader))
+
+	w = performRequest(r, http.MethodGet, "/api/songs", "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Header().Get(constants.RetryAfterHeader))
+}
+
+type denyingThrottle struct{}
+
+func (denyingThrottle) AllowPrincipal(c *gin.Context, principal *auth.Principal) bool {
+	c.AbortWithStatus(http.StatusTooManyRequests)
+	return false
+}
+
+func TestMiddleware_ThrottledBeforeQuota(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	enforcer := &countingEnforcer{}
+	m := NewMiddleware(quotaAuthenticator{}, enforcer, denyingThrottle{})
+
+	r := gin.New()
+	r.GET("/api/songs", m.Authenticate(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	assert.Equal(t, http.StatusTooManyRequests, performRequest(r, http.MethodGet, "/api/songs", "").Code)
+	assert.Zero(t, enforcer.requests, "throttled requests are not charged to the quota")
+}
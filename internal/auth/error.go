@@ -0,0 +1,14 @@
+package auth
+
+import "errors"
+
+var (
+	MissingCredentials = errors.New("missing credentials, expected an X-API-Key header or a bearer token")
+	InvalidAPIKey      = errors.New("invalid api key")
+	InvalidToken       = errors.New("invalid token")
+	Forbidden          = errors.New("insufficient role for this operation")
+	QuotaExceeded      = errors.New("request quota exceeded")
+	UnknownRole        = errors.New("unknown role, expected reader, editor or admin")
+	InvalidJWKS        = errors.New("invalid jwks file")
+	NoAuthenticators   = errors.New("no authentication configured, set API_KEYS or JWKS_FILE, or AUTH_DISABLED=true")
+)
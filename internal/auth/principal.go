@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"SongsLibrary/internal/auth/constants"
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+// roleRanks orders the roles: every role includes the permissions of the
+// roles ranked below it.
+var roleRanks = map[string]int{
+	constants.RoleReader: 1,
+	constants.RoleEditor: 2,
+	constants.RoleAdmin:  3,
+}
+
+func ValidRole(role string) bool {
+	_, ok := roleRanks[role]
+	return ok
+}
+
+// Principal is the authenticated caller of a request. Quota is set for callers
+// whose requests are metered.
+type Principal struct {
+	Subject string
+	Roles   []string
+	Quota   *Quota
+}
+
+// HasRole reports whether any role of the principal ranks at least as high as
+// required.
+func (p *Principal) HasRole(required string) bool {
+	for _, role := range p.Roles {
+		if roleRanks[role] >= roleRanks[required] {
+			return true
+		}
+	}
+
+	return false
+}
+
+type principalKey struct{}
+
+func NewContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext returns the principal stored by the authentication middleware or
+// interceptor, or nil outside of an authenticated request.
+func FromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
+
+// Quota allows Limit requests per Period to the credential KeyId.
+type Quota struct {
+	KeyId  uuid.UUID
+	Limit  int64
+	Period time.Duration
+}
+
+// QuotaStatus is the state of a quota after a request has been charged to it.
+type QuotaStatus struct {
+	Limit     int64
+	Remaining int64
+	Reset     time.Time
+}
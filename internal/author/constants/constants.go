@@ -0,0 +1,11 @@
+package constants
+
+const (
+	DefaultAuthorsPage     = 1
+	DefaultAuthorsPageSize = 10
+
+	DefaultAuthorSongsPage     = 1
+	DefaultAuthorSongsPageSize = 3
+
+	DbUniqueConstrintErr = "23505"
+)
@@ -0,0 +1,281 @@
+package authorGRPC
+
+import (
+	"SongsLibrary/internal/auth/constants"
+	"SongsLibrary/internal/author"
+	"SongsLibrary/internal/author/dtos"
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/pagination"
+	songgrpc "SongsLibrary/internal/song/delivery/grpc"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"fmt"
+	songv1 "github.com/DanKo-code/Protobuf-For-Songs-Library-Upgraded/protos/gen/go/song"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"time"
+)
+
+type serverGRPC struct {
+	songv1.UnimplementedAuthorServer
+	validate *validator.Validate
+	usecase  author.UseCase
+}
+
+// MethodRoles is the role each Author method requires.
+var MethodRoles = map[string]string{
+	songv1.Author_GetAuthors_FullMethodName:     constants.RoleReader,
+	songv1.Author_GetAuthor_FullMethodName:      constants.RoleReader,
+	songv1.Author_GetAuthorSongs_FullMethodName: constants.RoleReader,
+	songv1.Author_UpdateAuthor_FullMethodName:   constants.RoleEditor,
+	songv1.Author_MergeAuthors_FullMethodName:   constants.RoleEditor,
+	songv1.Author_DeleteAuthor_FullMethodName:   constants.RoleAdmin,
+}
+
+func Register(gRPC *grpc.Server, validator *validator.Validate, usecase author.UseCase) {
+	songv1.RegisterAuthorServer(gRPC, &serverGRPC{
+		validate: validator,
+		usecase:  usecase,
+	})
+}
+
+// validateDTO applies the same rules as the HTTP delivery: gin "binding" tags
+// first, then the custom "validate" tags registered on the shared validator.
+func (s *serverGRPC) validateDTO(dto interface{}) error {
+	if err := binding.Validator.ValidateStruct(dto); err != nil {
+		return err
+	}
+
+	return s.validate.Struct(dto)
+}
+
+func (s *serverGRPC) GetAuthors(ctx context.Context, req *songv1.GetAuthorsRequest) (*songv1.GetAuthorsResponseList, error) {
+
+	gadto := dtos.GetAuthorsDTO{
+		GroupName: req.GetGroupName(),
+		Page:      int(req.GetPage()),
+		PageSize:  int(req.GetPageSize()),
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetAuthors gRPC Hanlder with parameters: %+v", gadto))
+
+	if err := s.validateDTO(gadto); err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, author.InvalidInputData.Error())
+	}
+
+	gadto.SetDefaults()
+	logrusCustom.Log(ctx, logrus.DebugLevel, fmt.Sprintf("Setted default parameters: %+v", gadto))
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	page, err := s.usecase.GetAuthors(ctx, &gadto)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	var authorsResponseList songv1.GetAuthorsResponseList
+	for _, specificAuthor := range page.Authors {
+		authorsResponseList.Authors = append(authorsResponseList.GetAuthors(), &songv1.GetAuthorsResponse{
+			Id:        specificAuthor.ID.String(),
+			GroupName: specificAuthor.GroupName,
+		})
+	}
+	authorsResponseList.Pagination = songgrpc.PaginationToProto(pagination.New(page.Total, gadto.Page, gadto.PageSize))
+
+	return &authorsResponseList, nil
+}
+
+func (s *serverGRPC) GetAuthor(ctx context.Context, req *songv1.GetAuthorRequest) (*songv1.GetAuthorResponse, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetAuthor gRPC Hanlder with parameter: %s", req.GetId()))
+
+	convertedId, err := uuid.Parse(req.GetId())
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, author.InvalidAuthorIdFormat.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	authorToGet, err := s.usecase.GetAuthor(ctx, convertedId)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &songv1.GetAuthorResponse{
+		Id:        authorToGet.ID.String(),
+		GroupName: authorToGet.GroupName,
+	}, nil
+}
+
+func (s *serverGRPC) UpdateAuthor(ctx context.Context, req *songv1.UpdateAuthorRequest) (*songv1.UpdateAuthorResponse, error) {
+
+	fieldsToUpdate := dtos.UpdateAuthorDTO{GroupName: req.GetGroupName()}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered UpdateAuthor gRPC Hanlder with parameter: id: %s, %+v", req.GetId(), fieldsToUpdate))
+
+	convertedId, err := uuid.Parse(req.GetId())
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, author.InvalidAuthorIdFormat.Error())
+	}
+
+	if err := s.validateDTO(fieldsToUpdate); err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, author.InvalidInputData.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	updatedAuthor, err := s.usecase.UpdateAuthor(ctx, &models.Author{
+		ID:        convertedId,
+		GroupName: strings.ToLower(fieldsToUpdate.GroupName),
+	})
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &songv1.UpdateAuthorResponse{
+		Id:        updatedAuthor.ID.String(),
+		GroupName: updatedAuthor.GroupName,
+	}, nil
+}
+
+func (s *serverGRPC) MergeAuthors(ctx context.Context, req *songv1.MergeAuthorsRequest) (*songv1.MergeAuthorsResponse, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered MergeAuthors gRPC Hanlder with parameters: id: %s, source_id: %s", req.GetId(), req.GetSourceId()))
+
+	targetId, err := uuid.Parse(req.GetId())
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, author.InvalidAuthorIdFormat.Error())
+	}
+
+	sourceId, err := uuid.Parse(req.GetSourceId())
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, author.InvalidAuthorIdFormat.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	mergedAuthor, err := s.usecase.MergeAuthors(ctx, targetId, sourceId)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &songv1.MergeAuthorsResponse{
+		Id:        mergedAuthor.ID.String(),
+		GroupName: mergedAuthor.GroupName,
+	}, nil
+}
+
+func (s *serverGRPC) DeleteAuthor(ctx context.Context, req *songv1.DeleteAuthorRequest) (*songv1.DeleteAuthorResponse, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered DeleteAuthor gRPC Hanlder with parameter: %s", req.GetId()))
+
+	convertedId, err := uuid.Parse(req.GetId())
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, author.InvalidAuthorIdFormat.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	deletedAuthor, err := s.usecase.DeleteAuthor(ctx, convertedId)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	return &songv1.DeleteAuthorResponse{
+		Id:        deletedAuthor.ID.String(),
+		GroupName: deletedAuthor.GroupName,
+	}, nil
+}
+
+func (s *serverGRPC) GetAuthorSongs(ctx context.Context, req *songv1.GetAuthorSongsRequest) (*songv1.GetSongsResponseList, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetAuthorSongs gRPC Hanlder with parameter: %s", req.GetId()))
+
+	convertedId, err := uuid.Parse(req.GetId())
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, author.InvalidAuthorIdFormat.Error())
+	}
+
+	gasdto := dtos.GetAuthorSongsDTO{
+		Id:       convertedId,
+		Page:     int(req.GetPage()),
+		PageSize: int(req.GetPageSize()),
+	}
+
+	if err := s.validateDTO(gasdto); err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, author.InvalidInputData.Error())
+	}
+
+	gasdto.SetDefaults()
+	logrusCustom.Log(ctx, logrus.DebugLevel, fmt.Sprintf("Setted default parameters: %+v", gasdto))
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	page, err := s.usecase.GetAuthorSongs(ctx, &gasdto)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	var songsResponseList songv1.GetSongsResponseList
+	for _, specificSong := range page.Songs {
+		songsResponseList.Songs = append(songsResponseList.GetSongs(), &songv1.GetSongsResponse{
+			Id:          specificSong.ID.String(),
+			Name:        specificSong.Name,
+			AuthorId:    specificSong.AuthorId.String(),
+			AuthorName:  specificSong.Author.GroupName,
+			ReleaseDate: specificSong.ReleaseDate.String(),
+			Text:        specificSong.Text,
+			Link:        specificSong.Link,
+		})
+	}
+	songsResponseList.Pagination = songgrpc.PaginationToProto(pagination.New(page.Total, gasdto.Page, gasdto.PageSize))
+
+	return &songsResponseList, nil
+}
+
+func toStatusError(ctx context.Context, err error) error {
+	logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+	switch err.Error() {
+	case author.InvalidInputData.Error(), author.MergeSameAuthor.Error():
+		return status.Error(codes.InvalidArgument, err.Error())
+	case author.AuthorsNotFound.Error(), author.AuthorNotFound.Error(), author.AuthorSongsNotFound.Error():
+		return status.Error(codes.NotFound, err.Error())
+	case author.AuthorAlreadyExists.Error():
+		return status.Error(codes.AlreadyExists, err.Error())
+	case author.AuthorHasSongs.Error(), author.MergeSongConflict.Error():
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,364 @@
+package http
+
+import (
+	"SongsLibrary/internal/author"
+	"SongsLibrary/internal/author/dtos"
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/pagination"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type Handler struct {
+	useCase  author.UseCase
+	validate *validator.Validate
+}
+
+func NewHandler(useCase author.UseCase, validate *validator.Validate) *Handler {
+	return &Handler{
+		useCase:  useCase,
+		validate: validate,
+	}
+}
+
+// GetAuthors
+// @Summary Retrieve a list of authors
+// @Description Fetch a list of authors (groups) ordered by group name, optionally filtered by a part of the group name.
+// @Tags Authors
+// @Produce json
+// @Param group_name query string false "Name of the group" maxlength(100)
+// @Param page query int false "Page number for pagination" minimum(1)
+// @Param page_size query int false "Number of authors per page" minimum(1) maximum(100)
+// @Success 200 {array} models.Author "List of authors"
+// @Header 200 {string} Link "Links to the next, previous, first and last pages"
+// @Failure 400 {object} string "Invalid input data"
+// @Failure 404 {object} string "Authors not found"
+// @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/authors [get]
+func (h *Handler) GetAuthors(c *gin.Context) {
+	var gadto dtos.GetAuthorsDTO
+
+	if err := c.ShouldBindQuery(&gadto); err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": author.InvalidInputData.Error()})
+		return
+	}
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered GetAuthors Hanlder with parameters: %+v", gadto))
+
+	err := h.validate.Struct(gadto)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": author.InvalidInputData.Error()})
+		return
+	}
+
+	gadto.SetDefaults()
+	logrusCustom.Log(c.Request.Context(), logrus.DebugLevel, fmt.Sprintf("Setted default parameters: %+v", gadto))
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	page, err := h.useCase.GetAuthors(ctx, &gadto)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		if err.Error() == author.AuthorsNotFound.Error() {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": author.AuthorsNotFound.Error()})
+			return
+		}
+
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	envelope := pagination.New(page.Total, gadto.Page, gadto.PageSize)
+	c.Header("Link", pagination.LinkHeader(c.Request.URL, envelope))
+
+	c.JSON(http.StatusOK, gin.H{"authors": page.Authors, "pagination": envelope})
+}
+
+// GetAuthor
+// @Summary Retrieve an author by its ID
+// @Description Fetch a single author (group) using its UUID.
+// @Tags Authors
+// @Produce json
+// @Param id path string true "UUID of the author" format(uuid)
+// @Success 200 {object} models.Author "Author details"
+// @Failure 400 {object} string "Invalid author ID format"
+// @Failure 404 {object} string "Author not found"
+// @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/authors/{id} [get]
+func (h *Handler) GetAuthor(c *gin.Context) {
+	id := c.Param("id")
+
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered GetAuthor Hanlder with parameter: %s", id))
+
+	convertedId, err := uuid.Parse(id)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": author.InvalidAuthorIdFormat.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	authorToGet, err := h.useCase.GetAuthor(ctx, convertedId)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"author": authorToGet})
+}
+
+// UpdateAuthor
+// @Summary Rename an author
+// @Description Change the group name of an author. The new name is converted to lowercase and must not be used by another author.
+// @Tags Authors
+// @Produce json
+// @Param id path string true "UUID of the author to rename" format(uuid)
+// @Param fieldsToUpdate body dtos.UpdateAuthorDTO true "New group name"
+// @Success 200 {object} models.Author "Updated author details"
+// @Failure 400 {object} string "Invalid input data"
+// @Failure 404 {object} string "Author not found"
+// @Failure 409 {object} string "Author already exists"
+// @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/authors/{id} [put]
+func (h *Handler) UpdateAuthor(c *gin.Context) {
+	var fieldsToUpdate dtos.UpdateAuthorDTO
+	id := c.Param("id")
+
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered UpdateAuthor Hanlder with parameter: %s", id))
+
+	convertedId, err := uuid.Parse(id)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": author.InvalidAuthorIdFormat.Error()})
+		return
+	}
+
+	if err := c.ShouldBindJSON(&fieldsToUpdate); err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": author.InvalidInputData.Error()})
+		return
+	}
+	logrusCustom.Log(c.Request.Context(), logrus.DebugLevel, fmt.Sprintf("Successfully binded author parameters: %+v", fieldsToUpdate))
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	updatedAuthor, err := h.useCase.UpdateAuthor(ctx, &models.Author{
+		ID:        convertedId,
+		GroupName: strings.ToLower(fieldsToUpdate.GroupName),
+	})
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"updatedAuthor": updatedAuthor})
+}
+
+// MergeAuthors
+// @Summary Merge another author into this one
+// @Description Move all songs of the source author to the author identified by the path ID and delete the source author.
+// @Tags Authors
+// @Produce json
+// @Param id path string true "UUID of the author that receives the songs" format(uuid)
+// @Param mergeAuthorsDTO body dtos.MergeAuthorsDTO true "UUID of the author to merge"
+// @Success 200 {object} models.Author "Merged author details"
+// @Failure 400 {object} string "Invalid input data"
+// @Failure 404 {object} string "Author not found"
+// @Failure 409 {object} string "Both authors have a song with the same name"
+// @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/authors/{id}/merge [post]
+func (h *Handler) MergeAuthors(c *gin.Context) {
+	var mergeAuthorsDTO dtos.MergeAuthorsDTO
+	id := c.Param("id")
+
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered MergeAuthors Hanlder with parameter: %s", id))
+
+	targetId, err := uuid.Parse(id)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": author.InvalidAuthorIdFormat.Error()})
+		return
+	}
+
+	if err := c.ShouldBindJSON(&mergeAuthorsDTO); err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": author.InvalidInputData.Error()})
+		return
+	}
+
+	sourceId, err := uuid.Parse(mergeAuthorsDTO.SourceId)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": author.InvalidAuthorIdFormat.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	mergedAuthor, err := h.useCase.MergeAuthors(ctx, targetId, sourceId)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"mergedAuthor": mergedAuthor})
+}
+
+// DeleteAuthor
+// @Summary Delete an author by its ID
+// @Description Remove an author that has no songs left. Delete or merge its songs first.
+// @Tags Authors
+// @Produce json
+// @Param id path string true "UUID of the author to delete" format(uuid)
+// @Success 200 {object} models.Author "Deleted author details"
+// @Failure 400 {object} string "Invalid author ID format"
+// @Failure 404 {object} string "Author not found"
+// @Failure 409 {object} string "Author still has songs"
+// @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/authors/{id} [delete]
+func (h *Handler) DeleteAuthor(c *gin.Context) {
+	id := c.Param("id")
+
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered DeleteAuthor Hanlder with parameter: %s", id))
+
+	convertedId, err := uuid.Parse(id)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": author.InvalidAuthorIdFormat.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	deletedAuthor, err := h.useCase.DeleteAuthor(ctx, convertedId)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deletedAuthor": deletedAuthor})
+}
+
+// GetAuthorSongs
+// @Summary Retrieve songs of an author
+// @Description Fetch the songs of a specific author ordered by name, with pagination.
+// @Tags Authors
+// @Produce json
+// @Param id path string true "UUID of the author" format(uuid)
+// @Param page query int false "Page number for pagination" minimum(1)
+// @Param page_size query int false "Number of songs per page" minimum(1) maximum(100)
+// @Success 200 {array} models.Song "List of songs"
+// @Header 200 {string} Link "Links to the next, previous, first and last pages"
+// @Failure 400 {object} string "Invalid input data"
+// @Failure 404 {object} string "Author or songs not found"
+// @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/authors/{id}/songs [get]
+func (h *Handler) GetAuthorSongs(c *gin.Context) {
+	var gasdto dtos.GetAuthorSongsDTO
+	id := c.Param("id")
+
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered GetAuthorSongs Hanlder with parameter: %s", id))
+
+	convertedId, err := uuid.Parse(id)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": author.InvalidAuthorIdFormat.Error()})
+		return
+	}
+
+	if err := c.ShouldBindQuery(&gasdto); err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": author.InvalidInputData.Error()})
+		return
+	}
+	gasdto.Id = convertedId
+
+	gasdto.SetDefaults()
+	logrusCustom.Log(c.Request.Context(), logrus.DebugLevel, fmt.Sprintf("Setted default parameters: %+v", gasdto))
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	page, err := h.useCase.GetAuthorSongs(ctx, &gasdto)
+	if err != nil {
+		h.abortWithError(c, err)
+		return
+	}
+
+	envelope := pagination.New(page.Total, gasdto.Page, gasdto.PageSize)
+	c.Header("Link", pagination.LinkHeader(c.Request.URL, envelope))
+
+	c.JSON(http.StatusOK, gin.H{"songs": page.Songs, "pagination": envelope})
+}
+
+func (h *Handler) abortWithError(c *gin.Context, err error) {
+	logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+	switch err.Error() {
+	case author.InvalidInputData.Error(), author.MergeSameAuthor.Error():
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case author.AuthorNotFound.Error(), author.AuthorSongsNotFound.Error():
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case author.AuthorAlreadyExists.Error(), author.AuthorHasSongs.Error(), author.MergeSongConflict.Error():
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
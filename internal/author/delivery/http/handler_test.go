@@ -0,0 +1,168 @@
+package http
+
+import (
+	"SongsLibrary/internal/auth/authenticator"
+	authhttp "SongsLibrary/internal/auth/delivery/http"
+	"SongsLibrary/internal/author"
+	"SongsLibrary/internal/author/dtos"
+	"SongsLibrary/internal/author/usecase"
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/pagination"
+	"SongsLibrary/internal/validators"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func setup() (*gin.Engine, *usecase.MockAuthorUseCase) {
+	gin.SetMode(gin.TestMode)
+
+	mockUseCase := new(usecase.MockAuthorUseCase)
+
+	validate := validator.New()
+	err := validate.RegisterValidation("DateValidation", validators.DateValidation)
+	if err != nil {
+		logrusCustom.Log(context.Background(), logrus.ErrorLevel, err.Error())
+	}
+
+	r := gin.Default()
+	RegisterHTTPEndpoints(r, mockUseCase, validate, authhttp.NewMiddleware(authenticator.Disabled{}, nil, nil))
+
+	return r, mockUseCase
+}
+
+func performRequest(r *gin.Engine, method, url string, body io.Reader) (*httptest.ResponseRecorder, error) {
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	r.ServeHTTP(w, req)
+	return w, nil
+}
+
+func TestGetAuthorsHandler_Success(t *testing.T) {
+	r, mockUseCase := setup()
+
+	gadto := dtos.GetAuthorsDTO{GroupName: "круг", Page: 1, PageSize: 10}
+
+	mockAuthors := []models.Author{{ID: uuid.New(), GroupName: "михаил круг"}}
+
+	mockUseCase.On("GetAuthors", mock.Anything, &gadto).Return(&dtos.AuthorsPage{Authors: mockAuthors, Total: 1}, nil)
+
+	w, err := performRequest(r, http.MethodGet, "/api/authors?group_name=круг", nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Authors    []models.Author       `json:"authors"`
+		Pagination pagination.Pagination `json:"pagination"`
+	}
+	err = json.NewDecoder(w.Body).Decode(&response)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	assert.Equal(t, mockAuthors, response.Authors)
+	assert.Equal(t, pagination.Pagination{Total: 1, Page: 1, PageSize: 10, TotalPages: 1}, response.Pagination)
+}
+
+func TestGetAuthorHandler_InvalidId(t *testing.T) {
+	r, _ := setup()
+
+	w, err := performRequest(r, http.MethodGet, "/api/authors/123", nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]string
+	err = json.NewDecoder(w.Body).Decode(&response)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	assert.Equal(t, author.InvalidAuthorIdFormat.Error(), response["error"])
+}
+
+func TestUpdateAuthorHandler_Conflict(t *testing.T) {
+	r, mockUseCase := setup()
+
+	id := uuid.New()
+
+	mockUseCase.On("UpdateAuthor", mock.Anything, &models.Author{ID: id, GroupName: "михаил круг"}).Return(nil, author.AuthorAlreadyExists)
+
+	w, err := performRequest(r, http.MethodPut, "/api/authors/"+id.String(), strings.NewReader(`{"group_name":"Михаил Круг"}`))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestMergeAuthorsHandler_Success(t *testing.T) {
+	r, mockUseCase := setup()
+
+	targetId := uuid.New()
+	sourceId := uuid.New()
+	mergedAuthor := &models.Author{ID: targetId, GroupName: "creedence clearwater revival"}
+
+	mockUseCase.On("MergeAuthors", mock.Anything, targetId, sourceId).Return(mergedAuthor, nil)
+
+	w, err := performRequest(r, http.MethodPost, "/api/authors/"+targetId.String()+"/merge", strings.NewReader(`{"source_id":"`+sourceId.String()+`"}`))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestDeleteAuthorHandler_HasSongs(t *testing.T) {
+	r, mockUseCase := setup()
+
+	id := uuid.New()
+
+	mockUseCase.On("DeleteAuthor", mock.Anything, id).Return(nil, author.AuthorHasSongs)
+
+	w, err := performRequest(r, http.MethodDelete, "/api/authors/"+id.String(), nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestGetAuthorSongsHandler_Success(t *testing.T) {
+	r, mockUseCase := setup()
+
+	id := uuid.New()
+	gasdto := dtos.GetAuthorSongsDTO{Id: id, Page: 2, PageSize: 3}
+
+	mockSongs := []models.Song{{ID: uuid.New(), Name: "фраер", AuthorId: id}}
+
+	mockUseCase.On("GetAuthorSongs", mock.Anything, &gasdto).Return(&dtos.AuthorSongsPage{Songs: mockSongs, Total: 7}, nil)
+
+	w, err := performRequest(r, http.MethodGet, "/api/authors/"+id.String()+"/songs?page=2", nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Link"), `</api/authors/`+id.String()+`/songs?page=1>; rel="prev"`)
+	assert.Contains(t, w.Header().Get("Link"), `</api/authors/`+id.String()+`/songs?page=3>; rel="next"`)
+	mockUseCase.AssertExpectations(t)
+}
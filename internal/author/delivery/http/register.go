@@ -0,0 +1,27 @@
+package http
+
+import (
+	"SongsLibrary/internal/auth/constants"
+	authhttp "SongsLibrary/internal/auth/delivery/http"
+	"SongsLibrary/internal/author"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+func RegisterHTTPEndpoints(router *gin.Engine, uc author.UseCase, validator *validator.Validate, authMiddleware *authhttp.Middleware) {
+	h := NewHandler(uc, validator)
+
+	authEndPoints := router.Group("/api", authMiddleware.Authenticate())
+	{
+		reader := authMiddleware.RequireRole(constants.RoleReader)
+		editor := authMiddleware.RequireRole(constants.RoleEditor)
+		admin := authMiddleware.RequireRole(constants.RoleAdmin)
+
+		authEndPoints.GET("/authors", reader, h.GetAuthors)
+		authEndPoints.GET("/authors/:id", reader, h.GetAuthor)
+		authEndPoints.PUT("/authors/:id", editor, h.UpdateAuthor)
+		authEndPoints.POST("/authors/:id/merge", editor, h.MergeAuthors)
+		authEndPoints.DELETE("/authors/:id", admin, h.DeleteAuthor)
+		authEndPoints.GET("/authors/:id/songs", reader, h.GetAuthorSongs)
+	}
+}
@@ -0,0 +1,29 @@
+package dtos
+
+import (
+	"SongsLibrary/internal/author/constants"
+	"SongsLibrary/internal/db/models"
+	"github.com/google/uuid"
+)
+
+type GetAuthorSongsDTO struct {
+	Id       uuid.UUID `form:"id"`
+	Page     int       `form:"page" binding:"omitempty,min=1"`
+	PageSize int       `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+func (dto *GetAuthorSongsDTO) SetDefaults() {
+	if dto.Page == 0 {
+		dto.Page = constants.DefaultAuthorSongsPage
+	}
+	if dto.PageSize == 0 {
+		dto.PageSize = constants.DefaultAuthorSongsPageSize
+	}
+}
+
+// AuthorSongsPage is one page of GetAuthorSongs with the number of songs of
+// the author across all pages.
+type AuthorSongsPage struct {
+	Songs []models.Song
+	Total int64
+}
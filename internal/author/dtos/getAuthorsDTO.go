@@ -0,0 +1,28 @@
+package dtos
+
+import (
+	"SongsLibrary/internal/author/constants"
+	"SongsLibrary/internal/db/models"
+)
+
+type GetAuthorsDTO struct {
+	GroupName string `form:"group_name" binding:"omitempty,max=100"`
+	Page      int    `form:"page" binding:"omitempty,min=1"`
+	PageSize  int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+func (dto *GetAuthorsDTO) SetDefaults() {
+	if dto.Page == 0 {
+		dto.Page = constants.DefaultAuthorsPage
+	}
+	if dto.PageSize == 0 {
+		dto.PageSize = constants.DefaultAuthorsPageSize
+	}
+}
+
+// AuthorsPage is one page of GetAuthors with the number of authors matching
+// the filters across all pages.
+type AuthorsPage struct {
+	Authors []models.Author
+	Total   int64
+}
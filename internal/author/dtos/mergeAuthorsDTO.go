@@ -0,0 +1,5 @@
+package dtos
+
+type MergeAuthorsDTO struct {
+	SourceId string `json:"source_id" binding:"required"`
+}
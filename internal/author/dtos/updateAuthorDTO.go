@@ -0,0 +1,5 @@
+package dtos
+
+type UpdateAuthorDTO struct {
+	GroupName string `json:"group_name" binding:"required,max=100"`
+}
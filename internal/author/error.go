@@ -0,0 +1,15 @@
+package author
+
+import "errors"
+
+var (
+	InvalidInputData      = errors.New("invalid input data")
+	AuthorsNotFound       = errors.New("authors not found")
+	AuthorNotFound        = errors.New("author not found")
+	AuthorAlreadyExists   = errors.New("author with this group name already exists")
+	AuthorHasSongs        = errors.New("author still has songs")
+	AuthorSongsNotFound   = errors.New("author songs not found")
+	InvalidAuthorIdFormat = errors.New("invalid author id format")
+	MergeSameAuthor       = errors.New("cannot merge author into itself")
+	MergeSongConflict     = errors.New("both authors have a song with the same name")
+)
@@ -0,0 +1,341 @@
+package postgres
+
+import (
+	"SongsLibrary/internal/author"
+	"SongsLibrary/internal/author/constants"
+	"SongsLibrary/internal/author/dtos"
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/outbox"
+	outboxconstants "SongsLibrary/internal/outbox/constants"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+)
+
+type AuthorRepository struct {
+	db *gorm.DB
+}
+
+func NewAuthorRepository(db *gorm.DB) *AuthorRepository {
+	return &AuthorRepository{db: db}
+}
+
+func (ar *AuthorRepository) GetAuthors(ctx context.Context, gadto *dtos.GetAuthorsDTO) (*dtos.AuthorsPage, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetAuthors Repository with parameters: %+v", gadto))
+
+	var authors []models.Author
+
+	query := ar.db.WithContext(ctx).Model(&models.Author{})
+
+	if gadto.GroupName != "" {
+		query = query.Where("group_name LIKE ?", "%"+strings.ToLower(gadto.GroupName)+"%")
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	offset := (gadto.Page - 1) * gadto.PageSize
+	query = query.Order("group_name").Offset(offset).Limit(gadto.PageSize)
+
+	if err := query.Find(&authors).Error; err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	if len(authors) == 0 {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, author.AuthorsNotFound.Error())
+
+		return nil, author.AuthorsNotFound
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetAuthors Repository with authors: %+v", authors))
+
+	return &dtos.AuthorsPage{Authors: authors, Total: total}, nil
+}
+
+func (ar *AuthorRepository) GetAuthor(ctx context.Context, id uuid.UUID) (*models.Author, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetAuthor Repository with parameter: id:%s", id.String()))
+
+	authorToGet, err := findAuthor(ar.db.WithContext(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetAuthor Repository with author: %+v", authorToGet))
+
+	return authorToGet, nil
+}
+
+// UpdateAuthor renames an author and records a SongUpdated event for each of
+// its songs in the same transaction, as the group is part of their payload.
+func (ar *AuthorRepository) UpdateAuthor(ctx context.Context, fieldsToUpdate *models.Author) (*models.Author, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered UpdateAuthor Repository with parameter: %+v", fieldsToUpdate))
+
+	var updatedAuthor *models.Author
+
+	err := ar.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(&models.Author{}).
+			Where("id = ?", fieldsToUpdate.ID).
+			Update("group_name", fieldsToUpdate.GroupName)
+		if result.Error != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, result.Error.Error())
+
+			if isUniqueViolation(result.Error) {
+				return author.AuthorAlreadyExists
+			}
+
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, author.AuthorNotFound.Error())
+
+			return author.AuthorNotFound
+		}
+
+		var err error
+		updatedAuthor, err = findAuthor(tx, fieldsToUpdate.ID)
+		if err != nil {
+			return err
+		}
+
+		var renamedSongs []models.Song
+		if err := tx.Where("author_id = ?", fieldsToUpdate.ID).Find(&renamedSongs).Error; err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+			return err
+		}
+
+		return addSongEvents(tx, renamedSongs, updatedAuthor)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting UpdateAuthor Repository with updated author: %+v", updatedAuthor))
+
+	return updatedAuthor, nil
+}
+
+// MergeAuthors moves every song of the source author to the target author and
+// removes the source author, all in one transaction which also records a
+// SongUpdated event for every moved song.
+func (ar *AuthorRepository) MergeAuthors(ctx context.Context, targetId, sourceId uuid.UUID) (*models.Author, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered MergeAuthors Repository with parameters: targetId:%s, sourceId:%s", targetId.String(), sourceId.String()))
+
+	var mergedAuthor *models.Author
+
+	err := ar.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+
+		if _, err := findAuthor(tx, sourceId); err != nil {
+			return err
+		}
+
+		if _, err := findAuthor(tx, targetId); err != nil {
+			return err
+		}
+
+		var movedSongs []models.Song
+		if err := tx.Model(&movedSongs).
+			Clauses(clause.Returning{}).
+			Where("author_id = ?", sourceId).
+			Update("author_id", targetId).Error; err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+			if isUniqueViolation(err) {
+				return author.MergeSongConflict
+			}
+
+			return err
+		}
+
+		if err := tx.Delete(&models.Author{}, "id = ?", sourceId).Error; err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+			return err
+		}
+
+		var err error
+		mergedAuthor, err = findAuthor(tx, targetId)
+		if err != nil {
+			return err
+		}
+
+		return addSongEvents(tx, movedSongs, mergedAuthor)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting MergeAuthors Repository with merged author: %+v", mergedAuthor))
+
+	return mergedAuthor, nil
+}
+
+func (ar *AuthorRepository) DeleteAuthor(ctx context.Context, id uuid.UUID) (*models.Author, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered DeleteAuthor Repository with parameter: %s", id.String()))
+
+	var authorToDelete *models.Author
+
+	err := ar.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+
+		var err error
+		authorToDelete, err = findAuthor(tx, id)
+		if err != nil {
+			return err
+		}
+
+		var songsCount int64
+		if err := tx.Model(&models.Song{}).Where("author_id = ?", id).Count(&songsCount).Error; err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+			return err
+		}
+
+		if songsCount > 0 {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, author.AuthorHasSongs.Error())
+
+			return author.AuthorHasSongs
+		}
+
+		if err := tx.Delete(&models.Author{}, "id = ?", id).Error; err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting DeleteAuthor Repository with deleted author: %+v", authorToDelete))
+
+	return authorToDelete, nil
+}
+
+func (ar *AuthorRepository) GetAuthorSongs(ctx context.Context, gasdto *dtos.GetAuthorSongsDTO) (*dtos.AuthorSongsPage, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetAuthorSongs Repository with parameters: %+v", gasdto))
+
+	if _, err := findAuthor(ar.db.WithContext(ctx), gasdto.Id); err != nil {
+		return nil, err
+	}
+
+	var songs []models.Song
+
+	query := ar.db.WithContext(ctx).Model(&models.Song{}).Where("author_id = ?", gasdto.Id)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	offset := (gasdto.Page - 1) * gasdto.PageSize
+
+	if err := query.
+		Order("name").
+		Offset(offset).
+		Limit(gasdto.PageSize).
+		Preload("Author").
+		Find(&songs).Error; err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	if len(songs) == 0 {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, author.AuthorSongsNotFound.Error())
+
+		return nil, author.AuthorSongsNotFound
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetAuthorSongs Repository with songs: %+v", songs))
+
+	return &dtos.AuthorSongsPage{Songs: songs, Total: total}, nil
+}
+
+func findAuthor(db *gorm.DB, id uuid.UUID) (*models.Author, error) {
+	var authorToGet models.Author
+
+	if err := db.First(&authorToGet, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logrusCustom.Log(db.Statement.Context, logrus.ErrorLevel, author.AuthorNotFound.Error())
+
+			return nil, author.AuthorNotFound
+		}
+
+		logrusCustom.Log(db.Statement.Context, logrus.ErrorLevel, err.Error())
+		return nil, err
+	}
+
+	return &authorToGet, nil
+}
+
+// addSongEvents records in the outbox as part of tx a SongUpdated event for
+// each of songs, which now belong to target.
+func addSongEvents(tx *gorm.DB, songs []models.Song, target *models.Author) error {
+	events, err := movedSongEvents(songs, target)
+	if err != nil {
+		logrusCustom.Log(tx.Statement.Context, logrus.ErrorLevel, err.Error())
+
+		return err
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	if err := tx.Create(&events).Error; err != nil {
+		logrusCustom.Log(tx.Statement.Context, logrus.ErrorLevel, err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+// movedSongEvents returns the SongUpdated outbox events of songs that belong
+// to target, whether moved to it or renamed with it. The payload is the song
+// after the change as for any other update.
+func movedSongEvents(songs []models.Song, target *models.Author) ([]models.OutboxEvent, error) {
+	events := make([]models.OutboxEvent, 0, len(songs))
+	for i := range songs {
+		songs[i].AuthorId = target.ID
+		songs[i].Author = *target
+
+		outboxEvent, err := outbox.NewOutboxEvent(outboxconstants.SongUpdated, songs[i].ID, &songs[i])
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *outboxEvent)
+	}
+
+	return events, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == constants.DbUniqueConstrintErr
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"SongsLibrary/internal/db/models"
+	outboxconstants "SongsLibrary/internal/outbox/constants"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMovedSongEvents(t *testing.T) {
+	target := &models.Author{ID: uuid.New(), GroupName: "muse"}
+
+	movedSongs := []models.Song{
+		{ID: uuid.New(), Name: "hysteria", AuthorId: target.ID},
+		{ID: uuid.New(), Name: "uprising", AuthorId: target.ID},
+	}
+
+	events, err := movedSongEvents(movedSongs, target)
+
+	assert.NoError(t, err)
+	assert.Len(t, events, len(movedSongs))
+
+	for i, event := range events {
+		assert.Equal(t, outboxconstants.SongUpdated, event.Type)
+		assert.Equal(t, movedSongs[i].ID, event.AggregateId)
+
+		var payload models.Song
+		assert.NoError(t, json.Unmarshal([]byte(event.Payload), &payload))
+		assert.Equal(t, movedSongs[i].Name, payload.Name)
+		assert.Equal(t, target.ID, payload.AuthorId)
+		assert.Equal(t, "muse", payload.Author.GroupName, "the payload carries the new author")
+	}
+}
+
+func TestMovedSongEvents_NoSongs(t *testing.T) {
+	events, err := movedSongEvents(nil, &models.Author{ID: uuid.New()})
+
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+}
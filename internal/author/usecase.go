@@ -0,0 +1,17 @@
+package author
+
+import (
+	"SongsLibrary/internal/author/dtos"
+	"SongsLibrary/internal/db/models"
+	"context"
+	"github.com/google/uuid"
+)
+
+type UseCase interface {
+	GetAuthors(context.Context, *dtos.GetAuthorsDTO) (*dtos.AuthorsPage, error)
+	GetAuthor(ctx context.Context, id uuid.UUID) (*models.Author, error)
+	UpdateAuthor(context.Context, *models.Author) (*models.Author, error)
+	MergeAuthors(ctx context.Context, targetId, sourceId uuid.UUID) (*models.Author, error)
+	DeleteAuthor(ctx context.Context, id uuid.UUID) (*models.Author, error)
+	GetAuthorSongs(context.Context, *dtos.GetAuthorSongsDTO) (*dtos.AuthorSongsPage, error)
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/author/dtos"
+	"SongsLibrary/internal/db/models"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuthorUseCase struct {
+	mock.Mock
+}
+
+func (m *MockAuthorUseCase) GetAuthors(ctx context.Context, gadto *dtos.GetAuthorsDTO) (*dtos.AuthorsPage, error) {
+	args := m.Called(ctx, gadto)
+	if page, ok := args.Get(0).(*dtos.AuthorsPage); ok {
+		return page, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthorUseCase) GetAuthor(ctx context.Context, id uuid.UUID) (*models.Author, error) {
+	args := m.Called(ctx, id)
+	if author, ok := args.Get(0).(*models.Author); ok {
+		return author, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthorUseCase) UpdateAuthor(ctx context.Context, author *models.Author) (*models.Author, error) {
+	args := m.Called(ctx, author)
+	if updatedAuthor, ok := args.Get(0).(*models.Author); ok {
+		return updatedAuthor, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthorUseCase) MergeAuthors(ctx context.Context, targetId, sourceId uuid.UUID) (*models.Author, error) {
+	args := m.Called(ctx, targetId, sourceId)
+	if mergedAuthor, ok := args.Get(0).(*models.Author); ok {
+		return mergedAuthor, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthorUseCase) DeleteAuthor(ctx context.Context, id uuid.UUID) (*models.Author, error) {
+	args := m.Called(ctx, id)
+	if author, ok := args.Get(0).(*models.Author); ok {
+		return author, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockAuthorUseCase) GetAuthorSongs(ctx context.Context, gasdto *dtos.GetAuthorSongsDTO) (*dtos.AuthorSongsPage, error) {
+	args := m.Called(ctx, gasdto)
+	if page, ok := args.Get(0).(*dtos.AuthorSongsPage); ok {
+		return page, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
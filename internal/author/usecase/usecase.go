@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/author"
+	"SongsLibrary/internal/author/dtos"
+	"SongsLibrary/internal/db/models"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type AuthorUseCase struct {
+	authorRepo author.Repository
+}
+
+func NewAuthorUseCase(authorRepo author.Repository) *AuthorUseCase {
+	return &AuthorUseCase{authorRepo: authorRepo}
+}
+
+func (auc *AuthorUseCase) GetAuthors(ctx context.Context, gadto *dtos.GetAuthorsDTO) (*dtos.AuthorsPage, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetAuthors UseCase with parameters: %+v", gadto))
+
+	page, err := auc.authorRepo.GetAuthors(ctx, gadto)
+	if err != nil {
+		return nil, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetAuthors UseCase with authors: %+v", page.Authors))
+
+	return page, nil
+}
+
+func (auc *AuthorUseCase) GetAuthor(ctx context.Context, id uuid.UUID) (*models.Author, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetAuthor UseCase with parameter: %s", id.String()))
+
+	authorToGet, err := auc.authorRepo.GetAuthor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetAuthor UseCase with author: %+v", authorToGet))
+
+	return authorToGet, nil
+}
+
+func (auc *AuthorUseCase) UpdateAuthor(ctx context.Context, fieldsToUpdate *models.Author) (*models.Author, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered UpdateAuthor UseCase with parameters: %+v", fieldsToUpdate))
+
+	if fieldsToUpdate.GroupName == "" {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, author.InvalidInputData.Error())
+
+		return nil, author.InvalidInputData
+	}
+
+	updatedAuthor, err := auc.authorRepo.UpdateAuthor(ctx, fieldsToUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting UpdateAuthor UseCase with updated author: %+v", updatedAuthor))
+
+	return updatedAuthor, nil
+}
+
+func (auc *AuthorUseCase) MergeAuthors(ctx context.Context, targetId, sourceId uuid.UUID) (*models.Author, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered MergeAuthors UseCase with parameters: targetId:%s, sourceId:%s", targetId.String(), sourceId.String()))
+
+	if targetId == sourceId {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, author.MergeSameAuthor.Error())
+
+		return nil, author.MergeSameAuthor
+	}
+
+	mergedAuthor, err := auc.authorRepo.MergeAuthors(ctx, targetId, sourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting MergeAuthors UseCase with merged author: %+v", mergedAuthor))
+
+	return mergedAuthor, nil
+}
+
+func (auc *AuthorUseCase) DeleteAuthor(ctx context.Context, id uuid.UUID) (*models.Author, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered DeleteAuthor UseCase with parameter: %s", id.String()))
+
+	deletedAuthor, err := auc.authorRepo.DeleteAuthor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting DeleteAuthor UseCase with deleted author: %+v", deletedAuthor))
+
+	return deletedAuthor, nil
+}
+
+func (auc *AuthorUseCase) GetAuthorSongs(ctx context.Context, gasdto *dtos.GetAuthorSongsDTO) (*dtos.AuthorSongsPage, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetAuthorSongs UseCase with parameters: %+v", gasdto))
+
+	page, err := auc.authorRepo.GetAuthorSongs(ctx, gasdto)
+	if err != nil {
+		return nil, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetAuthorSongs UseCase with songs: %+v", page.Songs))
+
+	return page, nil
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/author"
+	"SongsLibrary/internal/author/dtos"
+	"SongsLibrary/internal/author/repository/postgres"
+	"SongsLibrary/internal/db/models"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestGetAuthorsUseCase_Success(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	auc := NewAuthorUseCase(mockRepo)
+
+	gadto := &dtos.GetAuthorsDTO{Page: 1, PageSize: 10}
+	mockAuthors := []models.Author{{ID: uuid.New(), GroupName: "testgroup"}}
+
+	mockRepo.On("GetAuthors", mock.Anything, gadto).Return(&dtos.AuthorsPage{Authors: mockAuthors, Total: 1}, nil)
+
+	page, err := auc.GetAuthors(context.Background(), gadto)
+
+	assert.NoError(t, err)
+	assert.Equal(t, mockAuthors, page.Authors)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMergeAuthorsUseCase_SameAuthor(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	auc := NewAuthorUseCase(mockRepo)
+
+	id := uuid.New()
+	mergedAuthor, err := auc.MergeAuthors(context.Background(), id, id)
+
+	assert.Nil(t, mergedAuthor)
+	assert.ErrorIs(t, err, author.MergeSameAuthor)
+	mockRepo.AssertNotCalled(t, "MergeAuthors", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateAuthorUseCase_EmptyName(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	auc := NewAuthorUseCase(mockRepo)
+
+	updatedAuthor, err := auc.UpdateAuthor(context.Background(), &models.Author{ID: uuid.New()})
+
+	assert.Nil(t, updatedAuthor)
+	assert.ErrorIs(t, err, author.InvalidInputData)
+}
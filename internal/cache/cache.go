@@ -0,0 +1,11 @@
+package cache
+
+// Cache stores values by key. Implementations are safe for concurrent use and
+// may drop entries at any time, so a miss only means the value has to be
+// loaded again.
+type Cache[V any] interface {
+	Get(key string) (V, bool)
+	Set(key string, value V)
+	Delete(key string)
+	Purge()
+}
@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type entry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+// LRU keeps at most size entries for ttl each, evicting the least recently
+// used entry when it is full.
+type LRU[V any] struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List
+	entries map[string]*list.Element
+	now     func() time.Time
+}
+
+func NewLRU[V any](size int, ttl time.Duration) *LRU[V] {
+	return &LRU[V]{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+		now:     time.Now,
+	}
+}
+
+func (c *LRU[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	cached := element.Value.(*entry[V])
+	if !c.now().Before(cached.expiresAt) {
+		c.remove(element)
+
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(element)
+
+	return cached.value, true
+}
+
+func (c *LRU[V]) Set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+
+	if element, ok := c.entries[key]; ok {
+		cached := element.Value.(*entry[V])
+		cached.value, cached.expiresAt = value, expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[V]{key: key, value: value, expiresAt: expiresAt})
+
+	if c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *LRU[V]) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+func (c *LRU[V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	clear(c.entries)
+}
+
+// Len returns the number of entries, including expired ones not yet removed.
+func (c *LRU[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *LRU[V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*entry[V]).key)
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLRU_Eviction(t *testing.T) {
+	c := NewLRU[int](2, time.Minute)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a")
+	c.Set("c", 3)
+
+	_, ok := c.Get("b")
+	assert.False(t, ok, "least recently used entry is evicted")
+
+	value, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestLRU_TTL(t *testing.T) {
+	now := time.Now()
+
+	c := NewLRU[string](10, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", "value")
+
+	now = now.Add(59 * time.Second)
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestLRU_DeleteAndPurge(t *testing.T) {
+	c := NewLRU[int](10, time.Minute)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	c.Delete("a")
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	c.Purge()
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+}
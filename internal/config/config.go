@@ -0,0 +1,224 @@
+package config
+
+import (
+	"SongsLibrary/internal/httpclient"
+	providercacheconstants "SongsLibrary/internal/providercache/constants"
+	songconstants "SongsLibrary/internal/song/constants"
+	"SongsLibrary/internal/song/provider"
+	tracingconstants "SongsLibrary/internal/tracing/constants"
+	"SongsLibrary/pkg/logger"
+	"time"
+)
+
+// Config holds every setting of the service. Each field is read from the
+// environment variable in its env tag, which also names its command line flag
+// (DB_HOST becomes -db-host), and from the YAML key in its yaml tag. Fields
+// tagged secret are masked in the logs, see Secrets.
+type Config struct {
+	App       App       `yaml:"app"`
+	DB        DB        `yaml:"db"`
+	Auth      Auth      `yaml:"auth"`
+	RateLimit RateLimit `yaml:"rate_limit"`
+	SongCache SongCache `yaml:"song_cache"`
+	Jobs      Jobs      `yaml:"jobs"`
+	Events    Events    `yaml:"events"`
+	Providers Providers `yaml:"providers"`
+	Tracing   Tracing   `yaml:"tracing"`
+	Logging   Logging   `yaml:"logging"`
+}
+
+// App lists in TrustedProxies the IPs and CIDRs whose X-Forwarded-For and
+// X-Real-IP headers are believed. Without any, the client IP is the address of
+// the connection.
+type App struct {
+	HTTPPort       string   `yaml:"http_port" env:"APP_PORT"`
+	GRPCPort       string   `yaml:"grpc_port" env:"GRPC_PORT"`
+	SwaggerPath    string   `yaml:"swagger_path" env:"SWAGGER_PATH"`
+	TrustedProxies []string `yaml:"trusted_proxies" env:"TRUSTED_PROXIES"`
+}
+
+type DB struct {
+	Host     string `yaml:"host" env:"DB_HOST"`
+	Port     string `yaml:"port" env:"DB_PORT"`
+	User     string `yaml:"user" env:"DB_USER"`
+	Password string `yaml:"password" env:"DB_PASSWORD" secret:"value"`
+	Name     string `yaml:"name" env:"DB_NAME"`
+	SSLMode  string `yaml:"sslmode" env:"DB_SLLMODE"`
+}
+
+type Auth struct {
+	Disabled    bool   `yaml:"disabled" env:"AUTH_DISABLED"`
+	APIKeys     string `yaml:"api_keys" env:"API_KEYS" secret:"keys"`
+	JWKSFile    string `yaml:"jwks_file" env:"JWKS_FILE"`
+	JWTIssuer   string `yaml:"jwt_issuer" env:"JWT_ISSUER"`
+	JWTAudience string `yaml:"jwt_audience" env:"JWT_AUDIENCE"`
+}
+
+// RateLimit configures token-bucket limits per route. Default and Routes
+// limit authenticated principals; IPDefault and IPRoutes limit client IPs
+// before authentication, and are meant to be looser, as several partners may
+// share an egress IP.
+type RateLimit struct {
+	Default   string `yaml:"default" env:"RATE_LIMIT_DEFAULT"`
+	Routes    string `yaml:"routes" env:"RATE_LIMITS"`
+	IPDefault string `yaml:"ip_default" env:"RATE_LIMIT_IP_DEFAULT"`
+	IPRoutes  string `yaml:"ip_routes" env:"RATE_LIMITS_IP"`
+}
+
+// SongCache configures the read-through cache of song pages and lyrics; a
+// Size of 0 disables it.
+type SongCache struct {
+	Size int           `yaml:"size" env:"SONG_CACHE_SIZE"`
+	TTL  time.Duration `yaml:"ttl" env:"SONG_CACHE_TTL"`
+}
+
+type Jobs struct {
+	Workers int `yaml:"workers" env:"JOB_WORKERS"`
+}
+
+// Events selects the publisher of song lifecycle events. An empty Publisher
+// selects Kafka when brokers are configured.
+type Events struct {
+	Publisher    string   `yaml:"publisher" env:"EVENT_PUBLISHER"`
+	KafkaBrokers []string `yaml:"kafka_brokers" env:"KAFKA_BROKERS"`
+	KafkaTopic   string   `yaml:"kafka_topic" env:"KAFKA_TOPIC"`
+}
+
+// Providers configures the metadata providers, tried in Order. A CacheTTL of
+// 0 disables the response cache, a BreakerFailures of 0 the circuit breakers.
+type Providers struct {
+	Order            []string      `yaml:"order" env:"METADATA_PROVIDERS"`
+	LocalFile        string        `yaml:"local_file" env:"LOCAL_PROVIDER_FILE"`
+	SongDataAddr     string        `yaml:"songdata_addr" env:"SONGDATA_ADDR"`
+	CacheTTL         time.Duration `yaml:"cache_ttl" env:"PROVIDER_CACHE_TTL"`
+	CacheNegativeTTL time.Duration `yaml:"cache_negative_ttl" env:"PROVIDER_CACHE_NEGATIVE_TTL"`
+	Timeout          time.Duration `yaml:"timeout" env:"PROVIDER_TIMEOUT"`
+	MaxRetries       int           `yaml:"max_retries" env:"PROVIDER_MAX_RETRIES"`
+	BreakerFailures  int           `yaml:"breaker_failures" env:"PROVIDER_BREAKER_FAILURES"`
+	BreakerCooldown  time.Duration `yaml:"breaker_cooldown" env:"PROVIDER_BREAKER_COOLDOWN"`
+	Musixmatch       Musixmatch    `yaml:"musixmatch"`
+	Genius           Genius        `yaml:"genius"`
+}
+
+// Musixmatch configures the Musixmatch API; a Timeout of 0 falls back to the
+// timeout shared by the providers.
+type Musixmatch struct {
+	BaseURL    string        `yaml:"base_url" env:"MMLAPI_BASE_URL"`
+	SongPath   string        `yaml:"song_path" env:"MMLAPI_GET_SONG_IP_PATH"`
+	LyricsPath string        `yaml:"lyrics_path" env:"MMLAPI_GET_LYRICS_PATH"`
+	APIKey     string        `yaml:"api_key" env:"MMLAPI_API_KEY" secret:"value"`
+	Timeout    time.Duration `yaml:"timeout" env:"MMLAPI_TIMEOUT"`
+}
+
+// Genius configures the Genius API; a Timeout of 0 falls back to the timeout
+// shared by the providers.
+type Genius struct {
+	BaseURL       string        `yaml:"base_url" env:"GAPI_BASE_URL"`
+	SearchPath    string        `yaml:"search_path" env:"GAPI_GET_SONG_RELEASE_DATE"`
+	Authorization string        `yaml:"authorization" env:"GAPI_AUTHORIZATION" secret:"authorization"`
+	Timeout       time.Duration `yaml:"timeout" env:"GAPI_TIMEOUT"`
+}
+
+// Tracing selects the span exporter: none, otlp (OTLP over gRPC to the
+// host:port OTLPEndpoint) or stdout (JSON to File, or standard output when
+// it is empty). SampleRatio applies to traces started by this service.
+type Tracing struct {
+	Exporter     string  `yaml:"exporter" env:"TRACING_EXPORTER"`
+	OTLPEndpoint string  `yaml:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT"`
+	OTLPInsecure bool    `yaml:"otlp_insecure" env:"TRACING_OTLP_INSECURE"`
+	File         string  `yaml:"file" env:"TRACING_FILE"`
+	SampleRatio  float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+	ServiceName  string  `yaml:"service_name" env:"TRACING_SERVICE_NAME"`
+}
+
+// Logging selects the level, format (json or text) and output (stdout, stderr
+// or file) of the logs. The file output is rotated at MaxSizeMB, keeping
+// MaxBackups files for MaxAgeDays; 0 keeps all of them. The values of the
+// RedactQueryParams and RedactHeaders are masked next to the secrets.
+type Logging struct {
+	Level             string   `yaml:"level" env:"LOG_LEVEL"`
+	Format            string   `yaml:"format" env:"LOG_FORMAT"`
+	Output            string   `yaml:"output" env:"LOG_OUTPUT"`
+	File              string   `yaml:"file" env:"LOG_FILE"`
+	MaxSizeMB         int      `yaml:"max_size_mb" env:"LOG_MAX_SIZE_MB"`
+	MaxBackups        int      `yaml:"max_backups" env:"LOG_MAX_BACKUPS"`
+	MaxAgeDays        int      `yaml:"max_age_days" env:"LOG_MAX_AGE_DAYS"`
+	RedactQueryParams []string `yaml:"redact_query_params" env:"LOG_REDACT_QUERY_PARAMS"`
+	RedactHeaders     []string `yaml:"redact_headers" env:"LOG_REDACT_HEADERS"`
+}
+
+// Default returns the settings used for every key that is not configured.
+// Credentials and the database location have no defaults.
+func Default() *Config {
+	policy := httpclient.DefaultPolicy()
+
+	return &Config{
+		App: App{
+			HTTPPort:    "3023",
+			GRPCPort:    "3026",
+			SwaggerPath: "/swagger/*any",
+		},
+		DB: DB{
+			Port:    "5432",
+			SSLMode: "disable",
+		},
+		SongCache: SongCache{
+			Size: songconstants.DefaultCacheSize,
+			TTL:  songconstants.DefaultCacheTTL,
+		},
+		Events: Events{
+			KafkaTopic: "songs.events",
+		},
+		Providers: Providers{
+			Order:            []string{provider.SongDataName, provider.MusixmatchName, provider.GeniusName},
+			SongDataAddr:     "127.0.0.1:3024",
+			CacheTTL:         providercacheconstants.DefaultTTL,
+			CacheNegativeTTL: providercacheconstants.DefaultNegativeTTL,
+			Timeout:          policy.Timeout,
+			MaxRetries:       policy.MaxRetries,
+			BreakerFailures:  policy.BreakerFailures,
+			BreakerCooldown:  policy.BreakerCooldown,
+			Musixmatch: Musixmatch{
+				BaseURL:    "https://api.musixmatch.com/ws/1.1/",
+				SongPath:   "track.search?q_artist=%s&q_track=%s&apikey=%s",
+				LyricsPath: "track.lyrics.get?commontrack_id=%s&apikey=%s",
+			},
+			Genius: Genius{
+				BaseURL:    "https://api.genius.com/",
+				SearchPath: "search?q=%s %s",
+			},
+		},
+		Tracing: Tracing{
+			Exporter:     tracingconstants.ExporterNone,
+			OTLPEndpoint: "localhost:4317",
+			SampleRatio:  1,
+			ServiceName:  "songs-library",
+		},
+		Logging: Logging{
+			Level:             "info",
+			Format:            logger.FormatJSON,
+			Output:            logger.OutputStdout,
+			File:              "app.log",
+			MaxSizeMB:         100,
+			MaxBackups:        5,
+			MaxAgeDays:        28,
+			RedactQueryParams: []string{"apikey", "api_key", "access_token", "token", "password"},
+			RedactHeaders:     []string{"Authorization", "X-API-Key", "Cookie", "Set-Cookie"},
+		},
+	}
+}
+
+// Policy returns the client policy of a provider whose own timeout is timeout.
+func (p Providers) Policy(timeout time.Duration) httpclient.Policy {
+	policy := httpclient.DefaultPolicy()
+
+	policy.Timeout = p.Timeout
+	if timeout > 0 {
+		policy.Timeout = timeout
+	}
+	policy.MaxRetries = p.MaxRetries
+	policy.BreakerFailures = p.BreakerFailures
+	policy.BreakerCooldown = p.BreakerCooldown
+
+	return policy
+}
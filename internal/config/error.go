@@ -0,0 +1,7 @@
+package config
+
+import "errors"
+
+var (
+	InvalidConfig = errors.New("invalid configuration")
+)
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Load builds the configuration from, in increasing order of precedence, the
+// defaults, the YAML file given by -config or CONFIG_FILE, the environment and
+// the command line. It registers -config and one flag per key on flags before
+// parsing args. The returned error lists every missing or invalid key.
+func Load(flags *flag.FlagSet, args []string) (*Config, error) {
+	config := Default()
+
+	keys := fields(reflect.ValueOf(config).Elem())
+
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "YAML configuration file")
+
+	overrides := make(map[string]*string, len(keys))
+	for _, key := range keys {
+		overrides[key.env] = flags.String(flagName(key.env), "", fmt.Sprintf("overrides %s", key.env))
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *configFile != "" {
+		if err := loadFile(config, *configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	setByFlag := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) {
+		setByFlag[f.Name] = true
+	})
+
+	var invalid problems
+
+	for _, key := range keys {
+		value := os.Getenv(key.env)
+		if setByFlag[flagName(key.env)] {
+			value = *overrides[key.env]
+		}
+		if value == "" {
+			continue
+		}
+
+		if err := set(key.value, value); err != nil {
+			invalid.add("%s: %s", key.env, err.Error())
+		}
+	}
+
+	invalid = append(invalid, config.validate()...)
+
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("%w:\n  - %s", InvalidConfig, strings.Join(invalid, "\n  - "))
+	}
+
+	return config, nil
+}
+
+func loadFile(config *Config, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%w: %w", InvalidConfig, err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: %s: %w", InvalidConfig, path, err)
+	}
+
+	return nil
+}
+
+type field struct {
+	env    string
+	secret string
+	value  reflect.Value
+}
+
+// fields lists the settable fields of the struct v that carry an env tag,
+// descending into nested structs.
+func fields(v reflect.Value) []field {
+	var found []field
+
+	for i := 0; i < v.NumField(); i++ {
+		value := v.Field(i)
+
+		if env := v.Type().Field(i).Tag.Get("env"); env != "" {
+			found = append(found, field{env: env, secret: v.Type().Field(i).Tag.Get("secret"), value: value})
+			continue
+		}
+
+		if value.Kind() == reflect.Struct {
+			found = append(found, fields(value)...)
+		}
+	}
+
+	return found
+}
+
+func flagName(env string) string {
+	return strings.ToLower(strings.ReplaceAll(env, "_", "-"))
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// set parses raw into the field v. Lists are comma separated.
+func set(v reflect.Value, raw string) error {
+	if v.Type() == durationType {
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+		v.SetInt(int64(duration))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		v.SetBool(parsed)
+	case reflect.Int:
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		v.SetInt(int64(parsed))
+	case reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		v.SetFloat(parsed)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported setting type %s", v.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func load(args ...string) (*Config, error) {
+	return Load(flag.NewFlagSet("test", flag.ContinueOnError), args)
+}
+
+func setRequired(t *testing.T) {
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_USER", "songs")
+	t.Setenv("DB_NAME", "songs")
+	t.Setenv("API_KEYS", "secret:admin")
+	t.Setenv("METADATA_PROVIDERS", "local")
+	t.Setenv("LOCAL_PROVIDER_FILE", "songs.json")
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	setRequired(t)
+
+	config, err := load()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "3023", config.App.HTTPPort)
+	assert.Equal(t, "5432", config.DB.Port)
+	assert.Equal(t, []string{"local"}, config.Providers.Order)
+	assert.Equal(t, "127.0.0.1:3024", config.Providers.SongDataAddr)
+	assert.Equal(t, 30*time.Second, config.Providers.Policy(0).BreakerCooldown)
+	assert.Equal(t, time.Second, config.Providers.Policy(time.Second).Timeout)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	setRequired(t)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "app:\n  http_port: 8080\n  grpc_port: 9090\nsong_cache:\n  ttl: 1m\nevents:\n  kafka_brokers: [kafka-1:9092]\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("GRPC_PORT", "9191")
+	t.Setenv("APP_PORT", "8181")
+
+	config, err := load("-app-port", "8282", "-kafka-brokers", "kafka-1:9092, kafka-2:9092")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "8282", config.App.HTTPPort)
+	assert.Equal(t, "9191", config.App.GRPCPort)
+	assert.Equal(t, time.Minute, config.SongCache.TTL)
+	assert.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, config.Events.KafkaBrokers)
+}
+
+func TestLoad_UnknownFileKey(t *testing.T) {
+	setRequired(t)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("app:\n  htp_port: 8080\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := load("-config", path)
+
+	assert.True(t, errors.Is(err, InvalidConfig))
+	assert.ErrorContains(t, err, "htp_port")
+}
+
+func TestLoad_ListsEveryProblem(t *testing.T) {
+	t.Setenv("DB_USER", "songs")
+	t.Setenv("API_KEYS", "secret:admin")
+	t.Setenv("SONG_CACHE_TTL", "soon")
+	t.Setenv("JOB_WORKERS", "-1")
+	t.Setenv("METADATA_PROVIDERS", "musixmatch,lastfm")
+	t.Setenv("RATE_LIMIT_DEFAULT", "fast")
+	t.Setenv("RATE_LIMITS_IP", "POST /api/songs")
+	t.Setenv("TRACING_SAMPLE_RATIO", "2")
+	t.Setenv("LOG_LEVEL", "loud")
+	t.Setenv("LOG_OUTPUT", "syslog")
+
+	_, err := load("-grpc-port", "http")
+
+	assert.True(t, errors.Is(err, InvalidConfig))
+	for _, problem := range []string{
+		"SONG_CACHE_TTL: invalid duration",
+		"GRPC_PORT: invalid port",
+		"DB_HOST is required",
+		"DB_NAME is required",
+		"RATE_LIMIT_DEFAULT:",
+		"RATE_LIMITS_IP:",
+		"JOB_WORKERS: must not be negative",
+		"MMLAPI_API_KEY is required by the musixmatch provider",
+		`METADATA_PROVIDERS: unknown provider "lastfm"`,
+		"TRACING_SAMPLE_RATIO: must be between 0 and 1",
+		"LOG_LEVEL:",
+		`LOG_OUTPUT: unknown output "syslog"`,
+	} {
+		assert.ErrorContains(t, err, problem)
+	}
+	assert.NotContains(t, err.Error(), "DB_USER")
+}
+
+func TestLoad_AuthRequired(t *testing.T) {
+	setRequired(t)
+	t.Setenv("API_KEYS", "")
+
+	_, err := load()
+	assert.ErrorContains(t, err, "API_KEYS or JWKS_FILE is required")
+
+	_, err = load("-auth-disabled", "true")
+	assert.NoError(t, err)
+}
+
+func TestSecrets(t *testing.T) {
+	setRequired(t)
+	t.Setenv("DB_PASSWORD", "db-password")
+	t.Setenv("API_KEYS", "admin-key:admin, reader-key:reader")
+	t.Setenv("MMLAPI_API_KEY", "mm-key")
+	t.Setenv("GAPI_AUTHORIZATION", "Bearer genius-token")
+
+	config, err := load()
+	assert.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		"db-password",
+		"admin-key:admin, reader-key:reader", "admin-key", "reader-key",
+		"mm-key",
+		"Bearer genius-token", "genius-token",
+	}, config.Secrets())
+}
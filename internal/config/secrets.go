@@ -0,0 +1,39 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Secrets returns the values of the settings tagged secret, which must never
+// be logged. The tag tells how a value is used: "value" as is, "keys" as an
+// API_KEYS list whose keys are returned without their roles, and
+// "authorization" as a header value whose credentials are also returned
+// without their scheme.
+func (c *Config) Secrets() []string {
+	var secrets []string
+
+	for _, key := range fields(reflect.ValueOf(c).Elem()) {
+		value := key.value.String()
+		if key.secret == "" || value == "" {
+			continue
+		}
+
+		secrets = append(secrets, value)
+
+		switch key.secret {
+		case "keys":
+			for _, pair := range strings.Split(value, ",") {
+				if apiKey, _, _ := strings.Cut(strings.TrimSpace(pair), ":"); apiKey != "" {
+					secrets = append(secrets, apiKey)
+				}
+			}
+		case "authorization":
+			if _, credentials, ok := strings.Cut(value, " "); ok {
+				secrets = append(secrets, strings.TrimSpace(credentials))
+			}
+		}
+	}
+
+	return secrets
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	outboxconstants "SongsLibrary/internal/outbox/constants"
+	"SongsLibrary/internal/ratelimit"
+	"SongsLibrary/internal/song/provider"
+	tracingconstants "SongsLibrary/internal/tracing/constants"
+	"SongsLibrary/pkg/logger"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type problems []string
+
+func (p *problems) add(format string, args ...any) {
+	*p = append(*p, fmt.Sprintf(format, args...))
+}
+
+func (p *problems) required(key, value string) {
+	if value == "" {
+		p.add("%s is required", key)
+	}
+}
+
+func (p *problems) port(key, value string) {
+	if number, err := strconv.Atoi(value); err != nil || number <= 0 || number > 65535 {
+		p.add("%s: invalid port %q", key, value)
+	}
+}
+
+func (p *problems) positive(key string, value time.Duration) {
+	if value <= 0 {
+		p.add("%s: must be positive, got %s", key, value)
+	}
+}
+
+func notNegative[T int | time.Duration](p *problems, key string, value T) {
+	if value < 0 {
+		p.add("%s: must not be negative, got %v", key, value)
+	}
+}
+
+// validate returns one problem per missing or invalid key. Keys that are only
+// needed by an optional component are required once that component is enabled.
+func (c *Config) validate() problems {
+	var p problems
+
+	p.port("APP_PORT", c.App.HTTPPort)
+	p.port("GRPC_PORT", c.App.GRPCPort)
+	p.required("SWAGGER_PATH", c.App.SwaggerPath)
+	for _, proxy := range c.App.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			p.add("TRUSTED_PROXIES: %q is neither an IP nor a CIDR", proxy)
+		}
+	}
+
+	p.required("DB_HOST", c.DB.Host)
+	p.port("DB_PORT", c.DB.Port)
+	p.required("DB_USER", c.DB.User)
+	p.required("DB_NAME", c.DB.Name)
+
+	if !c.Auth.Disabled && c.Auth.APIKeys == "" && c.Auth.JWKSFile == "" {
+		p.add("API_KEYS or JWKS_FILE is required unless AUTH_DISABLED is true")
+	}
+
+	if c.RateLimit.Default != "" {
+		if _, err := ratelimit.ParseRule(c.RateLimit.Default); err != nil {
+			p.add("RATE_LIMIT_DEFAULT: %s", err.Error())
+		}
+	}
+	if _, err := ratelimit.ParseRules(c.RateLimit.Routes); err != nil {
+		p.add("RATE_LIMITS: %s", err.Error())
+	}
+	if c.RateLimit.IPDefault != "" {
+		if _, err := ratelimit.ParseRule(c.RateLimit.IPDefault); err != nil {
+			p.add("RATE_LIMIT_IP_DEFAULT: %s", err.Error())
+		}
+	}
+	if _, err := ratelimit.ParseRules(c.RateLimit.IPRoutes); err != nil {
+		p.add("RATE_LIMITS_IP: %s", err.Error())
+	}
+
+	notNegative(&p, "SONG_CACHE_SIZE", c.SongCache.Size)
+	p.positive("SONG_CACHE_TTL", c.SongCache.TTL)
+
+	notNegative(&p, "JOB_WORKERS", c.Jobs.Workers)
+
+	switch strings.ToLower(c.Events.Publisher) {
+	case "", outboxconstants.EventPublisherMemory:
+	case outboxconstants.EventPublisherKafka:
+		if len(c.Events.KafkaBrokers) == 0 {
+			p.add("KAFKA_BROKERS is required when EVENT_PUBLISHER is kafka")
+		}
+	default:
+		p.add("EVENT_PUBLISHER: unknown publisher %q", c.Events.Publisher)
+	}
+	if len(c.Events.KafkaBrokers) > 0 {
+		p.required("KAFKA_TOPIC", c.Events.KafkaTopic)
+	}
+
+	c.Providers.validate(&p)
+
+	switch c.Tracing.Exporter {
+	case tracingconstants.ExporterNone, tracingconstants.ExporterStdout:
+	case tracingconstants.ExporterOTLP:
+		p.required("TRACING_OTLP_ENDPOINT", c.Tracing.OTLPEndpoint)
+	default:
+		p.add("TRACING_EXPORTER: unknown exporter %q, expected none, otlp or stdout", c.Tracing.Exporter)
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		p.add("TRACING_SAMPLE_RATIO: must be between 0 and 1, got %v", c.Tracing.SampleRatio)
+	}
+	p.required("TRACING_SERVICE_NAME", c.Tracing.ServiceName)
+
+	if _, err := logrus.ParseLevel(c.Logging.Level); err != nil {
+		p.add("LOG_LEVEL: %s", err.Error())
+	}
+	switch c.Logging.Format {
+	case logger.FormatJSON, logger.FormatText:
+	default:
+		p.add("LOG_FORMAT: unknown format %q, expected json or text", c.Logging.Format)
+	}
+	switch c.Logging.Output {
+	case logger.OutputStdout, logger.OutputStderr:
+	case logger.OutputFile:
+		p.required("LOG_FILE", c.Logging.File)
+	default:
+		p.add("LOG_OUTPUT: unknown output %q, expected stdout, stderr or file", c.Logging.Output)
+	}
+	notNegative(&p, "LOG_MAX_SIZE_MB", c.Logging.MaxSizeMB)
+	notNegative(&p, "LOG_MAX_BACKUPS", c.Logging.MaxBackups)
+	notNegative(&p, "LOG_MAX_AGE_DAYS", c.Logging.MaxAgeDays)
+
+	return p
+}
+
+func (c *Providers) validate(p *problems) {
+	requiredBy := func(name, key, value string) {
+		if value == "" {
+			p.add("%s is required by the %s provider", key, name)
+		}
+	}
+
+	if len(c.Order) == 0 {
+		p.add("METADATA_PROVIDERS must name at least one provider")
+	}
+
+	for _, name := range c.Order {
+		switch name {
+		case provider.SongDataName:
+			requiredBy(name, "SONGDATA_ADDR", c.SongDataAddr)
+		case provider.MusixmatchName:
+			requiredBy(name, "MMLAPI_BASE_URL", c.Musixmatch.BaseURL)
+			requiredBy(name, "MMLAPI_GET_SONG_IP_PATH", c.Musixmatch.SongPath)
+			requiredBy(name, "MMLAPI_GET_LYRICS_PATH", c.Musixmatch.LyricsPath)
+			requiredBy(name, "MMLAPI_API_KEY", c.Musixmatch.APIKey)
+		case provider.GeniusName:
+			requiredBy(name, "GAPI_BASE_URL", c.Genius.BaseURL)
+			requiredBy(name, "GAPI_GET_SONG_RELEASE_DATE", c.Genius.SearchPath)
+			requiredBy(name, "GAPI_AUTHORIZATION", c.Genius.Authorization)
+		case provider.LocalName:
+			requiredBy(name, "LOCAL_PROVIDER_FILE", c.LocalFile)
+		default:
+			p.add("METADATA_PROVIDERS: unknown provider %q", name)
+		}
+	}
+
+	notNegative(p, "PROVIDER_CACHE_TTL", c.CacheTTL)
+	notNegative(p, "PROVIDER_CACHE_NEGATIVE_TTL", c.CacheNegativeTTL)
+	p.positive("PROVIDER_TIMEOUT", c.Timeout)
+	notNegative(p, "MMLAPI_TIMEOUT", c.Musixmatch.Timeout)
+	notNegative(p, "GAPI_TIMEOUT", c.Genius.Timeout)
+	notNegative(p, "PROVIDER_MAX_RETRIES", c.MaxRetries)
+	notNegative(p, "PROVIDER_BREAKER_FAILURES", c.BreakerFailures)
+	p.positive("PROVIDER_BREAKER_COOLDOWN", c.BreakerCooldown)
+}
@@ -1,8 +0,0 @@
-package constants
-
-type Protocol string
-
-const (
-	HTTP Protocol = "HTTP"
-	GRPC Protocol = "GRPC"
-)
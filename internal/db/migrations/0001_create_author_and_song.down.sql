@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS song;
+DROP TABLE IF EXISTS author;
@@ -0,0 +1,20 @@
+-- Tables created by AutoMigrate before versioned migrations existed are
+-- adopted as they are.
+CREATE TABLE IF NOT EXISTS author (
+    id         uuid PRIMARY KEY,
+    group_name varchar(255) UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS song (
+    id           uuid PRIMARY KEY,
+    name         varchar(255),
+    author_id    uuid NOT NULL,
+    release_date timestamptz,
+    text         text,
+    link         text,
+    CONSTRAINT fk_song_author FOREIGN KEY (author_id) REFERENCES author (id) ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_song_author ON song (name, author_id);
+CREATE INDEX IF NOT EXISTS idx_song_name_id ON song (name, id);
+CREATE INDEX IF NOT EXISTS idx_song_release_date ON song (release_date);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_song_search_vector;
+ALTER TABLE song DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE song DROP COLUMN IF EXISTS language;
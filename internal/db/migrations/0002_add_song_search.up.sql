@@ -0,0 +1,20 @@
+-- Songs stored before the language column existed are assigned a text search
+-- configuration based on their lyrics.
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'song' AND column_name = 'language'
+    ) THEN
+        ALTER TABLE song ADD COLUMN language regconfig NOT NULL DEFAULT 'simple';
+
+        UPDATE song SET language = CASE WHEN (name || text) ~* '[а-яё]' THEN 'russian' ELSE 'english' END::regconfig;
+    END IF;
+END $$;
+
+ALTER TABLE song ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector(language, coalesce(name, '')), 'A') ||
+    setweight(to_tsvector(language, coalesce(text, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_song_search_vector ON song USING GIN (search_vector);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS job (
+    id         uuid PRIMARY KEY,
+    type       varchar(64) NOT NULL,
+    status     varchar(32) NOT NULL,
+    payload    jsonb NOT NULL,
+    song_id    uuid,
+    error      text,
+    attempts   bigint NOT NULL DEFAULT 0,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_status_created ON job (status, created_at);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS outbox_event (
+    id           uuid PRIMARY KEY,
+    type         varchar(64) NOT NULL,
+    aggregate_id uuid NOT NULL,
+    payload      jsonb NOT NULL,
+    occurred_at  timestamptz NOT NULL,
+    published_at timestamptz,
+    attempts     bigint NOT NULL DEFAULT 0,
+    last_error   text
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_event_pending ON outbox_event (occurred_at) WHERE published_at IS NULL;
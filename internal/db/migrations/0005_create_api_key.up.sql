@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS api_key (
+    id           uuid PRIMARY KEY,
+    name         varchar(100) NOT NULL,
+    prefix       varchar(16) NOT NULL,
+    secret_hash  varchar(64) NOT NULL,
+    scopes       varchar(255) NOT NULL,
+    quota_limit  bigint NOT NULL DEFAULT 0,
+    quota_period varchar(16) NOT NULL,
+    expires_at   timestamptz,
+    revoked_at   timestamptz,
+    created_at   timestamptz,
+    updated_at   timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_key_prefix ON api_key (prefix);
+
+CREATE TABLE IF NOT EXISTS api_key_usage (
+    api_key_id   uuid,
+    window_start timestamptz,
+    requests     bigint NOT NULL,
+    PRIMARY KEY (api_key_id, window_start)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS provider_response (
+    provider   varchar(32),
+    query_key  varchar(255),
+    payload    text,
+    not_found  boolean NOT NULL DEFAULT false,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz,
+    PRIMARY KEY (provider, query_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_provider_response_expires_at ON provider_response (expires_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_job_running_lease;
+ALTER TABLE job DROP COLUMN IF EXISTS lease_until;
+ALTER TABLE job DROP COLUMN IF EXISTS worker_id;
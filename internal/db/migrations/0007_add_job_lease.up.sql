@@ -0,0 +1,7 @@
+ALTER TABLE job ADD COLUMN IF NOT EXISTS worker_id varchar(64);
+ALTER TABLE job ADD COLUMN IF NOT EXISTS lease_until timestamptz;
+
+-- Jobs left running by a process without a lease are claimed again at once.
+UPDATE job SET lease_until = now() WHERE status = 'running' AND lease_until IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_job_running_lease ON job (lease_until) WHERE status = 'running';
@@ -0,0 +1,2 @@
+-- Pending jobs are only claimed once run_after has passed; NULL means at once.
+ALTER TABLE job ADD COLUMN IF NOT EXISTS run_after timestamptz;
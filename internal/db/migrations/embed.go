@@ -0,0 +1,10 @@
+package migrations
+
+import "embed"
+
+// FS holds the versioned migrations of the schema: every version has a
+// NNNN_name.up.sql file and the NNNN_name.down.sql file reverting it. Released
+// migrations are never edited, a schema change is a new version.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// APIKey is a credential issued to a partner integration. Only the SHA-256
+// hash of the secret is stored; Prefix identifies the key without revealing
+// it. Scopes is a comma separated list of roles. A QuotaLimit of zero means
+// the key is not metered.
+type APIKey struct {
+	ID          uuid.UUID `gorm:"primaryKey"`
+	Name        string    `gorm:"size:100;not null"`
+	Prefix      string    `gorm:"size:16;not null;uniqueIndex"`
+	SecretHash  string    `gorm:"size:64;not null" json:"-"`
+	Scopes      string    `gorm:"size:255;not null"`
+	QuotaLimit  int64     `gorm:"not null;default:0"`
+	QuotaPeriod string    `gorm:"size:16;not null"`
+	ExpiresAt   *time.Time
+	RevokedAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// APIKeyUsage counts the requests made with one key in one quota period.
+type APIKeyUsage struct {
+	APIKeyId    uuid.UUID `gorm:"primaryKey"`
+	WindowStart time.Time `gorm:"primaryKey"`
+	Requests    int64     `gorm:"not null"`
+}
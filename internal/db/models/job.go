@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Job is a queued unit of work. While it runs, WorkerId is the pool executing
+// it, which renews the lease until LeaseUntil; a job whose lease has expired
+// is claimed again by any pool.
+type Job struct {
+	ID         uuid.UUID `gorm:"primaryKey"`
+	Type       string    `gorm:"size:64;not null"`
+	Status     string    `gorm:"size:32;not null;index:idx_job_status_created"`
+	Payload    string    `gorm:"type:jsonb;not null"`
+	SongId     *uuid.UUID
+	Error      string     `gorm:"type:text"`
+	Attempts   int        `gorm:"not null;default:0"`
+	WorkerId   string     `gorm:"size:64" json:"-"`
+	LeaseUntil *time.Time `json:"-"`
+	RunAfter   *time.Time `json:"-"`
+	CreatedAt  time.Time  `gorm:"index:idx_job_status_created"`
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// OutboxEvent is a domain event stored in the same transaction as the change
+// it describes and published asynchronously by the outbox relay.
+type OutboxEvent struct {
+	ID          uuid.UUID `gorm:"primaryKey"`
+	Type        string    `gorm:"size:64;not null"`
+	AggregateId uuid.UUID `gorm:"not null"`
+	Payload     string    `gorm:"type:jsonb;not null"`
+	OccurredAt  time.Time `gorm:"not null;index:idx_outbox_event_pending,where:published_at IS NULL"`
+	PublishedAt *time.Time
+	Attempts    int    `gorm:"not null;default:0"`
+	LastError   string `gorm:"type:text"`
+}
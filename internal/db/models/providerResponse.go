@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// ProviderResponse is a cached answer of an external metadata provider to a
+// normalized "group|song" query, suffixed with "|track:<id>" when the lookup
+// was given the track id found by another provider. NotFound entries record
+// that the provider had no match, and have no payload.
+type ProviderResponse struct {
+	Provider  string    `gorm:"primaryKey;size:32"`
+	QueryKey  string    `gorm:"primaryKey;size:255"`
+	Payload   string    `gorm:"type:text"`
+	NotFound  bool      `gorm:"not null;default:false"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// SchemaMigration records a migration applied to the database.
+type SchemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"size:255;not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
@@ -12,11 +12,12 @@ type Author struct {
 }
 
 type Song struct {
-	ID          uuid.UUID `gorm:"primaryKey"`
-	Name        string    `gorm:"size:255;uniqueIndex:idx_song_author"`
+	ID          uuid.UUID `gorm:"primaryKey;index:idx_song_name_id,priority:2"`
+	Name        string    `gorm:"size:255;uniqueIndex:idx_song_author;index:idx_song_name_id,priority:1"`
 	AuthorId    uuid.UUID `gorm:"column:author_id;not null;uniqueIndex:idx_song_author"`
 	Author      Author    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	ReleaseDate time.Time
-	Text        string `gorm:"type:text"`
-	Link        string `gorm:"type:text"`
+	ReleaseDate time.Time `gorm:"index:idx_song_release_date"`
+	Text        string    `gorm:"type:text"`
+	Link        string    `gorm:"type:text"`
+	Language    string    `gorm:"type:regconfig;not null;default:'simple'"`
 }
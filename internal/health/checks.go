@@ -0,0 +1,37 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"gorm.io/gorm"
+)
+
+// Postgres pings the database.
+func Postgres(db *gorm.DB) Check {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// GRPCConn checks the state of a client connection. An idle connection is
+// asked to reconnect and counts as usable, since it connects on the next call.
+func GRPCConn(conn *grpc.ClientConn) Check {
+	return func(ctx context.Context) error {
+		switch state := conn.GetState(); state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.Idle:
+			conn.Connect()
+			return nil
+		default:
+			return fmt.Errorf("connection is %s", state)
+		}
+	}
+}
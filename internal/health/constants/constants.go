@@ -0,0 +1,15 @@
+package constants
+
+import "time"
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+const (
+	// CheckTimeout bounds every dependency check.
+	CheckTimeout = 2 * time.Second
+	// UpdateInterval is how often the gRPC health status is refreshed.
+	UpdateInterval = 10 * time.Second
+)
@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"SongsLibrary/internal/health"
+	"SongsLibrary/internal/health/constants"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"sync"
+	"time"
+)
+
+// MethodRoles makes the health service public, like the HTTP probes.
+var MethodRoles = map[string]string{
+	healthv1.Health_Check_FullMethodName: "",
+	healthv1.Health_Watch_FullMethodName: "",
+}
+
+// Server serves grpc.health.v1 with the readiness of the service, refreshed
+// every constants.UpdateInterval. The overall status, service "", and the
+// status of every name in services follow the readiness checks.
+type Server struct {
+	health   *health.Health
+	server   *grpchealth.Server
+	services []string
+	interval time.Duration
+	wg       sync.WaitGroup
+	cancel   context.CancelFunc
+}
+
+func Register(gRPC *grpc.Server, health *health.Health, services ...string) *Server {
+	s := &Server{
+		health:   health,
+		server:   grpchealth.NewServer(),
+		services: append([]string{""}, services...),
+		interval: constants.UpdateInterval,
+	}
+
+	s.setStatus(healthv1.HealthCheckResponse_NOT_SERVING)
+
+	healthv1.RegisterHealthServer(gRPC, s.server)
+
+	return s
+}
+
+func (s *Server) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+// Shutdown reports every service as not serving from now on, so that clients
+// stop routing calls to the server while it drains.
+func (s *Server) Shutdown() {
+	if s.cancel != nil {
+		s.cancel()
+		s.wg.Wait()
+	}
+
+	s.server.Shutdown()
+}
+
+func (s *Server) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.update(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Server) update(ctx context.Context) {
+	report := s.health.Check(ctx)
+	if ctx.Err() != nil {
+		return
+	}
+
+	status := healthv1.HealthCheckResponse_SERVING
+	if !report.Up() {
+		status = healthv1.HealthCheckResponse_NOT_SERVING
+
+		logrusCustom.Log(ctx, logrus.WarnLevel, fmt.Sprintf("gRPC health is NOT_SERVING: %+v", report.Checks))
+	}
+
+	s.setStatus(status)
+}
+
+func (s *Server) setStatus(status healthv1.HealthCheckResponse_ServingStatus) {
+	for _, service := range s.services {
+		s.server.SetServingStatus(service, status)
+	}
+}
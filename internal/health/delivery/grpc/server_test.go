@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"SongsLibrary/internal/health"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"sync/atomic"
+	"testing"
+)
+
+func status(t *testing.T, s *Server, service string) healthv1.HealthCheckResponse_ServingStatus {
+	response, err := s.server.Check(context.Background(), &healthv1.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	return response.Status
+}
+
+func TestServer_FollowsReadiness(t *testing.T) {
+
+	var down atomic.Bool
+
+	checks := health.New()
+	checks.Add("songdata", func(ctx context.Context) error {
+		if down.Load() {
+			return errors.New("connection is TRANSIENT_FAILURE")
+		}
+		return nil
+	})
+
+	s := Register(grpc.NewServer(), checks, "songsLibrary.Song")
+
+	assert.Equal(t, healthv1.HealthCheckResponse_NOT_SERVING, status(t, s, ""))
+
+	s.update(context.Background())
+	assert.Equal(t, healthv1.HealthCheckResponse_SERVING, status(t, s, ""))
+	assert.Equal(t, healthv1.HealthCheckResponse_SERVING, status(t, s, "songsLibrary.Song"))
+
+	down.Store(true)
+	s.update(context.Background())
+	assert.Equal(t, healthv1.HealthCheckResponse_NOT_SERVING, status(t, s, "songsLibrary.Song"))
+
+	down.Store(false)
+	s.Start(context.Background())
+	s.Shutdown()
+	assert.Equal(t, healthv1.HealthCheckResponse_NOT_SERVING, status(t, s, ""))
+}
@@ -0,0 +1,53 @@
+package http
+
+import (
+	"SongsLibrary/internal/health"
+	"SongsLibrary/internal/health/constants"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Handler struct {
+	health *health.Health
+}
+
+func NewHandler(health *health.Health) *Handler {
+	return &Handler{
+		health: health,
+	}
+}
+
+// Live
+// @Summary Liveness probe
+// @Description Report that the process is running. It does not check any dependency.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} health.Report "Service is alive"
+// @Router /healthz [get]
+func (h *Handler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, health.Report{Status: constants.StatusUp, Checks: map[string]health.Status{}})
+}
+
+// Ready
+// @Summary Readiness probe
+// @Description Check Postgres connectivity, the database schema and, when it is a metadata provider, the SongData connection, and report the status of each dependency.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} health.Report "Every dependency is up"
+// @Failure 503 {object} health.Report "At least one dependency is down"
+// @Router /readyz [get]
+func (h *Handler) Ready(c *gin.Context) {
+	report := h.health.Check(c.Request.Context())
+
+	if !report.Up() {
+		logrusCustom.Log(c.Request.Context(), logrus.WarnLevel, fmt.Sprintf("Readiness check failed: %+v", report.Checks))
+
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"SongsLibrary/internal/health"
+	"SongsLibrary/internal/health/constants"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func setup(songDataErr error) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	checks := health.New()
+	checks.Add("postgres", func(ctx context.Context) error { return nil })
+	checks.Add("songdata", func(ctx context.Context) error { return songDataErr })
+
+	r := gin.Default()
+	RegisterHTTPEndpoints(r, checks)
+
+	return r
+}
+
+func get(t *testing.T, r *gin.Engine, path string) (int, health.Report) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	r.ServeHTTP(w, req)
+
+	var report health.Report
+	err := json.NewDecoder(w.Body).Decode(&report)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	return w.Code, report
+}
+
+func TestLiveHandler_SkipsDependencies(t *testing.T) {
+	r := setup(errors.New("connection is TRANSIENT_FAILURE"))
+
+	code, report := get(t, r, "/healthz")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, constants.StatusUp, report.Status)
+}
+
+func TestReadyHandler_Up(t *testing.T) {
+	r := setup(nil)
+
+	code, report := get(t, r, "/readyz")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, constants.StatusUp, report.Status)
+	assert.Equal(t, constants.StatusUp, report.Checks["songdata"].Status)
+}
+
+func TestReadyHandler_DependencyDown(t *testing.T) {
+	r := setup(errors.New("connection is TRANSIENT_FAILURE"))
+
+	code, report := get(t, r, "/readyz")
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, constants.StatusDown, report.Status)
+	assert.Equal(t, constants.StatusUp, report.Checks["postgres"].Status)
+	assert.Equal(t, "connection is TRANSIENT_FAILURE", report.Checks["songdata"].Error)
+}
@@ -0,0 +1,14 @@
+package http
+
+import (
+	"SongsLibrary/internal/health"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterHTTPEndpoints adds the probes, which need no credentials.
+func RegisterHTTPEndpoints(router *gin.Engine, health *health.Health) {
+	h := NewHandler(health)
+
+	router.GET("/healthz", h.Live)
+	router.GET("/readyz", h.Ready)
+}
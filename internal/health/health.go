@@ -0,0 +1,96 @@
+package health
+
+import (
+	"SongsLibrary/internal/health/constants"
+	"context"
+	"sync"
+	"time"
+)
+
+// Check reports whether a dependency is usable; nil means it is.
+type Check func(ctx context.Context) error
+
+// Status is the outcome of one check.
+type Status struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// Report is the outcome of every check; Status is up only when all of them
+// are.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Status `json:"checks"`
+}
+
+func (r *Report) Up() bool {
+	return r.Status == constants.StatusUp
+}
+
+// Health holds the checks deciding whether the service is ready to serve.
+type Health struct {
+	names  []string
+	checks []Check
+}
+
+func New() *Health {
+	return &Health{}
+}
+
+func (h *Health) Add(name string, check Check) {
+	h.names = append(h.names, name)
+	h.checks = append(h.checks, check)
+}
+
+// Check runs all checks concurrently, each bounded by constants.CheckTimeout.
+func (h *Health) Check(ctx context.Context) *Report {
+	statuses := make([]Status, len(h.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i] = run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := &Report{Status: constants.StatusUp, Checks: make(map[string]Status, len(h.checks))}
+	for i, status := range statuses {
+		report.Checks[h.names[i]] = status
+		if status.Status != constants.StatusUp {
+			report.Status = constants.StatusDown
+		}
+	}
+
+	return report
+}
+
+func run(ctx context.Context, check Check) Status {
+	ctx, cancel := context.WithTimeout(ctx, constants.CheckTimeout)
+	defer cancel()
+
+	started := time.Now()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	status := Status{Status: constants.StatusUp, Duration: time.Since(started).String()}
+	if err != nil {
+		status.Status = constants.StatusDown
+		status.Error = err.Error()
+	}
+
+	return status
+}
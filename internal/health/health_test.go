@@ -0,0 +1,50 @@
+package health
+
+import (
+	"SongsLibrary/internal/health/constants"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHealth_CheckAllUp(t *testing.T) {
+	h := New()
+	h.Add("postgres", func(ctx context.Context) error { return nil })
+	h.Add("songdata", func(ctx context.Context) error { return nil })
+
+	report := h.Check(context.Background())
+
+	assert.True(t, report.Up())
+	assert.Len(t, report.Checks, 2)
+	assert.Equal(t, constants.StatusUp, report.Checks["songdata"].Status)
+}
+
+func TestHealth_CheckOneDown(t *testing.T) {
+	h := New()
+	h.Add("postgres", func(ctx context.Context) error { return nil })
+	h.Add("songdata", func(ctx context.Context) error { return errors.New("connection is TRANSIENT_FAILURE") })
+
+	report := h.Check(context.Background())
+
+	assert.False(t, report.Up())
+	assert.Equal(t, constants.StatusUp, report.Checks["postgres"].Status)
+	assert.Equal(t, constants.StatusDown, report.Checks["songdata"].Status)
+	assert.Equal(t, "connection is TRANSIENT_FAILURE", report.Checks["songdata"].Error)
+}
+
+func TestHealth_CheckCancelled(t *testing.T) {
+	h := New()
+	h.Add("postgres", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report := h.Check(ctx)
+
+	assert.False(t, report.Up())
+	assert.Equal(t, context.Canceled.Error(), report.Checks["postgres"].Error)
+}
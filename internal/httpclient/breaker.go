@@ -0,0 +1,130 @@
+package httpclient
+
+import (
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+const (
+	StateClosed   = "closed"
+	StateOpen     = "open"
+	StateHalfOpen = "half-open"
+)
+
+// BreakerState is a snapshot of a Breaker for the admin API.
+type BreakerState struct {
+	Name      string     `json:"name"`
+	State     string     `json:"state"`
+	Failures  int        `json:"failures"`
+	OpenedAt  *time.Time `json:"opened_at,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+// Breaker counts consecutive failed calls to a provider. Once open it rejects
+// calls until the cooldown has passed, then lets a single probe through: the
+// breaker closes when the probe succeeds and opens again when it fails.
+type Breaker struct {
+	mu        sync.Mutex
+	name      string
+	failures  int
+	threshold int
+	cooldown  time.Duration
+	state     string
+	openedAt  time.Time
+	probing   bool
+	lastError string
+	now       func() time.Time
+}
+
+func NewBreaker(name string, threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{name: name, threshold: threshold, cooldown: cooldown, state: StateClosed, now: time.Now}
+}
+
+// Allow reports whether a call may go to the provider. Every allowed call has
+// to be followed by Record or Release. Transitions are logged to the logger of ctx.
+func (b *Breaker) Allow(ctx context.Context) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.transition(ctx, StateHalfOpen)
+		b.probing = true
+		return true
+	case StateHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Record reports the outcome of an allowed call; err is nil on success.
+func (b *Breaker) Record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	if err == nil {
+		b.failures = 0
+		if b.state != StateClosed {
+			b.transition(ctx, StateClosed)
+		}
+		return
+	}
+
+	b.failures++
+	b.lastError = err.Error()
+
+	if b.state == StateHalfOpen || (b.threshold > 0 && b.failures >= b.threshold) {
+		b.openedAt = b.now()
+		if b.state != StateOpen {
+			b.transition(ctx, StateOpen)
+		}
+	}
+}
+
+// Release reports an allowed call abandoned before its outcome was known, such
+// as one cancelled by the caller. The state is left as it is, so a half-open
+// breaker lets the next call probe.
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state := BreakerState{Name: b.name, State: b.state, Failures: b.failures, LastError: b.lastError}
+	if b.state != StateClosed {
+		openedAt := b.openedAt
+		state.OpenedAt = &openedAt
+	}
+
+	return state
+}
+
+func (b *Breaker) transition(ctx context.Context, state string) {
+	level := logrus.WarnLevel
+	if state == StateClosed {
+		level = logrus.InfoLevel
+	}
+
+	logrusCustom.Log(ctx, level, fmt.Sprintf("Circuit breaker of %s %s -> %s after %d consecutive failures", b.name, b.state, state, b.failures))
+
+	b.state = state
+}
@@ -0,0 +1,42 @@
+package http
+
+import (
+	"SongsLibrary/internal/httpclient"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Handler struct {
+	breakers []*httpclient.Breaker
+}
+
+func NewHandler(breakers []*httpclient.Breaker) *Handler {
+	return &Handler{
+		breakers: breakers,
+	}
+}
+
+// GetProviders
+// @Summary Inspect provider circuit breakers
+// @Description Show the circuit breaker of every external metadata provider: its state (closed, open or half-open), the number of consecutive failures, when it opened and the last error.
+// @Tags Providers
+// @Produce json
+// @Success 200 {array} httpclient.BreakerState "Circuit breaker states"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/admin/providers [get]
+func (h *Handler) GetProviders(c *gin.Context) {
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, "Entered GetProviders Hanlder")
+
+	states := make([]httpclient.BreakerState, 0, len(h.breakers))
+	for _, breaker := range h.breakers {
+		states = append(states, breaker.State())
+	}
+
+	c.JSON(http.StatusOK, gin.H{"providers": states})
+}
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"SongsLibrary/internal/auth/authenticator"
+	authhttp "SongsLibrary/internal/auth/delivery/http"
+	"SongsLibrary/internal/httpclient"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetProvidersHandler_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	musixmatch := httpclient.NewBreaker("musixmatch", 1, time.Minute)
+	genius := httpclient.NewBreaker("genius", 1, time.Minute)
+
+	musixmatch.Allow(context.Background())
+	musixmatch.Record(context.Background(), errors.New("status 503"))
+
+	r := gin.Default()
+	RegisterHTTPEndpoints(r, []*httpclient.Breaker{musixmatch, genius}, authhttp.NewMiddleware(authenticator.Disabled{}, nil, nil))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/admin/providers", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Providers []httpclient.BreakerState `json:"providers"`
+	}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	assert.Len(t, response.Providers, 2)
+	assert.Equal(t, "musixmatch", response.Providers[0].Name)
+	assert.Equal(t, httpclient.StateOpen, response.Providers[0].State)
+	assert.Equal(t, "status 503", response.Providers[0].LastError)
+	assert.Equal(t, httpclient.StateClosed, response.Providers[1].State)
+}
@@ -0,0 +1,17 @@
+package http
+
+import (
+	"SongsLibrary/internal/auth/constants"
+	authhttp "SongsLibrary/internal/auth/delivery/http"
+	"SongsLibrary/internal/httpclient"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterHTTPEndpoints(router *gin.Engine, breakers []*httpclient.Breaker, authMiddleware *authhttp.Middleware) {
+	h := NewHandler(breakers)
+
+	adminEndPoints := router.Group("/api/admin", authMiddleware.Authenticate(), authMiddleware.RequireRole(constants.RoleAdmin))
+	{
+		adminEndPoints.GET("/providers", h.GetProviders)
+	}
+}
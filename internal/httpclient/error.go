@@ -0,0 +1,5 @@
+package httpclient
+
+import "errors"
+
+var CircuitOpen = errors.New("circuit breaker open, provider calls are failing fast")
@@ -0,0 +1,30 @@
+package httpclient
+
+import "time"
+
+// Policy configures the client of one provider. Timeout bounds a whole call,
+// retries included. Retries wait for an exponential backoff with full jitter
+// between BaseBackoff and MaxBackoff, or for the Retry-After of the response
+// when it is at most MaxRetryAfter. After BreakerFailures consecutive failed
+// calls the breaker opens and calls fail fast for BreakerCooldown.
+type Policy struct {
+	Timeout         time.Duration
+	MaxRetries      int
+	BaseBackoff     time.Duration
+	MaxBackoff      time.Duration
+	MaxRetryAfter   time.Duration
+	BreakerFailures int
+	BreakerCooldown time.Duration
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		Timeout:         5 * time.Second,
+		MaxRetries:      2,
+		BaseBackoff:     200 * time.Millisecond,
+		MaxBackoff:      2 * time.Second,
+		MaxRetryAfter:   3 * time.Second,
+		BreakerFailures: 5,
+		BreakerCooldown: 30 * time.Second,
+	}
+}
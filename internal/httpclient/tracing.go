@@ -0,0 +1,65 @@
+package httpclient
+
+import (
+	"errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"net/url"
+)
+
+var tracer = otel.Tracer("SongsLibrary/internal/httpclient")
+
+// TracingTransport opens a client span for every request it sends and passes
+// the trace context on in W3C headers. Only the path of the URL is recorded:
+// provider credentials travel in the query string.
+type TracingTransport struct {
+	name string
+	next http.RoundTripper
+}
+
+func NewTracingTransport(name string, next http.RoundTripper) *TracingTransport {
+	return &TracingTransport{name: name, next: next}
+}
+
+func (t *TracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := tracer.Start(req.Context(), t.name+" "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("provider", t.name),
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.ServerAddress(req.URL.Hostname()),
+			semconv.URLPath(req.URL.Path),
+		),
+	)
+	defer span.End()
+
+	// A RoundTripper must not modify the request it is given.
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		// The URL of a *url.Error would carry the credentials along.
+		cause := err
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			cause = urlErr.Err
+		}
+
+		span.RecordError(cause)
+		span.SetStatus(codes.Error, cause.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,45 @@
+package httpclient
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTracingTransport_PropagatesContextWithoutQuery(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTracingTransport("musixmatch", http.DefaultTransport)}
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/track.lyrics.get?apikey=secret", nil)
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Empty(t, req.Header.Get("traceparent"))
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "musixmatch GET", spans[0].Name())
+	assert.True(t, strings.Contains(traceparent, spans[0].SpanContext().TraceID().String()))
+
+	for _, attribute := range spans[0].Attributes() {
+		assert.NotContains(t, attribute.Value.Emit(), "secret")
+	}
+}
@@ -0,0 +1,184 @@
+package httpclient
+
+import (
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Transport retries failed requests of one provider and guards them with a
+// Breaker. Network errors, 429 and 5xx responses are retried; a call that
+// still fails after the retries counts as one breaker failure.
+type Transport struct {
+	name    string
+	policy  Policy
+	next    http.RoundTripper
+	breaker *Breaker
+	sleep   func(ctx context.Context, wait time.Duration) error
+}
+
+func NewTransport(name string, policy Policy, next http.RoundTripper) *Transport {
+	return &Transport{
+		name:    name,
+		policy:  policy,
+		next:    next,
+		breaker: NewBreaker(name, policy.BreakerFailures, policy.BreakerCooldown),
+		sleep:   sleep,
+	}
+}
+
+// NewClient returns an http.Client for the provider name with the timeout and
+// Transport of policy, and the breaker guarding it. Every attempt is traced.
+func NewClient(name string, policy Policy) (*http.Client, *Breaker) {
+	transport := NewTransport(name, policy, NewTracingTransport(name, http.DefaultTransport))
+
+	return &http.Client{Timeout: policy.Timeout, Transport: transport}, transport.breaker
+}
+
+func (t *Transport) Breaker() *Breaker {
+	return t.breaker
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.breaker.Allow(req.Context()) {
+		return nil, fmt.Errorf("%s: %w", t.name, CircuitOpen)
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+
+		wait, retryable := t.retryWait(resp, err, attempt)
+		if !retryable || attempt >= t.policy.MaxRetries || !rewind(req) {
+			t.record(req.Context(), resp, err)
+			return resp, err
+		}
+
+		logrusCustom.Log(req.Context(), logrus.WarnLevel, fmt.Sprintf("Retrying %s request in %s (attempt %d of %d): %s", t.name, wait, attempt+1, t.policy.MaxRetries, describe(resp, err)))
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := t.sleep(req.Context(), wait); err != nil {
+			t.record(req.Context(), nil, err)
+			return nil, err
+		}
+	}
+}
+
+// retryWait decides whether the outcome of attempt is worth retrying and how
+// long to wait before the next attempt.
+func (t *Transport) retryWait(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if err != nil {
+		return t.backoff(attempt), !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	if !retryableStatus(resp.StatusCode) {
+		return 0, false
+	}
+
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		return retryAfter, retryAfter <= t.policy.MaxRetryAfter
+	}
+
+	return t.backoff(attempt), true
+}
+
+func (t *Transport) backoff(attempt int) time.Duration {
+	ceiling := min(t.policy.BaseBackoff<<attempt, t.policy.MaxBackoff)
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return rand.N(ceiling) + 1
+}
+
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// record reports the outcome of a call to the breaker. Calls cancelled by the
+// caller say nothing about the provider and only release the breaker.
+func (t *Transport) record(ctx context.Context, resp *http.Response, err error) {
+	if errors.Is(err, context.Canceled) {
+		t.breaker.Release()
+		return
+	}
+
+	t.breaker.Record(ctx, failure(resp, err))
+}
+
+func failure(resp *http.Response, err error) error {
+	switch {
+	case err != nil:
+		return err
+	case retryableStatus(resp.StatusCode):
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	default:
+		return nil
+	}
+}
+
+func describe(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+
+	return resp.Status
+}
+
+// parseRetryAfter reads a Retry-After header in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// rewind prepares req to be sent again, which is only possible for requests
+// without a body or with GetBody.
+func rewind(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	req.Body = body
+
+	return true
+}
+
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,202 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient records the waits between retries instead of sleeping.
+func newTestClient(policy Policy) (*http.Client, *Transport, *[]time.Duration) {
+
+	var waits []time.Duration
+	transport := NewTransport("test", policy, http.DefaultTransport)
+	transport.sleep = func(ctx context.Context, wait time.Duration) error {
+		waits = append(waits, wait)
+		return nil
+	}
+
+	client := &http.Client{Transport: transport}
+
+	return client, transport, &waits
+}
+
+func get(t *testing.T, client *http.Client, url string) (*http.Response, error) {
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	resp, err := client.Do(req)
+	if resp != nil {
+		resp.Body.Close()
+	}
+	return resp, err
+}
+
+func TestTransport_RetriesThenSucceeds(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, transport, waits := newTestClient(DefaultPolicy())
+
+	resp, err := get(t, client, server.URL)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Len(t, *waits, 2)
+	for i, wait := range *waits {
+		assert.LessOrEqual(t, wait, DefaultPolicy().BaseBackoff<<i, "backoff is jittered below the exponential ceiling")
+	}
+	assert.Equal(t, StateClosed, transport.Breaker().State().State)
+}
+
+func TestTransport_HonoursRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _, waits := newTestClient(DefaultPolicy())
+
+	resp, err := get(t, client, server.URL)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []time.Duration{2 * time.Second}, *waits)
+}
+
+func TestTransport_RetryAfterTooLongNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, _, _ := newTestClient(DefaultPolicy())
+
+	resp, err := get(t, client, server.URL)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestTransport_ClientErrorsNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, transport, _ := newTestClient(DefaultPolicy())
+
+	resp, _ := get(t, client, server.URL)
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, 0, transport.Breaker().State().Failures)
+}
+
+func TestTransport_BreakerFailsFast(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	policy := DefaultPolicy()
+	policy.MaxRetries = 0
+	policy.BreakerFailures = 2
+
+	client, transport, _ := newTestClient(policy)
+
+	get(t, client, server.URL)
+	get(t, client, server.URL)
+
+	_, err := get(t, client, server.URL)
+
+	assert.True(t, errors.Is(err, CircuitOpen))
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, StateOpen, transport.Breaker().State().State)
+}
+
+func TestBreaker_HalfOpenProbe(t *testing.T) {
+
+	now := time.Now()
+	breaker := NewBreaker("test", 1, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	assert.True(t, breaker.Allow(context.Background()))
+	breaker.Record(context.Background(), errors.New("down"))
+	assert.False(t, breaker.Allow(context.Background()))
+
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.Allow(context.Background()), "one probe after the cooldown")
+	assert.False(t, breaker.Allow(context.Background()), "no second probe while the first is in flight")
+	assert.Equal(t, StateHalfOpen, breaker.State().State)
+
+	breaker.Record(context.Background(), errors.New("still down"))
+	assert.False(t, breaker.Allow(context.Background()))
+
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.Allow(context.Background()))
+	breaker.Record(context.Background(), nil)
+
+	assert.Equal(t, StateClosed, breaker.State().State)
+	assert.True(t, breaker.Allow(context.Background()))
+}
+
+func TestTransport_CancelledProbeKeepsBreakerOpen(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	policy := DefaultPolicy()
+	policy.BreakerFailures = 1
+
+	client, transport, _ := newTestClient(policy)
+
+	now := time.Now()
+	breaker := transport.Breaker()
+	breaker.now = func() time.Time { return now }
+	breaker.Allow(context.Background())
+	breaker.Record(context.Background(), errors.New("down"))
+
+	now = now.Add(policy.BreakerCooldown)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	_, err := client.Do(req)
+
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, StateHalfOpen, transport.Breaker().State().State, "a cancelled probe does not close the breaker")
+
+	resp, err := get(t, client, server.URL)
+
+	assert.NoError(t, err, "the next call probes")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, StateClosed, transport.Breaker().State().State)
+}
@@ -0,0 +1,34 @@
+package constants
+
+import "time"
+
+const (
+	JobTypeCreateSong = "create_song"
+
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+
+	DefaultJobWorkers = 4
+	MaxJobAttempts    = 3
+	JobPollInterval   = time.Second
+	JobTimeout        = 30 * time.Second
+
+	// A running job is claimed again once its lease has not been renewed for
+	// JobLeaseDuration, which spans a few renewals so that a slow renewal does
+	// not lose it.
+	JobLeaseDuration      = 30 * time.Second
+	JobLeaseRenewInterval = 10 * time.Second
+
+	// A failed job is retried after JobRetryBackoff, doubled on every further
+	// attempt up to JobMaxRetryBackoff, so that an outage of a provider does
+	// not use up its attempts.
+	JobRetryBackoff    = 30 * time.Second
+	JobMaxRetryBackoff = 10 * time.Minute
+)
+
+// RetryablePgErrorClasses are the classes of the Postgres errors worth another
+// attempt: connection exceptions, transaction rollbacks such as deadlocks and
+// serialization failures, insufficient resources and operator intervention.
+var RetryablePgErrorClasses = []string{"08", "40", "53", "57"}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"SongsLibrary/internal/job"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"time"
+)
+
+type Handler struct {
+	useCase job.UseCase
+}
+
+func NewHandler(useCase job.UseCase) *Handler {
+	return &Handler{
+		useCase: useCase,
+	}
+}
+
+// GetJob
+// @Summary Retrieve the status of a background job
+// @Description Fetch a job created by an asynchronous request such as POST /api/songs?async=true. Status is one of pending, running, succeeded or failed; SongId is set once the song has been created.
+// @Tags Jobs
+// @Produce json
+// @Param id path string true "UUID of the job" format(uuid)
+// @Success 200 {object} models.Job "Job details"
+// @Failure 400 {object} string "Invalid job ID format"
+// @Failure 404 {object} string "Job not found"
+// @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/jobs/{id} [get]
+func (h *Handler) GetJob(c *gin.Context) {
+	id := c.Param("id")
+
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered GetJob Hanlder with parameter: %s", id))
+
+	convertedId, err := uuid.Parse(id)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": job.InvalidJobIdFormat.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	jobToGet, err := h.useCase.GetJob(ctx, convertedId)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		if err.Error() == job.JobNotFound.Error() {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": job.JobNotFound.Error()})
+			return
+		}
+
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"job": jobToGet})
+}
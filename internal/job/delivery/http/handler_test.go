@@ -0,0 +1,65 @@
+package http
+
+import (
+	"SongsLibrary/internal/auth/authenticator"
+	authhttp "SongsLibrary/internal/auth/delivery/http"
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/job"
+	"SongsLibrary/internal/job/constants"
+	"SongsLibrary/internal/job/usecase"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func setup() (*gin.Engine, *usecase.MockJobUseCase) {
+	gin.SetMode(gin.TestMode)
+
+	mockUseCase := new(usecase.MockJobUseCase)
+
+	r := gin.Default()
+	RegisterHTTPEndpoints(r, mockUseCase, authhttp.NewMiddleware(authenticator.Disabled{}, nil, nil))
+
+	return r, mockUseCase
+}
+
+func TestGetJobHandler_Success(t *testing.T) {
+	r, mockUseCase := setup()
+
+	songId := uuid.New()
+	jobToGet := &models.Job{ID: uuid.New(), Type: constants.JobTypeCreateSong, Status: constants.JobStatusSucceeded, SongId: &songId}
+
+	mockUseCase.On("GetJob", mock.Anything, jobToGet.ID).Return(jobToGet, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/jobs/"+jobToGet.ID.String(), nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]models.Job
+	err := json.NewDecoder(w.Body).Decode(&response)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	assert.Equal(t, constants.JobStatusSucceeded, response["job"].Status)
+	assert.Equal(t, &songId, response["job"].SongId)
+}
+
+func TestGetJobHandler_NotFound(t *testing.T) {
+	r, mockUseCase := setup()
+
+	id := uuid.New()
+	mockUseCase.On("GetJob", mock.Anything, id).Return(nil, job.JobNotFound)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/jobs/"+id.String(), nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
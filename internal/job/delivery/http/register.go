@@ -0,0 +1,17 @@
+package http
+
+import (
+	"SongsLibrary/internal/auth/constants"
+	authhttp "SongsLibrary/internal/auth/delivery/http"
+	"SongsLibrary/internal/job"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterHTTPEndpoints(router *gin.Engine, uc job.UseCase, authMiddleware *authhttp.Middleware) {
+	h := NewHandler(uc)
+
+	authEndPoints := router.Group("/api", authMiddleware.Authenticate())
+	{
+		authEndPoints.GET("/jobs/:id", authMiddleware.RequireRole(constants.RoleReader), h.GetJob)
+	}
+}
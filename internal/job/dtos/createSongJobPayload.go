@@ -0,0 +1,6 @@
+package dtos
+
+type CreateSongJobPayload struct {
+	Group string `json:"group"`
+	Song  string `json:"song"`
+}
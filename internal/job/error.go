@@ -0,0 +1,11 @@
+package job
+
+import "errors"
+
+var (
+	JobNotFound        = errors.New("job not found")
+	NoPendingJobs      = errors.New("no pending jobs")
+	InvalidJobIdFormat = errors.New("invalid job id format")
+	UnknownJobType     = errors.New("unknown job type")
+	JobLeaseLost       = errors.New("job lease lost to another worker")
+)
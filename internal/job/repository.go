@@ -0,0 +1,17 @@
+package job
+
+import (
+	"SongsLibrary/internal/db/models"
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Repository interface {
+	CreateJob(context.Context, *models.Job) (*models.Job, error)
+	GetJob(ctx context.Context, id uuid.UUID) (*models.Job, error)
+	ClaimNextJob(ctx context.Context, workerId string, lease time.Duration) (*models.Job, error)
+	RenewLease(ctx context.Context, id uuid.UUID, workerId string, lease time.Duration) error
+	CompleteJob(ctx context.Context, id uuid.UUID, workerId string, songId uuid.UUID) error
+	FailJob(ctx context.Context, id uuid.UUID, workerId string, errMsg string, retry bool) error
+}
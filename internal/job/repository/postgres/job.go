@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/job"
+	"SongsLibrary/internal/job/constants"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type JobRepository struct {
+	db *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) *JobRepository {
+	return &JobRepository{db: db}
+}
+
+func (jr *JobRepository) CreateJob(ctx context.Context, jobToCreate *models.Job) (*models.Job, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered CreateJob Repository with parameter: %+v", jobToCreate))
+
+	if err := jr.db.WithContext(ctx).Create(jobToCreate).Error; err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting CreateJob Repository with created job: %s", jobToCreate.ID))
+
+	return jobToCreate, nil
+}
+
+func (jr *JobRepository) GetJob(ctx context.Context, id uuid.UUID) (*models.Job, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetJob Repository with parameter: id:%s", id.String()))
+
+	var jobToGet models.Job
+	if err := jr.db.WithContext(ctx).First(&jobToGet, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, job.JobNotFound.Error())
+
+			return nil, job.JobNotFound
+		}
+
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+		return nil, err
+	}
+
+	return &jobToGet, nil
+}
+
+// ClaimNextJob leases to workerId the oldest job that is pending and due or
+// whose lease has expired, the latter having been abandoned by a stopped or stuck
+// worker. Abandoned jobs without attempts left are failed instead. SKIP
+// LOCKED lets several workers, possibly in different replicas, claim jobs
+// concurrently without picking the same one. Leases are compared with the
+// database clock, so that replicas need not agree on the time.
+func (jr *JobRepository) ClaimNextJob(ctx context.Context, workerId string, lease time.Duration) (*models.Job, error) {
+
+	var claimedJob models.Job
+
+	err := jr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Job{}).
+			Where("status = ? AND lease_until < now() AND attempts >= ?", constants.JobStatusRunning, constants.MaxJobAttempts).
+			Updates(map[string]interface{}{
+				"status":      constants.JobStatusFailed,
+				"error":       job.JobLeaseLost.Error(),
+				"worker_id":   nil,
+				"lease_until": nil,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("(status = ? AND (run_after IS NULL OR run_after <= now())) OR (status = ? AND lease_until < now())", constants.JobStatusPending, constants.JobStatusRunning).
+			Order("created_at").
+			First(&claimedJob).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return job.NoPendingJobs
+			}
+
+			return err
+		}
+
+		if claimedJob.Status == constants.JobStatusRunning {
+			logrusCustom.Log(ctx, logrus.WarnLevel, fmt.Sprintf("Reclaiming job %s abandoned by worker %s", claimedJob.ID, claimedJob.WorkerId))
+		}
+
+		return tx.Model(&claimedJob).Updates(map[string]interface{}{
+			"status":      constants.JobStatusRunning,
+			"attempts":    gorm.Expr("attempts + 1"),
+			"worker_id":   workerId,
+			"lease_until": leaseUntil(lease),
+		}).Error
+	})
+	if err != nil {
+		if !errors.Is(err, job.NoPendingJobs) {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+		}
+
+		return nil, err
+	}
+
+	claimedJob.Status = constants.JobStatusRunning
+	claimedJob.Attempts++
+	claimedJob.WorkerId = workerId
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Claimed job %s, attempt %d", claimedJob.ID, claimedJob.Attempts))
+
+	return &claimedJob, nil
+}
+
+// RenewLease extends the lease of workerId on a running job. It returns
+// JobLeaseLost when the job is no longer leased to workerId.
+func (jr *JobRepository) RenewLease(ctx context.Context, id uuid.UUID, workerId string, lease time.Duration) error {
+	return jr.updateLeased(ctx, id, workerId, map[string]interface{}{
+		"lease_until": leaseUntil(lease),
+	})
+}
+
+func (jr *JobRepository) CompleteJob(ctx context.Context, id uuid.UUID, workerId string, songId uuid.UUID) error {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered CompleteJob Repository with parameters: id:%s, songId:%s", id.String(), songId.String()))
+
+	return jr.updateLeased(ctx, id, workerId, map[string]interface{}{
+		"status":      constants.JobStatusSucceeded,
+		"song_id":     songId,
+		"error":       "",
+		"worker_id":   nil,
+		"lease_until": nil,
+	})
+}
+
+// FailJob records the error of the last attempt. With retry the job goes back
+// to pending, to be claimed again once its backoff has passed.
+func (jr *JobRepository) FailJob(ctx context.Context, id uuid.UUID, workerId string, errMsg string, retry bool) error {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered FailJob Repository with parameters: id:%s, error:%s, retry:%t", id.String(), errMsg, retry))
+
+	updates := map[string]interface{}{
+		"status":      constants.JobStatusFailed,
+		"error":       errMsg,
+		"worker_id":   nil,
+		"lease_until": nil,
+	}
+	if retry {
+		updates["status"] = constants.JobStatusPending
+		updates["run_after"] = retryAfter()
+	}
+
+	return jr.updateLeased(ctx, id, workerId, updates)
+}
+
+// updateLeased updates a running job only while it is leased to workerId, so
+// that a worker that lost its lease cannot overwrite the outcome of the
+// worker that reclaimed the job.
+func (jr *JobRepository) updateLeased(ctx context.Context, id uuid.UUID, workerId string, updates map[string]interface{}) error {
+
+	result := jr.db.WithContext(ctx).Model(&models.Job{}).
+		Where("id = ? AND status = ? AND worker_id = ?", id, constants.JobStatusRunning, workerId).
+		Updates(updates)
+	if result.Error != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, result.Error.Error())
+
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, fmt.Sprintf("%s: %s", job.JobLeaseLost.Error(), id))
+
+		return job.JobLeaseLost
+	}
+
+	return nil
+}
+
+func leaseUntil(lease time.Duration) clause.Expr {
+	return gorm.Expr("now() + make_interval(secs => ?)", lease.Seconds())
+}
+
+// retryAfter doubles the backoff with every attempt the job has made, as
+// counted by the database.
+func retryAfter() clause.Expr {
+	return gorm.Expr("now() + make_interval(secs => least(? * power(2, attempts - 1), ?))", constants.JobRetryBackoff.Seconds(), constants.JobMaxRetryBackoff.Seconds())
+}
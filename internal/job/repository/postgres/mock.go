@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"SongsLibrary/internal/db/models"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) CreateJob(ctx context.Context, jobToCreate *models.Job) (*models.Job, error) {
+	args := m.Called(ctx, jobToCreate)
+	if createdJob, ok := args.Get(0).(*models.Job); ok {
+		return createdJob, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRepository) GetJob(ctx context.Context, id uuid.UUID) (*models.Job, error) {
+	args := m.Called(ctx, id)
+	if jobToGet, ok := args.Get(0).(*models.Job); ok {
+		return jobToGet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRepository) ClaimNextJob(ctx context.Context, workerId string, lease time.Duration) (*models.Job, error) {
+	args := m.Called(ctx, workerId, lease)
+	if claimedJob, ok := args.Get(0).(*models.Job); ok {
+		return claimedJob, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRepository) RenewLease(ctx context.Context, id uuid.UUID, workerId string, lease time.Duration) error {
+	args := m.Called(ctx, id, workerId, lease)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CompleteJob(ctx context.Context, id uuid.UUID, workerId string, songId uuid.UUID) error {
+	args := m.Called(ctx, id, workerId, songId)
+	return args.Error(0)
+}
+
+func (m *MockRepository) FailJob(ctx context.Context, id uuid.UUID, workerId string, errMsg string, retry bool) error {
+	args := m.Called(ctx, id, workerId, errMsg, retry)
+	return args.Error(0)
+}
@@ -0,0 +1,17 @@
+package job
+
+import (
+	"SongsLibrary/internal/db/models"
+	"context"
+	"github.com/google/uuid"
+)
+
+type UseCase interface {
+	EnqueueCreateSong(ctx context.Context, group, song string) (*models.Job, error)
+	GetJob(ctx context.Context, id uuid.UUID) (*models.Job, error)
+}
+
+// Notifier wakes the workers up when a job has been enqueued.
+type Notifier interface {
+	Notify()
+}
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/db/models"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJobUseCase struct {
+	mock.Mock
+}
+
+func (m *MockJobUseCase) EnqueueCreateSong(ctx context.Context, group, song string) (*models.Job, error) {
+	args := m.Called(ctx, group, song)
+	if createdJob, ok := args.Get(0).(*models.Job); ok {
+		return createdJob, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockJobUseCase) GetJob(ctx context.Context, id uuid.UUID) (*models.Job, error) {
+	args := m.Called(ctx, id)
+	if jobToGet, ok := args.Get(0).(*models.Job); ok {
+		return jobToGet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
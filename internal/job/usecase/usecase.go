@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/job"
+	"SongsLibrary/internal/job/constants"
+	"SongsLibrary/internal/job/dtos"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type JobUseCase struct {
+	jobRepo  job.Repository
+	notifier job.Notifier
+}
+
+func NewJobUseCase(jobRepo job.Repository, notifier job.Notifier) *JobUseCase {
+	return &JobUseCase{jobRepo: jobRepo, notifier: notifier}
+}
+
+func (juc *JobUseCase) EnqueueCreateSong(ctx context.Context, group, songName string) (*models.Job, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered EnqueueCreateSong UseCase with parameters: group:%s, song:%s", group, songName))
+
+	payload, err := json.Marshal(dtos.CreateSongJobPayload{Group: group, Song: songName})
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	createdJob, err := juc.jobRepo.CreateJob(ctx, &models.Job{
+		ID:      uuid.New(),
+		Type:    constants.JobTypeCreateSong,
+		Status:  constants.JobStatusPending,
+		Payload: string(payload),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if juc.notifier != nil {
+		juc.notifier.Notify()
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting EnqueueCreateSong UseCase with job: %s", createdJob.ID))
+
+	return createdJob, nil
+}
+
+func (juc *JobUseCase) GetJob(ctx context.Context, id uuid.UUID) (*models.Job, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetJob UseCase with parameter: %s", id.String()))
+
+	return juc.jobRepo.GetJob(ctx, id)
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/job/constants"
+	"SongsLibrary/internal/job/repository/postgres"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type countingNotifier struct {
+	calls int
+}
+
+func (n *countingNotifier) Notify() {
+	n.calls++
+}
+
+func TestEnqueueCreateSongUseCase_Success(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	notifier := &countingNotifier{}
+	juc := NewJobUseCase(mockRepo, notifier)
+
+	mockJob := &models.Job{ID: uuid.New(), Type: constants.JobTypeCreateSong, Status: constants.JobStatusPending}
+
+	mockRepo.On("CreateJob", mock.Anything, mock.MatchedBy(func(j *models.Job) bool {
+		return j.Type == constants.JobTypeCreateSong &&
+			j.Status == constants.JobStatusPending &&
+			j.Payload == `{"group":"muse","song":"hysteria"}`
+	})).Return(mockJob, nil)
+
+	createdJob, err := juc.EnqueueCreateSong(context.Background(), "muse", "hysteria")
+
+	assert.NoError(t, err)
+	assert.Equal(t, mockJob, createdJob)
+	assert.Equal(t, 1, notifier.calls)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestEnqueueCreateSongUseCase_RepositoryError(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	notifier := &countingNotifier{}
+	juc := NewJobUseCase(mockRepo, notifier)
+
+	mockRepo.On("CreateJob", mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	createdJob, err := juc.EnqueueCreateSong(context.Background(), "muse", "hysteria")
+
+	assert.Error(t, err)
+	assert.Nil(t, createdJob)
+	assert.Equal(t, 0, notifier.calls)
+}
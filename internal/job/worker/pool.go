@@ -0,0 +1,248 @@
+package worker
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/job"
+	"SongsLibrary/internal/job/constants"
+	"SongsLibrary/internal/job/dtos"
+	"SongsLibrary/internal/song"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
+	"net"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Pool runs background workers that claim pending jobs from the repository
+// and execute them with the song use case. Claimed jobs are leased to the
+// pool, which renews the lease until they finish; jobs of a pool that stopped
+// renewing are claimed again by any pool once their lease expires.
+type Pool struct {
+	jobRepo      job.Repository
+	songUC       song.UseCase
+	workers      int
+	id           string
+	lease        time.Duration
+	renewEvery   time.Duration
+	pollInterval time.Duration
+	wake         chan struct{}
+	wg           sync.WaitGroup
+	cancel       context.CancelFunc
+}
+
+func NewPool(jobRepo job.Repository, songUC song.UseCase, workers int) *Pool {
+	if workers <= 0 {
+		workers = constants.DefaultJobWorkers
+	}
+
+	return &Pool{
+		jobRepo:      jobRepo,
+		songUC:       songUC,
+		workers:      workers,
+		id:           workerId(),
+		lease:        constants.JobLeaseDuration,
+		renewEvery:   constants.JobLeaseRenewInterval,
+		pollInterval: constants.JobPollInterval,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+// Notify wakes an idle worker without waiting for the next poll.
+func (p *Pool) Notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start starts the workers.
+func (p *Pool) Start(ctx context.Context) {
+	ctx, p.cancel = context.WithCancel(ctx)
+
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+		go p.run(ctx)
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Started %d job workers as %s", p.workers, p.id))
+}
+
+// Stop stops claiming new jobs and waits for running ones to finish or for
+// ctx to expire. Jobs still running afterwards are claimed again once their
+// lease expires.
+func (p *Pool) Stop(ctx context.Context) error {
+	if p.cancel == nil {
+		return nil
+	}
+	p.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("job workers did not stop: %w", ctx.Err())
+	}
+}
+
+func (p *Pool) run(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for p.processNext(ctx) {
+			if ctx.Err() != nil {
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// processNext claims and executes one job. It reports whether a job was found,
+// so the worker keeps draining the queue before going back to sleep.
+func (p *Pool) processNext(ctx context.Context) bool {
+	claimedJob, err := p.jobRepo.ClaimNextJob(ctx, p.id, p.lease)
+	if err != nil {
+		return false
+	}
+
+	// The job gets its own context so that shutdown lets it finish instead of
+	// failing it halfway; it keeps the logger of ctx.
+	jobCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), constants.JobTimeout)
+	defer cancel()
+
+	// Losing the lease cancels the job, as another worker may run it already.
+	runCtx, stopRunning := context.WithCancel(jobCtx)
+	renewing := p.keepLease(runCtx, stopRunning, claimedJob.ID)
+
+	createdSong, err := p.execute(runCtx, claimedJob)
+
+	stopRunning()
+	<-renewing
+
+	if err != nil {
+		retry := claimedJob.Attempts < constants.MaxJobAttempts && isRetryable(err)
+
+		logrusCustom.Log(ctx, logrus.ErrorLevel, fmt.Sprintf("Job %s failed on attempt %d (retry: %t): %s", claimedJob.ID, claimedJob.Attempts, retry, err.Error()))
+
+		if err := p.jobRepo.FailJob(jobCtx, claimedJob.ID, p.id, err.Error(), retry); err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+		}
+
+		return true
+	}
+
+	if err := p.jobRepo.CompleteJob(jobCtx, claimedJob.ID, p.id, createdSong.ID); err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Job %s succeeded with song %s", claimedJob.ID, createdSong.ID))
+
+	return true
+}
+
+// keepLease renews the lease of the job every renewEvery until ctx is done,
+// and calls lost when the job is no longer leased to the pool. The returned
+// channel is closed once it has stopped.
+func (p *Pool) keepLease(ctx context.Context, lost context.CancelFunc, id uuid.UUID) <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(p.renewEvery)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			err := p.jobRepo.RenewLease(ctx, id, p.id, p.lease)
+			if errors.Is(err, job.JobLeaseLost) {
+				logrusCustom.Log(ctx, logrus.ErrorLevel, fmt.Sprintf("Lost the lease of job %s, cancelling it", id))
+
+				lost()
+				return
+			}
+			if err != nil && ctx.Err() == nil {
+				logrusCustom.Log(ctx, logrus.ErrorLevel, fmt.Sprintf("Failed to renew the lease of job %s: %s", id, err.Error()))
+			}
+		}
+	}()
+
+	return done
+}
+
+func (p *Pool) execute(ctx context.Context, claimedJob *models.Job) (*models.Song, error) {
+	switch claimedJob.Type {
+	case constants.JobTypeCreateSong:
+		var payload dtos.CreateSongJobPayload
+		if err := json.Unmarshal([]byte(claimedJob.Payload), &payload); err != nil {
+			return nil, err
+		}
+
+		return p.songUC.CreateSong(ctx, payload.Group, payload.Song)
+	default:
+		return nil, job.UnknownJobType
+	}
+}
+
+// isRetryable allows another attempt for failures that may go away: providers
+// that could not answer, timeouts, and connection or contention errors of the
+// database. Anything else, such as a song no provider knows, would fail the
+// same way again and only cost more provider calls.
+func isRetryable(err error) bool {
+	switch {
+	case errors.Is(err, song.ProviderUnavailable),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, context.Canceled),
+		errors.Is(err, driver.ErrBadConn),
+		pgconn.SafeToRetry(err),
+		pgconn.Timeout(err):
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return len(pgErr.Code) >= 2 && slices.Contains(constants.RetryablePgErrorClasses, pgErr.Code[:2])
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// workerId identifies the pool in the jobs it leases.
+func workerId() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "worker"
+	}
+
+	return fmt.Sprintf("%.27s-%s", hostname, uuid.NewString())
+}
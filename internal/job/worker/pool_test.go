@@ -0,0 +1,177 @@
+package worker
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/job"
+	"SongsLibrary/internal/job/constants"
+	"SongsLibrary/internal/job/repository/postgres"
+	"SongsLibrary/internal/song"
+	"SongsLibrary/internal/song/usecase"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestProcessNext_Success(t *testing.T) {
+
+	mockRepo := new(postgres.MockRepository)
+	mockSongUseCase := new(usecase.MockSongUseCase)
+	pool := NewPool(mockRepo, mockSongUseCase, 1)
+
+	claimedJob := &models.Job{
+		ID:       uuid.New(),
+		Type:     constants.JobTypeCreateSong,
+		Status:   constants.JobStatusRunning,
+		Payload:  `{"group":"testgroup","song":"testsong"}`,
+		Attempts: 1,
+	}
+	createdSong := &models.Song{ID: uuid.New(), Name: "testsong"}
+
+	mockRepo.On("ClaimNextJob", mock.Anything, pool.id, constants.JobLeaseDuration).Return(claimedJob, nil)
+	mockSongUseCase.On("CreateSong", mock.Anything, "testgroup", "testsong").Return(createdSong, nil)
+	mockRepo.On("CompleteJob", mock.Anything, claimedJob.ID, pool.id, createdSong.ID).Return(nil)
+
+	assert.True(t, pool.processNext(context.Background()))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProcessNext_RetriesTransientError(t *testing.T) {
+
+	mockRepo := new(postgres.MockRepository)
+	mockSongUseCase := new(usecase.MockSongUseCase)
+	pool := NewPool(mockRepo, mockSongUseCase, 1)
+
+	claimedJob := &models.Job{
+		ID:       uuid.New(),
+		Type:     constants.JobTypeCreateSong,
+		Payload:  `{"group":"testgroup","song":"testsong"}`,
+		Attempts: 1,
+	}
+
+	mockRepo.On("ClaimNextJob", mock.Anything, pool.id, constants.JobLeaseDuration).Return(claimedJob, nil)
+	mockSongUseCase.On("CreateSong", mock.Anything, "testgroup", "testsong").Return(nil, song.ProviderUnavailable)
+	mockRepo.On("FailJob", mock.Anything, claimedJob.ID, pool.id, song.ProviderUnavailable.Error(), true).Return(nil)
+
+	assert.True(t, pool.processNext(context.Background()))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProcessNext_NotFoundIsPermanent(t *testing.T) {
+
+	mockRepo := new(postgres.MockRepository)
+	mockSongUseCase := new(usecase.MockSongUseCase)
+	pool := NewPool(mockRepo, mockSongUseCase, 1)
+
+	claimedJob := &models.Job{
+		ID:       uuid.New(),
+		Type:     constants.JobTypeCreateSong,
+		Payload:  `{"group":"testgroup","song":"testsong"}`,
+		Attempts: 1,
+	}
+
+	mockRepo.On("ClaimNextJob", mock.Anything, pool.id, constants.JobLeaseDuration).Return(claimedJob, nil)
+	mockSongUseCase.On("CreateSong", mock.Anything, "testgroup", "testsong").Return(nil, song.MetadataNotFound)
+	mockRepo.On("FailJob", mock.Anything, claimedJob.ID, pool.id, song.MetadataNotFound.Error(), false).Return(nil)
+
+	assert.True(t, pool.processNext(context.Background()))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, isRetryable(fmt.Errorf("create song: %w", song.ProviderUnavailable)))
+	assert.True(t, isRetryable(context.DeadlineExceeded))
+	assert.True(t, isRetryable(&pgconn.PgError{Code: "40P01"}), "deadlocks are retried")
+
+	assert.False(t, isRetryable(song.ErrorGetSongData))
+	assert.False(t, isRetryable(song.ErrorGetSongLyrics))
+	assert.False(t, isRetryable(song.MetadataNotFound))
+	assert.False(t, isRetryable(&pgconn.PgError{Code: "23505"}), "constraint violations are not retried")
+	assert.False(t, isRetryable(job.UnknownJobType))
+}
+
+func TestProcessNext_DuplicateIsPermanent(t *testing.T) {
+
+	mockRepo := new(postgres.MockRepository)
+	mockSongUseCase := new(usecase.MockSongUseCase)
+	pool := NewPool(mockRepo, mockSongUseCase, 1)
+
+	claimedJob := &models.Job{
+		ID:       uuid.New(),
+		Type:     constants.JobTypeCreateSong,
+		Payload:  `{"group":"testgroup","song":"testsong"}`,
+		Attempts: 1,
+	}
+
+	mockRepo.On("ClaimNextJob", mock.Anything, pool.id, constants.JobLeaseDuration).Return(claimedJob, nil)
+	mockSongUseCase.On("CreateSong", mock.Anything, "testgroup", "testsong").Return(nil, song.AuthorSongDuplicate)
+	mockRepo.On("FailJob", mock.Anything, claimedJob.ID, pool.id, song.AuthorSongDuplicate.Error(), false).Return(nil)
+
+	assert.True(t, pool.processNext(context.Background()))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProcessNext_NoPendingJobs(t *testing.T) {
+
+	mockRepo := new(postgres.MockRepository)
+	pool := NewPool(mockRepo, new(usecase.MockSongUseCase), 1)
+
+	mockRepo.On("ClaimNextJob", mock.Anything, pool.id, constants.JobLeaseDuration).Return(nil, job.NoPendingJobs)
+
+	assert.False(t, pool.processNext(context.Background()))
+}
+
+func TestProcessNext_RenewsLease(t *testing.T) {
+
+	mockRepo := new(postgres.MockRepository)
+	mockSongUseCase := new(usecase.MockSongUseCase)
+	pool := NewPool(mockRepo, mockSongUseCase, 1)
+	pool.renewEvery = time.Millisecond
+
+	claimedJob := &models.Job{
+		ID:       uuid.New(),
+		Type:     constants.JobTypeCreateSong,
+		Payload:  `{"group":"testgroup","song":"testsong"}`,
+		Attempts: 1,
+	}
+	createdSong := &models.Song{ID: uuid.New(), Name: "testsong"}
+
+	mockRepo.On("ClaimNextJob", mock.Anything, pool.id, constants.JobLeaseDuration).Return(claimedJob, nil)
+	mockRepo.On("RenewLease", mock.Anything, claimedJob.ID, pool.id, constants.JobLeaseDuration).Return(nil)
+	mockSongUseCase.On("CreateSong", mock.Anything, "testgroup", "testsong").
+		WaitUntil(time.After(20*time.Millisecond)).
+		Return(createdSong, nil)
+	mockRepo.On("CompleteJob", mock.Anything, claimedJob.ID, pool.id, createdSong.ID).Return(nil)
+
+	assert.True(t, pool.processNext(context.Background()))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProcessNext_LeaseLostCancelsJob(t *testing.T) {
+
+	mockRepo := new(postgres.MockRepository)
+	mockSongUseCase := new(usecase.MockSongUseCase)
+	pool := NewPool(mockRepo, mockSongUseCase, 1)
+	pool.renewEvery = time.Millisecond
+
+	claimedJob := &models.Job{
+		ID:       uuid.New(),
+		Type:     constants.JobTypeCreateSong,
+		Payload:  `{"group":"testgroup","song":"testsong"}`,
+		Attempts: 1,
+	}
+
+	mockRepo.On("ClaimNextJob", mock.Anything, pool.id, constants.JobLeaseDuration).Return(claimedJob, nil)
+	mockRepo.On("RenewLease", mock.Anything, claimedJob.ID, pool.id, constants.JobLeaseDuration).Return(job.JobLeaseLost).Once()
+	mockSongUseCase.On("CreateSong", mock.Anything, "testgroup", "testsong").Return(nil, context.Canceled).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	})
+	mockRepo.On("FailJob", mock.Anything, claimedJob.ID, pool.id, context.Canceled.Error(), true).Return(job.JobLeaseLost)
+
+	assert.True(t, pool.processNext(context.Background()))
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,19 @@
+package constants
+
+import "time"
+
+const (
+	RequestIDHeader     = "X-Request-ID"
+	CorrelationIDHeader = "X-Correlation-ID"
+
+	// gRPC metadata keys are lower case.
+	RequestIDKey     = "x-request-id"
+	CorrelationIDKey = "x-correlation-id"
+
+	// MaxIDLength bounds the ids accepted from clients.
+	MaxIDLength = 128
+)
+
+// SlowQueryThreshold is the duration above which queries are logged as
+// warnings.
+const SlowQueryThreshold = 200 * time.Millisecond
@@ -0,0 +1,121 @@
+package grpc
+
+import (
+	"SongsLibrary/internal/logging"
+	"SongsLibrary/internal/logging/constants"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"slices"
+	"time"
+)
+
+type Interceptor struct {
+	log   *logrus.Logger
+	quiet []string
+}
+
+// NewInterceptor returns the interceptor logging to log. Successful calls of
+// the quiet methods, such as health checks, are only logged at debug level.
+func NewInterceptor(log *logrus.Logger, quiet ...string) *Interceptor {
+	return &Interceptor{log: log, quiet: quiet}
+}
+
+// Unary puts an entry with the request and correlation ids of the call
+// metadata into the call context, sends the ids back in the response header
+// and logs every call once it has been handled.
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		started := time.Now()
+
+		ctx = i.newContext(ctx)
+
+		resp, err := handler(ctx, req)
+
+		i.served(ctx, info.FullMethod, err, started)
+
+		return resp, err
+	}
+}
+
+// Stream does for streams what Unary does for calls.
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		started := time.Now()
+
+		ctx := i.newContext(stream.Context())
+
+		err := handler(srv, &loggedStream{ServerStream: stream, ctx: ctx})
+
+		i.served(ctx, info.FullMethod, err, started)
+
+		return err
+	}
+}
+
+// UnaryClient forwards the correlation id of ctx to the called service, so
+// that its logs can be joined with ours.
+func UnaryClient() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if correlationID := logging.CorrelationID(ctx); correlationID != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, constants.CorrelationIDKey, correlationID)
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func (i *Interceptor) newContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestID, correlationID := logging.RequestIDs(first(md, constants.RequestIDKey), first(md, constants.CorrelationIDKey))
+
+	// The header only fails to be set once it has been sent, which cannot
+	// have happened before the handler runs.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(constants.RequestIDKey, requestID, constants.CorrelationIDKey, correlationID))
+
+	return logging.NewContext(ctx, i.log, requestID, correlationID)
+}
+
+func (i *Interceptor) served(ctx context.Context, method string, err error, started time.Time) {
+	code := status.Code(err)
+
+	level := logrus.InfoLevel
+	switch code {
+	case codes.OK:
+		if slices.Contains(i.quiet, method) {
+			level = logrus.DebugLevel
+		}
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		level = logrus.ErrorLevel
+	default:
+		level = logrus.WarnLevel
+	}
+
+	logrusCustom.FromContext(ctx).WithFields(logrus.Fields{
+		"method":     method,
+		"code":       code.String(),
+		"latency_ms": time.Since(started).Milliseconds(),
+	}).Log(level, "Served call")
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+type loggedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,71 @@
+package grpc
+
+import (
+	"SongsLibrary/internal/logging"
+	"SongsLibrary/internal/logging/constants"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestUnary_AttachesIDsOfMetadata(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	unary := NewInterceptor(log).Unary()
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(constants.RequestIDKey, "req-1", constants.CorrelationIDKey, "order:42"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/songsLibrary.Song/CreateSong"}
+
+	_, err := unary(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		logrusCustom.Log(ctx, logrus.InfoLevel, "Entered CreateSong gRPC Hanlder")
+		assert.Equal(t, "order:42", logging.CorrelationID(ctx))
+
+		return nil, status.Error(codes.InvalidArgument, "invalid input data")
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	entries := hook.AllEntries()
+	assert.Len(t, entries, 2)
+	for _, entry := range entries {
+		assert.Equal(t, "req-1", entry.Data["request_id"])
+		assert.Equal(t, "order:42", entry.Data["correlation_id"])
+	}
+	assert.Equal(t, logrus.WarnLevel, entries[1].Level)
+	assert.Equal(t, "InvalidArgument", entries[1].Data["code"])
+}
+
+func TestUnary_GeneratesIDs(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	unary := NewInterceptor(log).Unary()
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/songsLibrary.Song/GetSongs"}
+
+	_, err := unary(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "songs", nil
+	})
+	assert.NoError(t, err)
+
+	requestID := hook.LastEntry().Data["request_id"]
+	assert.NotEmpty(t, requestID)
+	assert.Equal(t, requestID, hook.LastEntry().Data["correlation_id"])
+}
+
+func TestUnaryClient_ForwardsCorrelationID(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	ctx := logging.NewContext(context.Background(), log, "req-1", "order:42")
+
+	err := UnaryClient()(ctx, "/songData.SongData/GetSong", nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		assert.Equal(t, []string{"order:42"}, md.Get(constants.CorrelationIDKey))
+		assert.Empty(t, md.Get(constants.RequestIDKey))
+
+		return nil
+	})
+	assert.NoError(t, err)
+}
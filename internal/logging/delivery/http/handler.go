@@ -0,0 +1,78 @@
+package http
+
+import (
+	"SongsLibrary/internal/logging"
+	"SongsLibrary/internal/logging/dtos"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+type Handler struct {
+	log *logrus.Logger
+}
+
+func NewHandler(log *logrus.Logger) *Handler {
+	return &Handler{
+		log: log,
+	}
+}
+
+// GetLogLevel
+// @Summary Show the log level
+// @Description Show the level below which log entries are dropped.
+// @Tags Logging
+// @Produce json
+// @Success 200 {object} dtos.LevelDTO "Current log level"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/admin/log-level [get]
+func (h *Handler) GetLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, dtos.LevelDTO{Level: h.log.GetLevel().String()})
+}
+
+// SetLogLevel
+// @Summary Change the log level
+// @Description Change the log level of the running service until it restarts, e.g. to debug an incident without a redeploy.
+// @Tags Logging
+// @Accept json
+// @Produce json
+// @Param level body dtos.LevelDTO true "New level: panic, fatal, error, warn, info, debug or trace"
+// @Success 200 {object} dtos.LevelDTO "New log level"
+// @Failure 400 {object} string "Invalid log level"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/admin/log-level [put]
+func (h *Handler) SetLogLevel(c *gin.Context) {
+	var levelDTO dtos.LevelDTO
+
+	if err := c.ShouldBindJSON(&levelDTO); err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": logging.InvalidLevel.Error()})
+		return
+	}
+
+	level, err := logrus.ParseLevel(levelDTO.Level)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": logging.InvalidLevel.Error()})
+		return
+	}
+
+	previous := h.log.GetLevel()
+	h.log.SetLevel(level)
+
+	logrusCustom.Log(c.Request.Context(), logrus.WarnLevel, fmt.Sprintf("Changed log level from %s to %s", previous, level))
+
+	c.JSON(http.StatusOK, dtos.LevelDTO{Level: level.String()})
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"SongsLibrary/internal/auth/authenticator"
+	authhttp "SongsLibrary/internal/auth/delivery/http"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func setup() (*gin.Engine, *logrus.Logger) {
+	gin.SetMode(gin.TestMode)
+
+	log := logrus.New()
+	log.SetLevel(logrus.InfoLevel)
+
+	r := gin.Default()
+	RegisterHTTPEndpoints(r, log, authhttp.NewMiddleware(authenticator.Disabled{}, nil, nil))
+
+	return r, log
+}
+
+func TestGetLogLevelHandler_Success(t *testing.T) {
+	r, _ := setup()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/admin/log-level", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"level":"info"}`, w.Body.String())
+}
+
+func TestSetLogLevelHandler_Success(t *testing.T) {
+	r, log := setup()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/admin/log-level", strings.NewReader(`{"level":"DEBUG"}`))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"level":"debug"}`, w.Body.String())
+	assert.Equal(t, logrus.DebugLevel, log.GetLevel())
+}
+
+func TestSetLogLevelHandler_InvalidLevel(t *testing.T) {
+	r, log := setup()
+
+	for _, body := range []string{`{"level":"loud"}`, `{}`, `debug`} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/api/admin/log-level", strings.NewReader(body))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+	assert.Equal(t, logrus.InfoLevel, log.GetLevel())
+}
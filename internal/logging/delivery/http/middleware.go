@@ -0,0 +1,61 @@
+package http
+
+import (
+	"SongsLibrary/internal/logging"
+	"SongsLibrary/internal/logging/constants"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"slices"
+	"time"
+)
+
+type Middleware struct {
+	log   *logrus.Logger
+	quiet []string
+}
+
+// NewMiddleware returns the middleware logging to log. Successful requests to
+// the quiet routes, such as probes, are only logged at debug level.
+func NewMiddleware(log *logrus.Logger, quiet ...string) *Middleware {
+	return &Middleware{log: log, quiet: quiet}
+}
+
+// Log puts an entry with the request and correlation ids into the request
+// context, echoes the ids in the response headers and logs every request once
+// it has been served.
+func (m *Middleware) Log() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		started := time.Now()
+
+		requestID, correlationID := logging.RequestIDs(c.GetHeader(constants.RequestIDHeader), c.GetHeader(constants.CorrelationIDHeader))
+		c.Header(constants.RequestIDHeader, requestID)
+		c.Header(constants.CorrelationIDHeader, correlationID)
+
+		c.Request = c.Request.WithContext(logging.NewContext(c.Request.Context(), m.log, requestID, correlationID))
+
+		c.Next()
+
+		status := c.Writer.Status()
+
+		level := logrus.InfoLevel
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = logrus.ErrorLevel
+		case status >= http.StatusBadRequest:
+			level = logrus.WarnLevel
+		case slices.Contains(m.quiet, c.FullPath()):
+			level = logrus.DebugLevel
+		}
+
+		logrusCustom.FromContext(c.Request.Context()).WithFields(logrus.Fields{
+			"method":     c.Request.Method,
+			"path":       c.Request.URL.Path,
+			"route":      c.FullPath(),
+			"status":     status,
+			"latency_ms": time.Since(started).Milliseconds(),
+			"client_ip":  c.ClientIP(),
+		}).Log(level, "Served request")
+	}
+}
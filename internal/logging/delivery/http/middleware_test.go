@@ -0,0 +1,89 @@
+package http
+
+import (
+	"SongsLibrary/internal/logging/constants"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func setupMiddleware() (*gin.Engine, *test.Hook) {
+	gin.SetMode(gin.TestMode)
+
+	log, hook := test.NewNullLogger()
+	log.SetLevel(logrus.InfoLevel)
+
+	r := gin.New()
+	r.Use(NewMiddleware(log, "/healthz").Log())
+	r.GET("/api/songs", func(c *gin.Context) {
+		logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, "Entered GetSongs Hanlder")
+		c.Status(http.StatusOK)
+	})
+	r.GET("/healthz", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	return r, hook
+}
+
+func TestLog_GeneratesIDs(t *testing.T) {
+	r, hook := setupMiddleware()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/songs", nil))
+
+	requestID := w.Header().Get(constants.RequestIDHeader)
+	assert.NotEmpty(t, requestID)
+	assert.Equal(t, requestID, w.Header().Get(constants.CorrelationIDHeader))
+
+	entries := hook.AllEntries()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "Entered GetSongs Hanlder", entries[0].Message)
+	assert.Equal(t, requestID, entries[0].Data["request_id"])
+	assert.Equal(t, "Served request", entries[1].Message)
+	assert.Equal(t, requestID, entries[1].Data["correlation_id"])
+	assert.Equal(t, http.StatusOK, entries[1].Data["status"])
+}
+
+func TestLog_KeepsIncomingIDs(t *testing.T) {
+	r, hook := setupMiddleware()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/songs", nil)
+	req.Header.Set(constants.RequestIDHeader, "req-1")
+	req.Header.Set(constants.CorrelationIDHeader, "order:42")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, "req-1", w.Header().Get(constants.RequestIDHeader))
+	assert.Equal(t, "order:42", w.Header().Get(constants.CorrelationIDHeader))
+	assert.Equal(t, "req-1", hook.LastEntry().Data["request_id"])
+	assert.Equal(t, "order:42", hook.LastEntry().Data["correlation_id"])
+}
+
+func TestLog_ReplacesMalformedIDs(t *testing.T) {
+	r, hook := setupMiddleware()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/songs", nil)
+	req.Header.Set(constants.RequestIDHeader, "forged\nlevel=error")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	requestID := w.Header().Get(constants.RequestIDHeader)
+	assert.NotContains(t, requestID, "forged")
+	assert.Equal(t, requestID, hook.LastEntry().Data["request_id"])
+}
+
+func TestLog_QuietRoutesLogAtDebug(t *testing.T) {
+	r, hook := setupMiddleware()
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Empty(t, hook.AllEntries())
+}
@@ -0,0 +1,18 @@
+package http
+
+import (
+	"SongsLibrary/internal/auth/constants"
+	authhttp "SongsLibrary/internal/auth/delivery/http"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func RegisterHTTPEndpoints(router *gin.Engine, log *logrus.Logger, authMiddleware *authhttp.Middleware) {
+	h := NewHandler(log)
+
+	adminEndPoints := router.Group("/api/admin", authMiddleware.Authenticate(), authMiddleware.RequireRole(constants.RoleAdmin))
+	{
+		adminEndPoints.GET("/log-level", h.GetLogLevel)
+		adminEndPoints.PUT("/log-level", h.SetLogLevel)
+	}
+}
@@ -0,0 +1,5 @@
+package dtos
+
+type LevelDTO struct {
+	Level string `json:"level" binding:"required"`
+}
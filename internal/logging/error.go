@@ -0,0 +1,7 @@
+package logging
+
+import "errors"
+
+var (
+	InvalidLevel = errors.New("invalid log level, expected panic, fatal, error, warn, info, debug or trace")
+)
@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"SongsLibrary/internal/logging/constants"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// GormLogger writes the queries and messages of GORM to the entry carried by
+// the context of the query, or to log when there is none, so that they honour
+// the configured level, format, sink and redaction. Queries are logged at
+// debug level, slow ones as warnings and failed ones as errors.
+type GormLogger struct {
+	log   *logrus.Logger
+	level gormlogger.LogLevel
+}
+
+func NewGormLogger(log *logrus.Logger) *GormLogger {
+	return &GormLogger{log: log, level: gormlogger.Info}
+}
+
+// LogMode only tells Silent from the other levels, as the level of the logger
+// decides what is written.
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	return &GormLogger{log: l.log, level: level}
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	l.write(ctx, logrus.InfoLevel, fmt.Sprintf(msg, data...))
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	l.write(ctx, logrus.WarnLevel, fmt.Sprintf(msg, data...))
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	l.write(ctx, logrus.ErrorLevel, fmt.Sprintf(msg, data...))
+}
+
+// Trace logs a query once it has run. Missing records are not failures, the
+// repositories report them themselves.
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level == gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+
+	level := logrus.DebugLevel
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		level = logrus.ErrorLevel
+	case elapsed > constants.SlowQueryThreshold:
+		level = logrus.WarnLevel
+	}
+
+	ctx = l.context(ctx)
+	if !logrusCustom.FromContext(ctx).Logger.IsLevelEnabled(level) {
+		return
+	}
+
+	sql, rows := fc()
+
+	msg := fmt.Sprintf("Query took %s, %d rows: %s", elapsed, rows, sql)
+	if level == logrus.ErrorLevel {
+		msg = fmt.Sprintf("Query failed after %s: %s: %s", elapsed, err.Error(), sql)
+	}
+
+	logrusCustom.LogAt(ctx, level, queryLocation, msg)
+}
+
+func (l *GormLogger) write(ctx context.Context, level logrus.Level, msg string) {
+	if l.level == gormlogger.Silent {
+		return
+	}
+
+	logrusCustom.LogAt(l.context(ctx), level, queryLocation, msg)
+}
+
+func (l *GormLogger) context(ctx context.Context) context.Context {
+	if logrusCustom.HasEntry(ctx) {
+		return ctx
+	}
+
+	return logrusCustom.NewContext(ctx, logrus.NewEntry(l.log))
+}
+
+// queryLocation returns the file and line of the code that called GORM.
+func queryLocation() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "gorm.io/") &&
+			!strings.HasPrefix(frame.Function, "SongsLibrary/internal/logging.(*GormLogger).") &&
+			!strings.HasPrefix(frame.Function, "SongsLibrary/pkg/logger.") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
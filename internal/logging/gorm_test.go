@@ -0,0 +1,66 @@
+package logging
+
+import (
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"testing"
+	"time"
+)
+
+func query() (string, int64) {
+	return `SELECT * FROM "song"`, 2
+}
+
+func TestGormLogger_TraceUsesEntryOfContext(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	log.SetLevel(logrus.DebugLevel)
+
+	ctx := logrusCustom.NewContext(context.Background(), log.WithField("request_id", "42"))
+
+	NewGormLogger(logrus.New()).Trace(ctx, time.Now(), query, nil)
+
+	assert.Len(t, hook.AllEntries(), 1)
+	assert.Equal(t, logrus.DebugLevel, hook.LastEntry().Level)
+	assert.Contains(t, hook.LastEntry().Message, `SELECT * FROM "song"`)
+	assert.Equal(t, "42", hook.LastEntry().Data["request_id"])
+	assert.Contains(t, hook.LastEntry().Data["location"], "internal/logging/gorm_test.go")
+}
+
+func TestGormLogger_TraceFallsBackToLogger(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	log.SetLevel(logrus.InfoLevel)
+
+	l := NewGormLogger(log)
+
+	l.Trace(context.Background(), time.Now(), query, nil)
+	assert.Empty(t, hook.AllEntries(), "queries are only logged at debug level")
+
+	l.Trace(context.Background(), time.Now(), query, gorm.ErrRecordNotFound)
+	assert.Empty(t, hook.AllEntries(), "missing records are not failures")
+
+	l.Trace(context.Background(), time.Now(), query, errors.New("connection reset"))
+	assert.Len(t, hook.AllEntries(), 1)
+	assert.Equal(t, logrus.ErrorLevel, hook.LastEntry().Level)
+	assert.Contains(t, hook.LastEntry().Message, "connection reset")
+
+	l.Trace(context.Background(), time.Now().Add(-time.Second), query, nil)
+	assert.Len(t, hook.AllEntries(), 2)
+	assert.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+}
+
+func TestGormLogger_Silent(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	log.SetLevel(logrus.DebugLevel)
+
+	l := NewGormLogger(log).LogMode(gormlogger.Silent)
+
+	l.Trace(context.Background(), time.Now(), query, errors.New("connection reset"))
+	l.Error(context.Background(), "failed")
+	assert.Empty(t, hook.AllEntries())
+}
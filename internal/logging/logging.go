@@ -0,0 +1,66 @@
+package logging
+
+import (
+	"SongsLibrary/internal/logging/constants"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type correlationIDKey struct{}
+
+// RequestIDs returns the ids of a request from the ones sent by the client. A
+// missing or malformed request id is replaced by a new one, and the
+// correlation id defaults to the request id so that a chain of calls started
+// here can be followed from its first request.
+func RequestIDs(requestID, correlationID string) (string, string) {
+	if !valid(requestID) {
+		requestID = uuid.NewString()
+	}
+	if !valid(correlationID) {
+		correlationID = requestID
+	}
+
+	return requestID, correlationID
+}
+
+// NewContext returns a copy of ctx carrying correlationID and an entry of log with
+// them and, when ctx is part of a trace, its trace id.
+func NewContext(ctx context.Context, log *logrus.Logger, requestID, correlationID string) context.Context {
+	fields := logrus.Fields{"request_id": requestID, "correlation_id": correlationID}
+	if span := trace.SpanContextFromContext(ctx); span.HasTraceID() {
+		fields["trace_id"] = span.TraceID().String()
+	}
+
+	ctx = context.WithValue(ctx, correlationIDKey{}, correlationID)
+
+	return logrusCustom.NewContext(ctx, log.WithFields(fields))
+}
+
+// CorrelationID returns the correlation id carried by ctx, if any.
+func CorrelationID(ctx context.Context) string {
+	correlationID, _ := ctx.Value(correlationIDKey{}).(string)
+
+	return correlationID
+}
+
+// valid accepts the ids that are safe to log as they are: short and made of
+// letters, digits and the separators common in ids.
+func valid(id string) bool {
+	if id == "" || len(id) > constants.MaxIDLength {
+		return false
+	}
+
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,19 @@
+package constants
+
+// Outcomes of use case operations and provider calls. Errors are reduced to
+// these few values so that label cardinality stays bounded.
+const (
+	OutcomeSuccess     = "success"
+	OutcomeInvalid     = "invalid"
+	OutcomeNotFound    = "not_found"
+	OutcomeConflict    = "conflict"
+	OutcomeUnavailable = "unavailable"
+	OutcomeError       = "error"
+)
+
+const (
+	// RouteUnmatched labels HTTP requests that matched no route.
+	RouteUnmatched = "unmatched"
+	// MethodOther labels HTTP requests with a non standard method.
+	MethodOther = "OTHER"
+)
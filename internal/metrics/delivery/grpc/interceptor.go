@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"SongsLibrary/internal/metrics"
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+type Interceptor struct {
+	metrics *metrics.Metrics
+}
+
+func NewInterceptor(metrics *metrics.Metrics) *Interceptor {
+	return &Interceptor{metrics: metrics}
+}
+
+// Unary records the count and latency of every call, rejected ones included.
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		started := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		i.metrics.ObserveGRPC(info.FullMethod, status.Code(err), time.Since(started))
+
+		return resp, err
+	}
+}
+
+// Stream records streams like Unary records calls, over their whole lifetime.
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		started := time.Now()
+
+		err := handler(srv, stream)
+
+		i.metrics.ObserveGRPC(info.FullMethod, status.Code(err), time.Since(started))
+
+		return err
+	}
+}
@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"SongsLibrary/internal/metrics"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUnary_RecordsStatusCode(t *testing.T) {
+	m := metrics.New()
+	unary := NewInterceptor(m).Unary()
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/songsLibrary.Song/CreateSong"}
+
+	_, err := unary(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.Unavailable, "metadata provider unavailable, retry later")
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	_, err = unary(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "created", nil
+	})
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Contains(t, w.Body.String(), `grpc_requests_total{code="Unavailable",method="/songsLibrary.Song/CreateSong"} 1`)
+	assert.Contains(t, w.Body.String(), `grpc_requests_total{code="OK",method="/songsLibrary.Song/CreateSong"} 1`)
+}
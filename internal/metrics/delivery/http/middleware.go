@@ -0,0 +1,27 @@
+package http
+
+import (
+	"SongsLibrary/internal/metrics"
+	"github.com/gin-gonic/gin"
+	"time"
+)
+
+type Middleware struct {
+	metrics *metrics.Metrics
+}
+
+func NewMiddleware(metrics *metrics.Metrics) *Middleware {
+	return &Middleware{metrics: metrics}
+}
+
+// Observe records the count and latency of every request by its route
+// pattern rather than its path, so that ids do not become labels.
+func (m *Middleware) Observe() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		started := time.Now()
+
+		c.Next()
+
+		m.metrics.ObserveHTTP(c.Request.Method, c.FullPath(), c.Writer.Status(), time.Since(started))
+	}
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"SongsLibrary/internal/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestObserve_LabelsByRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m := metrics.New()
+
+	r := gin.New()
+	r.Use(NewMiddleware(m).Observe())
+	RegisterHTTPEndpoints(r, m)
+	r.GET("/api/songs/:id", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	for _, path := range []string{"/api/songs/1", "/api/songs/2", "/missing"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `http_requests_total{method="GET",route="/api/songs/:id",status="204"} 2`)
+	assert.Contains(t, w.Body.String(), `http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+}
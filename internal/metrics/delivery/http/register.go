@@ -0,0 +1,12 @@
+package http
+
+import (
+	"SongsLibrary/internal/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterHTTPEndpoints adds GET /metrics, which like the probes needs no
+// credentials.
+func RegisterHTTPEndpoints(router *gin.Engine, metrics *metrics.Metrics) {
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+const queryStartKey = "metrics:query_start"
+
+// GormPlugin times every query GORM runs through its callbacks.
+type GormPlugin struct {
+	metrics *Metrics
+}
+
+func NewGormPlugin(metrics *Metrics) *GormPlugin {
+	return &GormPlugin{metrics: metrics}
+}
+
+func (p *GormPlugin) Name() string {
+	return "metrics"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+
+	return errors.Join(
+		callback.Create().Before("gorm:create").Register("metrics:before_create", p.before),
+		callback.Create().After("gorm:create").Register("metrics:after_create", p.after("create")),
+		callback.Query().Before("gorm:query").Register("metrics:before_query", p.before),
+		callback.Query().After("gorm:query").Register("metrics:after_query", p.after("query")),
+		callback.Update().Before("gorm:update").Register("metrics:before_update", p.before),
+		callback.Update().After("gorm:update").Register("metrics:after_update", p.after("update")),
+		callback.Delete().Before("gorm:delete").Register("metrics:before_delete", p.before),
+		callback.Delete().After("gorm:delete").Register("metrics:after_delete", p.after("delete")),
+		callback.Row().Before("gorm:row").Register("metrics:before_row", p.before),
+		callback.Row().After("gorm:row").Register("metrics:after_row", p.after("row")),
+		callback.Raw().Before("gorm:raw").Register("metrics:before_raw", p.before),
+		callback.Raw().After("gorm:raw").Register("metrics:after_raw", p.after("raw")),
+	)
+}
+
+func (p *GormPlugin) before(db *gorm.DB) {
+	db.InstanceSet(queryStartKey, time.Now())
+}
+
+func (p *GormPlugin) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+
+		started, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		p.metrics.ObserveQuery(operation, db.Statement.Table, time.Since(started))
+	}
+}
@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"SongsLibrary/internal/metrics/constants"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Metrics holds the collectors of the service in its own registry. Every
+// label takes its values from a bounded set: registered routes and methods,
+// status codes, outcomes, operations, tables and provider names.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests  *prometheus.CounterVec
+	httpDuration  *prometheus.HistogramVec
+	grpcRequests  *prometheus.CounterVec
+	grpcDuration  *prometheus.HistogramVec
+	operations    *prometheus.CounterVec
+	operationTime *prometheus.HistogramVec
+	dbQueries     *prometheus.HistogramVec
+	providerCalls *prometheus.CounterVec
+	providerTime  *prometheus.HistogramVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency by method and route.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		grpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_requests_total",
+			Help: "gRPC calls by full method name and status code.",
+		}, []string{"method", "code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_request_duration_seconds",
+			Help:    "gRPC call latency by full method name.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method"}),
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "song_usecase_operations_total",
+			Help: "Song use case operations by operation and outcome.",
+		}, []string{"operation", "outcome"}),
+		operationTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "song_usecase_operation_duration_seconds",
+			Help:    "Song use case operation latency by operation.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"operation"}),
+		dbQueries: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Database query latency by GORM operation and table.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"operation", "table"}),
+		providerCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "provider_calls_total",
+			Help: "Metadata provider calls by provider and outcome.",
+		}, []string{"provider", "outcome"}),
+		providerTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "provider_call_duration_seconds",
+			Help:    "Metadata provider call latency by provider.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"provider"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration,
+		m.grpcRequests, m.grpcDuration,
+		m.operations, m.operationTime,
+		m.dbQueries,
+		m.providerCalls, m.providerTime,
+	)
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+var standardMethods = map[string]bool{
+	http.MethodGet: true, http.MethodHead: true, http.MethodPost: true, http.MethodPut: true,
+	http.MethodPatch: true, http.MethodDelete: true, http.MethodOptions: true,
+}
+
+// ObserveHTTP records a request. route is the registered route pattern, or
+// empty when none matched.
+func (m *Metrics) ObserveHTTP(method, route string, status int, duration time.Duration) {
+	if !standardMethods[method] {
+		method = constants.MethodOther
+	}
+	if route == "" {
+		route = constants.RouteUnmatched
+	}
+
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// ObserveGRPC records a call to a registered method.
+func (m *Metrics) ObserveGRPC(method string, code codes.Code, duration time.Duration) {
+	m.grpcRequests.WithLabelValues(method, code.String()).Inc()
+	m.grpcDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveOperation(operation, outcome string, duration time.Duration) {
+	m.operations.WithLabelValues(operation, outcome).Inc()
+	m.operationTime.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveQuery(operation, table string, duration time.Duration) {
+	m.dbQueries.WithLabelValues(operation, table).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveProviderCall(provider, outcome string, duration time.Duration) {
+	m.providerCalls.WithLabelValues(provider, outcome).Inc()
+	m.providerTime.WithLabelValues(provider).Observe(duration.Seconds())
+}
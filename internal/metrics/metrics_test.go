@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestObserveHTTP_BoundsLabels(t *testing.T) {
+	m := New()
+
+	m.ObserveHTTP(http.MethodGet, "/api/songs/:id/lyrics", http.StatusOK, time.Millisecond)
+	m.ObserveHTTP("PROPFIND", "", http.StatusNotFound, time.Millisecond)
+	m.ObserveHTTP("BREW", "", http.StatusNotFound, time.Millisecond)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "/api/songs/:id/lyrics", "200")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("OTHER", "unmatched", "404")))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.httpRequests))
+}
+
+func TestHandler_ExposesMetrics(t *testing.T) {
+	m := New()
+
+	m.ObserveGRPC("/songsLibrary.Song/GetSongs", codes.NotFound, time.Millisecond)
+	m.ObserveOperation("CreateSong", "unavailable", time.Second)
+	m.ObserveQuery("query", "song", time.Millisecond)
+	m.ObserveProviderCall("genius", "error", time.Second)
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := w.Body.String()
+	for _, line := range []string{
+		`grpc_requests_total{code="NotFound",method="/songsLibrary.Song/GetSongs"} 1`,
+		`song_usecase_operations_total{operation="CreateSong",outcome="unavailable"} 1`,
+		`db_query_duration_seconds_count{operation="query",table="song"} 1`,
+		`provider_calls_total{outcome="error",provider="genius"} 1`,
+		`go_goroutines`,
+	} {
+		assert.True(t, strings.Contains(body, line), "missing %s", line)
+	}
+}
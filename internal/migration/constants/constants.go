@@ -0,0 +1,12 @@
+package constants
+
+// LockKey identifies the Postgres advisory lock held while migrations run, so
+// that replicas booting together apply them one after the other.
+const LockKey int64 = 0x536f6e67734c6962
+
+const (
+	CommandUp     = "up"
+	CommandDown   = "down"
+	CommandStatus = "status"
+	CommandTo     = "to"
+)
@@ -0,0 +1,13 @@
+package dtos
+
+import "time"
+
+// StatusDTO reports one migration. Unknown migrations were applied by a newer
+// build and cannot be reverted by this one.
+type StatusDTO struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	Unknown   bool       `json:"unknown,omitempty"`
+}
@@ -0,0 +1,14 @@
+package migration
+
+import "errors"
+
+var (
+	InvalidFileName       = errors.New("invalid migration file name")
+	DuplicateMigration    = errors.New("duplicate migration")
+	IncompleteMigration   = errors.New("migration needs both an up and a down file")
+	UnknownVersion        = errors.New("unknown migration version")
+	UnknownAppliedVersion = errors.New("applied migration is unknown to this build")
+	NothingToRevert       = errors.New("no migration to revert")
+	SchemaBehind          = errors.New("database schema is behind")
+	UnknownCommand        = errors.New(`unknown migrate command, expected "up", "down", "status" or "to <version>"`)
+)
@@ -0,0 +1,85 @@
+package migration
+
+import (
+	"cmp"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// Migration is one version of the schema, with the SQL moving the database to
+// it and the SQL moving it back to the previous version.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Load reads the migrations of fsys, named NNNN_name.up.sql and
+// NNNN_name.down.sql, ordered by version. Every version needs both files.
+func Load(fsys fs.FS) ([]Migration, error) {
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("%w: %s", InvalidFileName, entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("%w: %s", InvalidFileName, entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("%w: version %d is both %s and %s", DuplicateMigration, version, migration.Name, match[2])
+		}
+
+		script := &migration.Up
+		if match[3] == "down" {
+			script = &migration.Down
+		}
+		if *script != "" {
+			return nil, fmt.Errorf("%w: %s", DuplicateMigration, entry.Name())
+		}
+		*script = string(content)
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("%w: %d_%s", IncompleteMigration, migration.Version, migration.Name)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+
+	return migrations, nil
+}
@@ -0,0 +1,65 @@
+package migration
+
+import (
+	"SongsLibrary/internal/db/migrations"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad_OrdersByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0010_add_index.up.sql":     {Data: []byte("CREATE INDEX")},
+		"0010_add_index.down.sql":   {Data: []byte("DROP INDEX")},
+		"0002_create_song.up.sql":   {Data: []byte("CREATE TABLE song")},
+		"0002_create_song.down.sql": {Data: []byte("DROP TABLE song")},
+	}
+
+	loaded, err := Load(fsys)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Migration{
+		{Version: 2, Name: "create_song", Up: "CREATE TABLE song", Down: "DROP TABLE song"},
+		{Version: 10, Name: "add_index", Up: "CREATE INDEX", Down: "DROP INDEX"},
+	}, loaded)
+}
+
+func TestLoad_InvalidName(t *testing.T) {
+	_, err := Load(fstest.MapFS{"create_song.up.sql": {Data: []byte("CREATE TABLE song")}})
+
+	assert.ErrorIs(t, err, InvalidFileName)
+
+	_, err = Load(fstest.MapFS{"0000_create_song.up.sql": {Data: []byte("CREATE TABLE song")}})
+
+	assert.ErrorIs(t, err, InvalidFileName)
+}
+
+func TestLoad_MissingDown(t *testing.T) {
+	_, err := Load(fstest.MapFS{"0001_create_song.up.sql": {Data: []byte("CREATE TABLE song")}})
+
+	assert.ErrorIs(t, err, IncompleteMigration)
+}
+
+func TestLoad_DuplicateVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0001_create_song.up.sql":     {Data: []byte("CREATE TABLE song")},
+		"0001_create_song.down.sql":   {Data: []byte("DROP TABLE song")},
+		"0001_create_author.up.sql":   {Data: []byte("CREATE TABLE author")},
+		"0001_create_author.down.sql": {Data: []byte("DROP TABLE author")},
+	}
+
+	_, err := Load(fsys)
+
+	assert.ErrorIs(t, err, DuplicateMigration)
+}
+
+// TestLoad_Embedded keeps the shipped migrations loadable and numbered without
+// gaps.
+func TestLoad_Embedded(t *testing.T) {
+	loaded, err := Load(migrations.FS)
+
+	assert.NoError(t, err)
+	for i, migration := range loaded {
+		assert.Equal(t, int64(i+1), migration.Version)
+	}
+}
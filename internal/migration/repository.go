@@ -0,0 +1,16 @@
+package migration
+
+import (
+	"SongsLibrary/internal/db/models"
+	"context"
+)
+
+type Repository interface {
+	// Lock blocks until the migration lock is held; unlock releases it.
+	Lock(ctx context.Context) (unlock func(context.Context) error, err error)
+	GetApplied(context.Context) ([]models.SchemaMigration, error)
+	// Apply runs the up script and records the migration in one transaction.
+	Apply(context.Context, Migration) error
+	// Revert runs the down script and forgets the migration in one transaction.
+	Revert(context.Context, Migration) error
+}
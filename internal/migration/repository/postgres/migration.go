@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/migration"
+	"SongsLibrary/internal/migration/constants"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"time"
+)
+
+type MigrationRepository struct {
+	db *gorm.DB
+}
+
+func NewMigrationRepository(db *gorm.DB) *MigrationRepository {
+	return &MigrationRepository{db: db}
+}
+
+// Lock takes a session-level advisory lock on a connection of its own, which
+// is held until unlock releases the lock and returns the connection to the
+// pool. Should the lock fail to be released, the connection is discarded
+// instead, which ends the session and the lock with it.
+func (mr *MigrationRepository) Lock(ctx context.Context) (func(context.Context) error, error) {
+
+	logrusCustom.Log(ctx, logrus.DebugLevel, "Waiting for the migration lock")
+
+	sqlDB, err := mr.db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", constants.LockKey); err != nil {
+		conn.Close()
+
+		return nil, fmt.Errorf("failed to take migration lock: %w", err)
+	}
+
+	logrusCustom.Log(ctx, logrus.DebugLevel, "Acquired the migration lock")
+
+	return func(ctx context.Context) error {
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", constants.LockKey); err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, fmt.Sprintf("Failed to release the migration lock: %s", err.Error()))
+
+			// A bad connection is closed rather than returned to the pool.
+			conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+
+			return err
+		}
+
+		return conn.Close()
+	}, nil
+}
+
+// GetApplied returns the applied migrations ordered by version, none when the
+// database has never been migrated.
+func (mr *MigrationRepository) GetApplied(ctx context.Context) ([]models.SchemaMigration, error) {
+
+	db := mr.db.WithContext(ctx)
+
+	if !db.Migrator().HasTable(&models.SchemaMigration{}) {
+		return nil, nil
+	}
+
+	var applied []models.SchemaMigration
+
+	if err := db.Order("version").Find(&applied).Error; err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	return applied, nil
+}
+
+func (mr *MigrationRepository) Apply(ctx context.Context, m migration.Migration) error {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Applying migration %d_%s", m.Version, m.Name))
+
+	err := mr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createTable(tx); err != nil {
+			return err
+		}
+
+		if err := tx.Exec(m.Up).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&models.SchemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+	})
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return fmt.Errorf("migration %d_%s: %w", m.Version, m.Name, err)
+	}
+
+	return nil
+}
+
+func (mr *MigrationRepository) Revert(ctx context.Context, m migration.Migration) error {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Reverting migration %d_%s", m.Version, m.Name))
+
+	err := mr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(m.Down).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.SchemaMigration{Version: m.Version}).Error
+	})
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return fmt.Errorf("migration %d_%s: %w", m.Version, m.Name, err)
+	}
+
+	return nil
+}
+
+// createTable creates the table recording the applied migrations, which is
+// the only one not created by a migration.
+func createTable(tx *gorm.DB) error {
+	return tx.Exec(`CREATE TABLE IF NOT EXISTS schema_migration (
+		version    bigint PRIMARY KEY,
+		name       varchar(255) NOT NULL,
+		applied_at timestamptz NOT NULL
+	)`).Error
+}
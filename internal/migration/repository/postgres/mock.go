@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/migration"
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Lock(ctx context.Context) (func(context.Context) error, error) {
+	args := m.Called(ctx)
+	if unlock, ok := args.Get(0).(func(context.Context) error); ok {
+		return unlock, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRepository) GetApplied(ctx context.Context) ([]models.SchemaMigration, error) {
+	args := m.Called(ctx)
+	if applied, ok := args.Get(0).([]models.SchemaMigration); ok {
+		return applied, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRepository) Apply(ctx context.Context, mg migration.Migration) error {
+	args := m.Called(ctx, mg)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Revert(ctx context.Context, mg migration.Migration) error {
+	args := m.Called(ctx, mg)
+	return args.Error(0)
+}
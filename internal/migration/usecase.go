@@ -0,0 +1,14 @@
+package migration
+
+import (
+	"SongsLibrary/internal/migration/dtos"
+	"context"
+)
+
+type UseCase interface {
+	Up(context.Context) error
+	Down(context.Context) error
+	To(ctx context.Context, version int64) error
+	Status(context.Context) ([]dtos.StatusDTO, error)
+	Check(context.Context) error
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/migration/dtos"
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMigrationUseCase struct {
+	mock.Mock
+}
+
+func (m *MockMigrationUseCase) Up(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockMigrationUseCase) Down(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockMigrationUseCase) To(ctx context.Context, version int64) error {
+	args := m.Called(ctx, version)
+	return args.Error(0)
+}
+
+func (m *MockMigrationUseCase) Status(ctx context.Context) ([]dtos.StatusDTO, error) {
+	args := m.Called(ctx)
+	if statuses, ok := args.Get(0).([]dtos.StatusDTO); ok {
+		return statuses, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockMigrationUseCase) Check(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/migration"
+	"SongsLibrary/internal/migration/dtos"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"maps"
+	"slices"
+	"time"
+)
+
+// MigrationUseCase moves the schema between the versions of its migrations.
+// Runners are serialized by the repository lock, and every runner reads the
+// applied migrations once it holds the lock, so replicas booting together
+// apply each migration once.
+type MigrationUseCase struct {
+	migrationRepo migration.Repository
+	migrations    []migration.Migration
+}
+
+// NewMigrationUseCase expects migrations ordered by version, as returned by
+// migration.Load.
+func NewMigrationUseCase(migrationRepo migration.Repository, migrations []migration.Migration) *MigrationUseCase {
+	return &MigrationUseCase{migrationRepo: migrationRepo, migrations: migrations}
+}
+
+// Up applies every pending migration. Migrations applied by a newer build are
+// left in place.
+func (muc *MigrationUseCase) Up(ctx context.Context) error {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, "Entered Up UseCase")
+
+	return muc.locked(ctx, func(applied map[int64]models.SchemaMigration) error {
+		return muc.apply(ctx, applied, muc.latest())
+	})
+}
+
+// Down reverts the latest applied migration.
+func (muc *MigrationUseCase) Down(ctx context.Context) error {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, "Entered Down UseCase")
+
+	return muc.locked(ctx, func(applied map[int64]models.SchemaMigration) error {
+		if len(applied) == 0 {
+			return migration.NothingToRevert
+		}
+
+		return muc.revert(ctx, applied, latestApplied(applied)-1)
+	})
+}
+
+// To applies or reverts migrations until version is the latest applied one.
+// Version 0 reverts every migration.
+func (muc *MigrationUseCase) To(ctx context.Context, version int64) error {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered To UseCase with parameter: version:%d", version))
+
+	if version != 0 && muc.find(version) == nil {
+		return fmt.Errorf("%w: %d", migration.UnknownVersion, version)
+	}
+
+	return muc.locked(ctx, func(applied map[int64]models.SchemaMigration) error {
+		if err := muc.revert(ctx, applied, version); err != nil {
+			return err
+		}
+
+		return muc.apply(ctx, applied, version)
+	})
+}
+
+// Status lists the migrations of this build and those applied by a newer one,
+// ordered by version.
+func (muc *MigrationUseCase) Status(ctx context.Context) ([]dtos.StatusDTO, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, "Entered Status UseCase")
+
+	applied, err := muc.getApplied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]dtos.StatusDTO, 0, len(muc.migrations))
+	for _, m := range muc.migrations {
+		status := dtos.StatusDTO{Version: m.Version, Name: m.Name}
+		if record, ok := applied[m.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &record.AppliedAt
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	for version, record := range applied {
+		if muc.find(version) == nil {
+			statuses = append(statuses, dtos.StatusDTO{Version: version, Name: record.Name, Applied: true, AppliedAt: &record.AppliedAt, Unknown: true})
+		}
+	}
+
+	slices.SortFunc(statuses, func(a, b dtos.StatusDTO) int { return cmp.Compare(a.Version, b.Version) })
+
+	return statuses, nil
+}
+
+// Check fails with SchemaBehind while any migration of this build is pending.
+// A schema ahead of the build passes, so that replicas of the previous release
+// keep serving during a rollout.
+func (muc *MigrationUseCase) Check(ctx context.Context) error {
+
+	applied, err := muc.getApplied(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pending []string
+	for _, m := range muc.migrations {
+		if _, ok := applied[m.Version]; !ok {
+			pending = append(pending, fmt.Sprintf("%d_%s", m.Version, m.Name))
+		}
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %d pending migrations: %v", migration.SchemaBehind, len(pending), pending)
+	}
+
+	return nil
+}
+
+// locked runs migrate with the migrations applied once the lock is held.
+func (muc *MigrationUseCase) locked(ctx context.Context, migrate func(map[int64]models.SchemaMigration) error) (err error) {
+
+	started := time.Now()
+
+	unlock, err := muc.migrationRepo.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, unlock(context.WithoutCancel(ctx)))
+	}()
+
+	applied, err := muc.getApplied(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := migrate(applied); err != nil {
+		return err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Schema is at version %d after %s", latestApplied(applied), time.Since(started)))
+
+	return nil
+}
+
+// apply applies the pending migrations up to version in order, recording them
+// in applied.
+func (muc *MigrationUseCase) apply(ctx context.Context, applied map[int64]models.SchemaMigration, version int64) error {
+	for _, m := range muc.migrations {
+		if m.Version > version {
+			break
+		}
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+
+		if err := muc.migrationRepo.Apply(ctx, m); err != nil {
+			return err
+		}
+
+		applied[m.Version] = models.SchemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}
+	}
+
+	return nil
+}
+
+// revert reverts the applied migrations above version, latest first. Nothing
+// is reverted when one of them is unknown to this build, as its down script
+// is missing.
+func (muc *MigrationUseCase) revert(ctx context.Context, applied map[int64]models.SchemaMigration, version int64) error {
+
+	versions := slices.Sorted(maps.Keys(applied))
+	slices.Reverse(versions)
+
+	var reverted []migration.Migration
+	for _, appliedVersion := range versions {
+		if appliedVersion <= version {
+			break
+		}
+
+		m := muc.find(appliedVersion)
+		if m == nil {
+			return fmt.Errorf("%w: %d_%s", migration.UnknownAppliedVersion, appliedVersion, applied[appliedVersion].Name)
+		}
+
+		reverted = append(reverted, *m)
+	}
+
+	for _, m := range reverted {
+		if err := muc.migrationRepo.Revert(ctx, m); err != nil {
+			return err
+		}
+
+		delete(applied, m.Version)
+	}
+
+	return nil
+}
+
+func (muc *MigrationUseCase) getApplied(ctx context.Context) (map[int64]models.SchemaMigration, error) {
+
+	records, err := muc.migrationRepo.GetApplied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]models.SchemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+
+	return applied, nil
+}
+
+func (muc *MigrationUseCase) find(version int64) *migration.Migration {
+	for i := range muc.migrations {
+		if muc.migrations[i].Version == version {
+			return &muc.migrations[i]
+		}
+	}
+
+	return nil
+}
+
+func (muc *MigrationUseCase) latest() int64 {
+	if len(muc.migrations) == 0 {
+		return 0
+	}
+
+	return muc.migrations[len(muc.migrations)-1].Version
+}
+
+func latestApplied(applied map[int64]models.SchemaMigration) int64 {
+	if len(applied) == 0 {
+		return 0
+	}
+
+	return slices.Max(slices.Collect(maps.Keys(applied)))
+}
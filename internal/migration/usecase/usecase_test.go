@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/migration"
+	"SongsLibrary/internal/migration/repository/postgres"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+var migrations = []migration.Migration{
+	{Version: 1, Name: "create_author", Up: "CREATE TABLE author", Down: "DROP TABLE author"},
+	{Version: 2, Name: "create_song", Up: "CREATE TABLE song", Down: "DROP TABLE song"},
+	{Version: 3, Name: "add_language", Up: "ALTER TABLE song ADD language", Down: "ALTER TABLE song DROP language"},
+}
+
+func applied(versions ...int64) []models.SchemaMigration {
+	records := make([]models.SchemaMigration, 0, len(versions))
+	for _, version := range versions {
+		records = append(records, models.SchemaMigration{Version: version, Name: "applied", AppliedAt: time.Now()})
+	}
+	return records
+}
+
+// lock expects the lock to be taken and released once.
+func lock(mockRepo *postgres.MockRepository) *bool {
+	released := false
+	mockRepo.On("Lock", mock.Anything).Return(func(context.Context) error {
+		released = true
+		return nil
+	}, nil).Once()
+	return &released
+}
+
+func TestUpUseCase_AppliesPendingInOrder(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	muc := NewMigrationUseCase(mockRepo, migrations)
+
+	released := lock(mockRepo)
+	mockRepo.On("GetApplied", mock.Anything).Return(applied(1), nil)
+	mockRepo.On("Apply", mock.Anything, migrations[1]).Return(nil).Once()
+	mockRepo.On("Apply", mock.Anything, migrations[2]).Return(nil).Once()
+
+	err := muc.Up(context.Background())
+
+	assert.NoError(t, err)
+	assert.True(t, *released)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Apply", mock.Anything, migrations[0])
+}
+
+func TestUpUseCase_StopsAtFailureAndUnlocks(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	muc := NewMigrationUseCase(mockRepo, migrations)
+
+	released := lock(mockRepo)
+	mockRepo.On("GetApplied", mock.Anything).Return(applied(), nil)
+	mockRepo.On("Apply", mock.Anything, migrations[0]).Return(nil).Once()
+	mockRepo.On("Apply", mock.Anything, migrations[1]).Return(errors.New("syntax error")).Once()
+
+	err := muc.Up(context.Background())
+
+	assert.EqualError(t, err, "syntax error")
+	assert.True(t, *released)
+	mockRepo.AssertNotCalled(t, "Apply", mock.Anything, migrations[2])
+}
+
+func TestUpUseCase_LockError(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	muc := NewMigrationUseCase(mockRepo, migrations)
+
+	mockRepo.On("Lock", mock.Anything).Return(nil, errors.New("db down"))
+
+	err := muc.Up(context.Background())
+
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "GetApplied", mock.Anything)
+}
+
+func TestDownUseCase_RevertsLatest(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	muc := NewMigrationUseCase(mockRepo, migrations)
+
+	lock(mockRepo)
+	mockRepo.On("GetApplied", mock.Anything).Return(applied(1, 2), nil)
+	mockRepo.On("Revert", mock.Anything, migrations[1]).Return(nil).Once()
+
+	err := muc.Down(context.Background())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDownUseCase_NothingApplied(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	muc := NewMigrationUseCase(mockRepo, migrations)
+
+	lock(mockRepo)
+	mockRepo.On("GetApplied", mock.Anything).Return(applied(), nil)
+
+	err := muc.Down(context.Background())
+
+	assert.ErrorIs(t, err, migration.NothingToRevert)
+}
+
+func TestDownUseCase_UnknownApplied(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	muc := NewMigrationUseCase(mockRepo, migrations)
+
+	lock(mockRepo)
+	mockRepo.On("GetApplied", mock.Anything).Return(applied(1, 2, 3, 4), nil)
+
+	err := muc.Down(context.Background())
+
+	assert.ErrorIs(t, err, migration.UnknownAppliedVersion)
+	mockRepo.AssertNotCalled(t, "Revert", mock.Anything, mock.Anything)
+}
+
+func TestToUseCase_RevertsNewestFirst(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	muc := NewMigrationUseCase(mockRepo, migrations)
+
+	var order []int64
+	lock(mockRepo)
+	mockRepo.On("GetApplied", mock.Anything).Return(applied(1, 2, 3), nil)
+	mockRepo.On("Revert", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		order = append(order, args.Get(1).(migration.Migration).Version)
+	}).Return(nil)
+
+	err := muc.To(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3, 2}, order)
+}
+
+func TestToUseCase_Applies(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	muc := NewMigrationUseCase(mockRepo, migrations)
+
+	lock(mockRepo)
+	mockRepo.On("GetApplied", mock.Anything).Return(applied(), nil)
+	mockRepo.On("Apply", mock.Anything, migrations[0]).Return(nil).Once()
+	mockRepo.On("Apply", mock.Anything, migrations[1]).Return(nil).Once()
+
+	err := muc.To(context.Background(), 2)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestToUseCase_UnknownVersion(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	muc := NewMigrationUseCase(mockRepo, migrations)
+
+	err := muc.To(context.Background(), 7)
+
+	assert.ErrorIs(t, err, migration.UnknownVersion)
+	mockRepo.AssertNotCalled(t, "Lock", mock.Anything)
+}
+
+func TestStatusUseCase_Success(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	muc := NewMigrationUseCase(mockRepo, migrations)
+
+	mockRepo.On("GetApplied", mock.Anything).Return(applied(1, 4), nil)
+
+	statuses, err := muc.Status(context.Background())
+
+	assert.NoError(t, err)
+	if assert.Len(t, statuses, 4) {
+		assert.True(t, statuses[0].Applied)
+		assert.False(t, statuses[1].Applied)
+		assert.Nil(t, statuses[1].AppliedAt)
+		assert.Equal(t, int64(4), statuses[3].Version)
+		assert.True(t, statuses[3].Unknown)
+	}
+}
+
+func TestCheckUseCase_SchemaBehind(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	muc := NewMigrationUseCase(mockRepo, migrations)
+
+	mockRepo.On("GetApplied", mock.Anything).Return(applied(1, 2), nil)
+
+	err := muc.Check(context.Background())
+
+	assert.ErrorIs(t, err, migration.SchemaBehind)
+	assert.Contains(t, err.Error(), "3_add_language")
+}
+
+func TestCheckUseCase_SchemaAhead(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	muc := NewMigrationUseCase(mockRepo, migrations)
+
+	mockRepo.On("GetApplied", mock.Anything).Return(applied(1, 2, 3, 4), nil)
+
+	assert.NoError(t, muc.Check(context.Background()))
+}
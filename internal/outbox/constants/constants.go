@@ -0,0 +1,21 @@
+package constants
+
+import "time"
+
+const (
+	SongCreated = "SongCreated"
+	SongUpdated = "SongUpdated"
+	SongDeleted = "SongDeleted"
+)
+
+const (
+	EventPublisherKafka  = "kafka"
+	EventPublisherMemory = "memory"
+)
+
+const (
+	DefaultKafkaTopic     = "songs.events"
+	DefaultRelayBatchSize = 100
+	RelayPollInterval     = time.Second
+	PublishTimeout        = 10 * time.Second
+)
@@ -0,0 +1,8 @@
+package outbox
+
+import "errors"
+
+var (
+	UnknownEventPublisher = errors.New("unknown event publisher")
+	NoKafkaBrokers        = errors.New("no kafka brokers configured")
+)
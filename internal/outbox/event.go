@@ -0,0 +1,46 @@
+package outbox
+
+import (
+	"SongsLibrary/internal/db/models"
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Event is the message handed to an EventPublisher. Payload holds the JSON
+// document stored in the outbox, e.g. the song after the change for
+// SongCreated and SongUpdated, or before it for SongDeleted.
+type Event struct {
+	ID          uuid.UUID       `json:"id"`
+	Type        string          `json:"type"`
+	AggregateId uuid.UUID       `json:"aggregate_id"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// NewOutboxEvent builds the outbox row for an event about aggregateId. The
+// caller is expected to insert it with the transaction that made the change.
+func NewOutboxEvent(eventType string, aggregateId uuid.UUID, payload interface{}) (*models.OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.OutboxEvent{
+		ID:          uuid.New(),
+		Type:        eventType,
+		AggregateId: aggregateId,
+		Payload:     string(data),
+		OccurredAt:  time.Now().UTC(),
+	}, nil
+}
+
+func FromOutboxEvent(outboxEvent *models.OutboxEvent) Event {
+	return Event{
+		ID:          outboxEvent.ID,
+		Type:        outboxEvent.Type,
+		AggregateId: outboxEvent.AggregateId,
+		OccurredAt:  outboxEvent.OccurredAt,
+		Payload:     json.RawMessage(outboxEvent.Payload),
+	}
+}
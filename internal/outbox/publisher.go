@@ -0,0 +1,10 @@
+package outbox
+
+import "context"
+
+// EventPublisher delivers events to downstream consumers. Delivery is at least
+// once: an event may be published again if marking it as sent fails.
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+	Close() error
+}
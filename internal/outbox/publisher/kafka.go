@@ -0,0 +1,58 @@
+package publisher
+
+import (
+	"SongsLibrary/internal/outbox"
+	"SongsLibrary/internal/outbox/constants"
+	"context"
+	"encoding/json"
+	"github.com/segmentio/kafka-go"
+	"time"
+)
+
+// KafkaPublisher writes events to a single topic, keyed by aggregate id so that
+// all events of one song land in the same partition and keep their order.
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+func NewKafkaPublisher(brokers []string, topic string) (*KafkaPublisher, error) {
+	if len(brokers) == 0 {
+		return nil, outbox.NoKafkaBrokers
+	}
+
+	if topic == "" {
+		topic = constants.DefaultKafkaTopic
+	}
+
+	return &KafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Topic:                  topic,
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+			BatchTimeout:           10 * time.Millisecond,
+		},
+	}, nil
+}
+
+func (kp *KafkaPublisher) Publish(ctx context.Context, event outbox.Event) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return kp.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.AggregateId.String()),
+		Value: value,
+		Time:  event.OccurredAt,
+		Headers: []kafka.Header{
+			{Key: "event-type", Value: []byte(event.Type)},
+			{Key: "event-id", Value: []byte(event.ID.String())},
+		},
+	})
+}
+
+func (kp *KafkaPublisher) Close() error {
+	return kp.writer.Close()
+}
@@ -0,0 +1,71 @@
+package publisher
+
+import (
+	"SongsLibrary/internal/outbox"
+	"context"
+	"sync"
+)
+
+// MemoryPublisher keeps published events in process. It is meant for local
+// development and tests, where no broker is available.
+type MemoryPublisher struct {
+	mu          sync.Mutex
+	events      []outbox.Event
+	subscribers []chan outbox.Event
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (mp *MemoryPublisher) Publish(ctx context.Context, event outbox.Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	mp.events = append(mp.events, event)
+
+	for _, subscriber := range mp.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Subscribe returns a channel receiving events published from now on. Events
+// are dropped for a subscriber whose buffer of size buffer is full.
+func (mp *MemoryPublisher) Subscribe(buffer int) <-chan outbox.Event {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	subscriber := make(chan outbox.Event, buffer)
+	mp.subscribers = append(mp.subscribers, subscriber)
+
+	return subscriber
+}
+
+// Events returns a copy of every event published so far.
+func (mp *MemoryPublisher) Events() []outbox.Event {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	return append([]outbox.Event(nil), mp.events...)
+}
+
+func (mp *MemoryPublisher) Close() error {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	for _, subscriber := range mp.subscribers {
+		close(subscriber)
+	}
+	mp.subscribers = nil
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package publisher
+
+import (
+	"SongsLibrary/internal/outbox"
+	"SongsLibrary/internal/outbox/constants"
+	"fmt"
+	"strings"
+)
+
+// New returns the publisher called name. An empty name selects Kafka when
+// brokers are configured and the in-memory publisher otherwise.
+func New(name string, brokers []string, topic string) (outbox.EventPublisher, error) {
+	if name == "" {
+		name = constants.EventPublisherMemory
+		if len(brokers) > 0 {
+			name = constants.EventPublisherKafka
+		}
+	}
+
+	switch strings.ToLower(name) {
+	case constants.EventPublisherKafka:
+		return NewKafkaPublisher(brokers, topic)
+	case constants.EventPublisherMemory:
+		return NewMemoryPublisher(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", outbox.UnknownEventPublisher, name)
+	}
+}
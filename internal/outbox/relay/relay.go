@@ -0,0 +1,109 @@
+package relay
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/outbox"
+	"SongsLibrary/internal/outbox/constants"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+// Relay periodically moves events from the outbox table to the publisher.
+type Relay struct {
+	outboxRepo   outbox.Repository
+	publisher    outbox.EventPublisher
+	batchSize    int
+	pollInterval time.Duration
+	wg           sync.WaitGroup
+	cancel       context.CancelFunc
+}
+
+func NewRelay(outboxRepo outbox.Repository, publisher outbox.EventPublisher, batchSize int) *Relay {
+	if batchSize <= 0 {
+		batchSize = constants.DefaultRelayBatchSize
+	}
+
+	return &Relay{
+		outboxRepo:   outboxRepo,
+		publisher:    publisher,
+		batchSize:    batchSize,
+		pollInterval: constants.RelayPollInterval,
+	}
+}
+
+func (r *Relay) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	r.wg.Add(1)
+	go r.run(ctx)
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, "Started outbox relay")
+}
+
+// Stop waits for the batch in flight, if any, then closes the publisher.
+func (r *Relay) Stop(ctx context.Context) error {
+	if r.cancel == nil {
+		return r.publisher.Close()
+	}
+	r.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return r.publisher.Close()
+	case <-ctx.Done():
+		return fmt.Errorf("outbox relay did not stop: %w", ctx.Err())
+	}
+}
+
+func (r *Relay) run(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for r.publishBatch(ctx) == r.batchSize {
+			if ctx.Err() != nil {
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publishBatch publishes one batch of pending events and returns how many were
+// sent, so that a full batch is followed immediately by the next one.
+func (r *Relay) publishBatch(ctx context.Context) int {
+	// A batch is not interrupted by shutdown: events published to the broker
+	// must also be marked as published.
+	batchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), constants.PublishTimeout)
+	defer cancel()
+
+	published, err := r.outboxRepo.ProcessPending(batchCtx, r.batchSize, func(outboxEvent *models.OutboxEvent) error {
+		return r.publisher.Publish(batchCtx, outbox.FromOutboxEvent(outboxEvent))
+	})
+	if err != nil {
+		return 0
+	}
+
+	if published > 0 {
+		logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Published %d outbox events", published))
+	}
+
+	return published
+}
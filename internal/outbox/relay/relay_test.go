@@ -0,0 +1,92 @@
+package relay
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/outbox"
+	"SongsLibrary/internal/outbox/constants"
+	"SongsLibrary/internal/outbox/publisher"
+	"SongsLibrary/internal/outbox/repository/postgres"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type failingPublisher struct {
+	failOn  uuid.UUID
+	publish []outbox.Event
+}
+
+func (fp *failingPublisher) Publish(_ context.Context, event outbox.Event) error {
+	if event.ID == fp.failOn {
+		return errors.New("broker unavailable")
+	}
+	fp.publish = append(fp.publish, event)
+	return nil
+}
+
+func (fp *failingPublisher) Close() error {
+	return nil
+}
+
+func newSongEvent(t *testing.T, eventType string) models.OutboxEvent {
+	songId := uuid.New()
+
+	outboxEvent, err := outbox.NewOutboxEvent(eventType, songId, models.Song{ID: songId, Name: "hysteria"})
+	if err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	return *outboxEvent
+}
+
+func TestPublishBatch_PublishesInOrder(t *testing.T) {
+
+	mockRepo := new(postgres.MockRepository)
+	memoryPublisher := publisher.NewMemoryPublisher()
+
+	events := []models.OutboxEvent{newSongEvent(t, constants.SongCreated), newSongEvent(t, constants.SongDeleted)}
+	mockRepo.On("ProcessPending", mock.Anything, 10, mock.Anything).Return(events, nil)
+
+	r := NewRelay(mockRepo, memoryPublisher, 10)
+
+	assert.Equal(t, 2, r.publishBatch(context.Background()))
+
+	published := memoryPublisher.Events()
+	assert.Len(t, published, 2)
+	assert.Equal(t, constants.SongCreated, published[0].Type)
+	assert.Equal(t, constants.SongDeleted, published[1].Type)
+	assert.JSONEq(t, events[0].Payload, string(published[0].Payload))
+}
+
+func TestPublishBatch_StopsAtFirstFailure(t *testing.T) {
+
+	mockRepo := new(postgres.MockRepository)
+
+	events := []models.OutboxEvent{
+		newSongEvent(t, constants.SongCreated),
+		newSongEvent(t, constants.SongUpdated),
+		newSongEvent(t, constants.SongDeleted),
+	}
+	failing := &failingPublisher{failOn: events[1].ID}
+
+	mockRepo.On("ProcessPending", mock.Anything, 10, mock.Anything).Return(events, nil)
+
+	r := NewRelay(mockRepo, failing, 10)
+
+	assert.Equal(t, 1, r.publishBatch(context.Background()))
+	assert.Len(t, failing.publish, 1)
+	assert.Equal(t, events[0].ID, failing.publish[0].ID)
+}
+
+func TestPublishBatch_RepositoryError(t *testing.T) {
+
+	mockRepo := new(postgres.MockRepository)
+	mockRepo.On("ProcessPending", mock.Anything, 10, mock.Anything).Return(nil, errors.New("db down"))
+
+	r := NewRelay(mockRepo, publisher.NewMemoryPublisher(), 10)
+
+	assert.Equal(t, 0, r.publishBatch(context.Background()))
+}
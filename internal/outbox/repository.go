@@ -0,0 +1,14 @@
+package outbox
+
+import (
+	"SongsLibrary/internal/db/models"
+	"context"
+)
+
+type Repository interface {
+	// ProcessPending locks up to limit unpublished events in the order they
+	// occurred and passes them to handle one by one. Handled events are marked
+	// as published; processing stops at the first failure, which is recorded
+	// on the event, so later events of the same song are never sent first.
+	ProcessPending(ctx context.Context, limit int, handle func(*models.OutboxEvent) error) (int, error)
+}
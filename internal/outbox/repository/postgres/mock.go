@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"SongsLibrary/internal/db/models"
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+// ProcessPending hands the events given to Return, if any, to handle the way
+// the real repository does, stopping at the first failure.
+func (m *MockRepository) ProcessPending(ctx context.Context, limit int, handle func(*models.OutboxEvent) error) (int, error) {
+	args := m.Called(ctx, limit, handle)
+
+	events, _ := args.Get(0).([]models.OutboxEvent)
+
+	published := 0
+	for i := range events {
+		if err := handle(&events[i]); err != nil {
+			break
+		}
+		published++
+	}
+
+	return published, args.Error(1)
+}
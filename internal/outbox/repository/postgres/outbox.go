@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"SongsLibrary/internal/db/models"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// ProcessPending runs in one transaction so that the rows stay locked while
+// they are being published; SKIP LOCKED keeps relays of other replicas from
+// sending the same batch.
+func (or *OutboxRepository) ProcessPending(ctx context.Context, limit int, handle func(*models.OutboxEvent) error) (int, error) {
+
+	published := 0
+
+	err := or.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var events []models.OutboxEvent
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL").
+			Order("occurred_at, id").
+			Limit(limit).
+			Find(&events).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range events {
+			if handleErr := handle(&events[i]); handleErr != nil {
+				logrusCustom.Log(ctx, logrus.ErrorLevel, fmt.Sprintf("Failed to publish event %s: %s", events[i].ID, handleErr.Error()))
+
+				return tx.Model(&events[i]).Updates(map[string]interface{}{
+					"attempts":   gorm.Expr("attempts + 1"),
+					"last_error": handleErr.Error(),
+				}).Error
+			}
+
+			err := tx.Model(&events[i]).Updates(map[string]interface{}{
+				"published_at": time.Now().UTC(),
+				"attempts":     gorm.Expr("attempts + 1"),
+			}).Error
+			if err != nil {
+				return err
+			}
+
+			published++
+		}
+
+		return nil
+	})
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return 0, err
+	}
+
+	return published, nil
+}
@@ -0,0 +1,55 @@
+package pagination
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// LinkHeader builds an RFC 5988 Link header value for the list served at u.
+// Page links are relative to the request and keep its other query
+// parameters. A cursor page only links to the first page and, through the
+// cursor, to the next one.
+func LinkHeader(u *url.URL, p Pagination) string {
+	var links []string
+
+	add := func(rel string, set func(url.Values)) {
+		query := u.Query()
+		query.Del("cursor")
+		set(query)
+
+		links = append(links, fmt.Sprintf(`<%s?%s>; rel="%s"`, u.Path, query.Encode(), rel))
+	}
+
+	page := func(n int) func(url.Values) {
+		return func(query url.Values) {
+			query.Set("page", strconv.Itoa(n))
+		}
+	}
+
+	if p.Page == 0 {
+		if p.NextCursor != "" {
+			add("next", func(query url.Values) {
+				query.Del("page")
+				query.Set("cursor", p.NextCursor)
+			})
+		}
+		add("first", page(1))
+
+		return strings.Join(links, ", ")
+	}
+
+	if p.Page < p.TotalPages {
+		add("next", page(p.Page+1))
+	}
+	if p.Page > 1 {
+		add("prev", page(min(p.Page-1, max(p.TotalPages, 1))))
+	}
+	add("first", page(1))
+	if p.TotalPages > 0 {
+		add("last", page(p.TotalPages))
+	}
+
+	return strings.Join(links, ", ")
+}
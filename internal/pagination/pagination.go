@@ -0,0 +1,25 @@
+package pagination
+
+// Pagination is the envelope returned next to every list. Page is zero when
+// the list was requested with a cursor, since its position is then unknown.
+type Pagination struct {
+	Total      int64  `json:"total"`
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"page_size"`
+	TotalPages int    `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func New(total int64, page, pageSize int) Pagination {
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = int((total + int64(pageSize) - 1) / int64(pageSize))
+	}
+
+	return Pagination{
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}
+}
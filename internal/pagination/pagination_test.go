@@ -0,0 +1,59 @@
+package pagination
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+)
+
+func TestNew_TotalPages(t *testing.T) {
+	assert.Equal(t, 0, New(0, 1, 10).TotalPages)
+	assert.Equal(t, 1, New(10, 1, 10).TotalPages)
+	assert.Equal(t, 3, New(21, 1, 10).TotalPages)
+}
+
+func TestLinkHeader_Pages(t *testing.T) {
+	u, _ := url.Parse("/api/songs?name=muse&page=2&page_size=10")
+
+	link := LinkHeader(u, New(35, 2, 10))
+
+	assert.Equal(t, `</api/songs?name=muse&page=3&page_size=10>; rel="next", `+
+		`</api/songs?name=muse&page=1&page_size=10>; rel="prev", `+
+		`</api/songs?name=muse&page=1&page_size=10>; rel="first", `+
+		`</api/songs?name=muse&page=4&page_size=10>; rel="last"`, link)
+}
+
+func TestLinkHeader_LastPage(t *testing.T) {
+	u, _ := url.Parse("/api/authors?page=4")
+
+	link := LinkHeader(u, New(35, 4, 10))
+
+	assert.NotContains(t, link, `rel="next"`)
+	assert.Contains(t, link, `</api/authors?page=3>; rel="prev"`)
+}
+
+func TestLinkHeader_Cursor(t *testing.T) {
+	u, _ := url.Parse("/api/songs?cursor=current&page_size=5")
+
+	p := New(12, 0, 5)
+	p.NextCursor = "next"
+
+	assert.Equal(t, `</api/songs?cursor=next&page_size=5>; rel="next", </api/songs?page=1&page_size=5>; rel="first"`, LinkHeader(u, p))
+}
+
+func TestParseSort(t *testing.T) {
+	keys, err := ParseSort("-release_date, name", []string{"name", "release_date"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SortKey{{Field: "release_date", Desc: true}, {Field: "name"}}, keys)
+	assert.Equal(t, "-release_date,name", String(keys))
+}
+
+func TestParseSort_Invalid(t *testing.T) {
+	for _, sort := range []string{"text", "name,-name", "name,,"} {
+		_, err := ParseSort(sort, []string{"name", "release_date"})
+
+		assert.True(t, errors.Is(err, InvalidSort), sort)
+	}
+}
@@ -0,0 +1,58 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var InvalidSort = errors.New("invalid sort")
+
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort parses a comma separated list of fields, each optionally prefixed
+// with "-" for descending order, e.g. "-release_date,name". Only fields listed
+// in allowed are accepted and each field may appear once.
+func ParseSort(sort string, allowed []string) ([]SortKey, error) {
+	if strings.TrimSpace(sort) == "" {
+		return nil, nil
+	}
+
+	var keys []SortKey
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(sort, ",") {
+		part = strings.TrimSpace(part)
+
+		key := SortKey{Field: strings.TrimPrefix(strings.TrimPrefix(part, "-"), "+"), Desc: strings.HasPrefix(part, "-")}
+
+		if !slices.Contains(allowed, key.Field) {
+			return nil, fmt.Errorf("%w: unknown field %q", InvalidSort, key.Field)
+		}
+		if seen[key.Field] {
+			return nil, fmt.Errorf("%w: duplicate field %q", InvalidSort, key.Field)
+		}
+		seen[key.Field] = true
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// String formats keys back into the sort parameter syntax.
+func String(keys []SortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key.Field
+		if key.Desc {
+			parts[i] = "-" + key.Field
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,13 @@
+package constants
+
+import "time"
+
+const (
+	DefaultResponsesPage     = 1
+	DefaultResponsesPageSize = 20
+
+	// DefaultTTL and DefaultNegativeTTL apply when PROVIDER_CACHE_TTL and
+	// PROVIDER_CACHE_NEGATIVE_TTL are not set.
+	DefaultTTL         = 7 * 24 * time.Hour
+	DefaultNegativeTTL = 24 * time.Hour
+)
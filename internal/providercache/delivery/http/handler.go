@@ -0,0 +1,120 @@
+package http
+
+import (
+	"SongsLibrary/internal/pagination"
+	"SongsLibrary/internal/providercache"
+	"SongsLibrary/internal/providercache/dtos"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"time"
+)
+
+type Handler struct {
+	useCase providercache.UseCase
+}
+
+func NewHandler(useCase providercache.UseCase) *Handler {
+	return &Handler{
+		useCase: useCase,
+	}
+}
+
+// GetResponses
+// @Summary Inspect cached provider responses
+// @Description List the responses of external metadata providers cached in the database, including "not found" entries, with optional filters on provider and on a substring of the normalized "group|song" query.
+// @Tags Provider Cache
+// @Produce json
+// @Param provider query string false "Provider name, e.g. musixmatch or genius"
+// @Param query query string false "Substring of the normalized group|song query"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Number of responses per page (default: 20)"
+// @Success 200 {array} models.ProviderResponse "List of cached responses"
+// @Failure 400 {object} string "Invalid input data"
+// @Failure 404 {object} string "Responses not found"
+// @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/admin/provider-cache [get]
+func (h *Handler) GetResponses(c *gin.Context) {
+	var grdto dtos.GetResponsesDTO
+
+	if err := c.ShouldBindQuery(&grdto); err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": providercache.InvalidInputData.Error()})
+		return
+	}
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered GetResponses Hanlder with parameters: %+v", grdto))
+
+	grdto.SetDefaults()
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	page, err := h.useCase.GetResponses(ctx, &grdto)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		if err.Error() == providercache.ResponsesNotFound.Error() {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": providercache.ResponsesNotFound.Error()})
+			return
+		}
+
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	envelope := pagination.New(page.Total, grdto.Page, grdto.PageSize)
+	c.Header("Link", pagination.LinkHeader(c.Request.URL, envelope))
+
+	c.JSON(http.StatusOK, gin.H{"responses": page.Responses, "pagination": envelope})
+}
+
+// PurgeResponses
+// @Summary Purge cached provider responses
+// @Description Delete cached provider responses so the next lookup goes to the provider again. Takes the filters of GET /api/admin/provider-cache and optionally only expired entries; without filters the whole cache is purged.
+// @Tags Provider Cache
+// @Produce json
+// @Param provider query string false "Provider name, e.g. musixmatch or genius"
+// @Param query query string false "Substring of the normalized group|song query"
+// @Param expired query bool false "Only purge expired responses"
+// @Success 200 {object} map[string]int64 "Number of purged responses"
+// @Failure 400 {object} string "Invalid input data"
+// @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/admin/provider-cache [delete]
+func (h *Handler) PurgeResponses(c *gin.Context) {
+	var prdto dtos.PurgeResponsesDTO
+
+	if err := c.ShouldBindQuery(&prdto); err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": providercache.InvalidInputData.Error()})
+		return
+	}
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered PurgeResponses Hanlder with parameters: %+v", prdto))
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	purged, err := h.useCase.PurgeResponses(ctx, &prdto)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"purged": purged})
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"SongsLibrary/internal/auth/authenticator"
+	authhttp "SongsLibrary/internal/auth/delivery/http"
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/providercache"
+	"SongsLibrary/internal/providercache/dtos"
+	"SongsLibrary/internal/providercache/usecase"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func setup() (*gin.Engine, *usecase.MockProviderCacheUseCase) {
+	gin.SetMode(gin.TestMode)
+
+	mockUseCase := new(usecase.MockProviderCacheUseCase)
+
+	r := gin.Default()
+	RegisterHTTPEndpoints(r, mockUseCase, authhttp.NewMiddleware(authenticator.Disabled{}, nil, nil))
+
+	return r, mockUseCase
+}
+
+func TestGetResponsesHandler_Success(t *testing.T) {
+	r, mockUseCase := setup()
+
+	page := &dtos.ResponsesPage{
+		Responses: []models.ProviderResponse{{Provider: "genius", QueryKey: "muse|hysteria", NotFound: true}},
+		Total:     1,
+	}
+
+	mockUseCase.On("GetResponses", mock.Anything, &dtos.GetResponsesDTO{Provider: "genius", Query: "muse", Page: 1, PageSize: 20}).Return(page, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/admin/provider-cache?provider=genius&query=muse", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Responses []models.ProviderResponse `json:"responses"`
+	}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	assert.Equal(t, page.Responses, response.Responses)
+}
+
+func TestGetResponsesHandler_NotFound(t *testing.T) {
+	r, mockUseCase := setup()
+
+	mockUseCase.On("GetResponses", mock.Anything, mock.Anything).Return(nil, providercache.ResponsesNotFound)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/admin/provider-cache", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPurgeResponsesHandler_Success(t *testing.T) {
+	r, mockUseCase := setup()
+
+	mockUseCase.On("PurgeResponses", mock.Anything, &dtos.PurgeResponsesDTO{Provider: "musixmatch", Expired: true}).Return(int64(3), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/admin/provider-cache?provider=musixmatch&expired=true", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"purged":3}`, w.Body.String())
+}
@@ -0,0 +1,18 @@
+package http
+
+import (
+	"SongsLibrary/internal/auth/constants"
+	authhttp "SongsLibrary/internal/auth/delivery/http"
+	"SongsLibrary/internal/providercache"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterHTTPEndpoints(router *gin.Engine, uc providercache.UseCase, authMiddleware *authhttp.Middleware) {
+	h := NewHandler(uc)
+
+	adminEndPoints := router.Group("/api/admin", authMiddleware.Authenticate(), authMiddleware.RequireRole(constants.RoleAdmin))
+	{
+		adminEndPoints.GET("/provider-cache", h.GetResponses)
+		adminEndPoints.DELETE("/provider-cache", h.PurgeResponses)
+	}
+}
@@ -0,0 +1,29 @@
+package dtos
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/providercache/constants"
+)
+
+// GetResponsesDTO filters cached responses by provider name and by a
+// substring of their normalized query.
+type GetResponsesDTO struct {
+	Provider string `form:"provider" binding:"omitempty,max=32"`
+	Query    string `form:"query" binding:"omitempty,max=200"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+func (dto *GetResponsesDTO) SetDefaults() {
+	if dto.Page == 0 {
+		dto.Page = constants.DefaultResponsesPage
+	}
+	if dto.PageSize == 0 {
+		dto.PageSize = constants.DefaultResponsesPageSize
+	}
+}
+
+type ResponsesPage struct {
+	Responses []models.ProviderResponse
+	Total     int64
+}
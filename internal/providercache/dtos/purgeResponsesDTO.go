@@ -0,0 +1,10 @@
+package dtos
+
+// PurgeResponsesDTO selects the cached responses to delete with the filters of
+// GetResponsesDTO. Expired limits the purge to expired entries; without any
+// filter the whole cache is purged.
+type PurgeResponsesDTO struct {
+	Provider string `form:"provider" binding:"omitempty,max=32"`
+	Query    string `form:"query" binding:"omitempty,max=200"`
+	Expired  bool   `form:"expired"`
+}
@@ -0,0 +1,9 @@
+package providercache
+
+import "errors"
+
+var (
+	ResponseNotFound  = errors.New("provider response not cached")
+	ResponsesNotFound = errors.New("provider responses not found")
+	InvalidInputData  = errors.New("invalid input data")
+)
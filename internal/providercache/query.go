@@ -0,0 +1,13 @@
+package providercache
+
+import "strings"
+
+// NormalizeQuery builds the cache key of a lookup, so that queries differing
+// only in case or whitespace share one entry.
+func NormalizeQuery(groupName, songName string) string {
+	return normalize(groupName) + "|" + normalize(songName)
+}
+
+func normalize(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
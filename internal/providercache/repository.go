@@ -0,0 +1,14 @@
+package providercache
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/providercache/dtos"
+	"context"
+)
+
+type Repository interface {
+	GetResponse(ctx context.Context, provider, queryKey string) (*models.ProviderResponse, error)
+	SaveResponse(context.Context, *models.ProviderResponse) error
+	GetResponses(context.Context, *dtos.GetResponsesDTO) (*dtos.ResponsesPage, error)
+	DeleteResponses(context.Context, *dtos.PurgeResponsesDTO) (int64, error)
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/providercache/dtos"
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) GetResponse(ctx context.Context, provider, queryKey string) (*models.ProviderResponse, error) {
+	args := m.Called(ctx, provider, queryKey)
+	if response, ok := args.Get(0).(*models.ProviderResponse); ok {
+		return response, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRepository) SaveResponse(ctx context.Context, response *models.ProviderResponse) error {
+	args := m.Called(ctx, response)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetResponses(ctx context.Context, grdto *dtos.GetResponsesDTO) (*dtos.ResponsesPage, error) {
+	args := m.Called(ctx, grdto)
+	if page, ok := args.Get(0).(*dtos.ResponsesPage); ok {
+		return page, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRepository) DeleteResponses(ctx context.Context, prdto *dtos.PurgeResponsesDTO) (int64, error) {
+	args := m.Called(ctx, prdto)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/providercache"
+	"SongsLibrary/internal/providercache/dtos"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
+)
+
+type ProviderResponseRepository struct {
+	db *gorm.DB
+}
+
+func NewProviderResponseRepository(db *gorm.DB) *ProviderResponseRepository {
+	return &ProviderResponseRepository{db: db}
+}
+
+// GetResponse returns the cached response unless it has expired. Expired
+// entries are left in place until they are overwritten or purged.
+func (prr *ProviderResponseRepository) GetResponse(ctx context.Context, provider, queryKey string) (*models.ProviderResponse, error) {
+
+	logrusCustom.Log(ctx, logrus.DebugLevel, fmt.Sprintf("Entered GetResponse Repository with parameters: provider:%s, query:%s", provider, queryKey))
+
+	var response models.ProviderResponse
+
+	err := prr.db.WithContext(ctx).
+		Where("provider = ? AND query_key = ? AND expires_at > ?", provider, queryKey, time.Now()).
+		First(&response).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, providercache.ResponseNotFound
+		}
+
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (prr *ProviderResponseRepository) SaveResponse(ctx context.Context, response *models.ProviderResponse) error {
+
+	logrusCustom.Log(ctx, logrus.DebugLevel, fmt.Sprintf("Entered SaveResponse Repository with parameters: provider:%s, query:%s, notFound:%t", response.Provider, response.QueryKey, response.NotFound))
+
+	err := prr.db.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(response).Error
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+func (prr *ProviderResponseRepository) GetResponses(ctx context.Context, grdto *dtos.GetResponsesDTO) (*dtos.ResponsesPage, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetResponses Repository with parameters: %+v", grdto))
+
+	query := filterResponses(prr.db.WithContext(ctx).Model(&models.ProviderResponse{}), grdto.Provider, grdto.Query)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	var responses []models.ProviderResponse
+
+	offset := (grdto.Page - 1) * grdto.PageSize
+	err := query.Order("provider, query_key").Offset(offset).Limit(grdto.PageSize).Find(&responses).Error
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	if len(responses) == 0 {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, providercache.ResponsesNotFound.Error())
+
+		return nil, providercache.ResponsesNotFound
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetResponses Repository with %d of %d responses", len(responses), total))
+
+	return &dtos.ResponsesPage{Responses: responses, Total: total}, nil
+}
+
+func (prr *ProviderResponseRepository) DeleteResponses(ctx context.Context, prdto *dtos.PurgeResponsesDTO) (int64, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered DeleteResponses Repository with parameters: %+v", prdto))
+
+	query := filterResponses(prr.db.WithContext(ctx), prdto.Provider, prdto.Query)
+	if prdto.Expired {
+		query = query.Where("expires_at <= ?", time.Now())
+	}
+
+	result := query.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&models.ProviderResponse{})
+	if result.Error != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, result.Error.Error())
+
+		return 0, result.Error
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting DeleteResponses Repository with %d purged responses", result.RowsAffected))
+
+	return result.RowsAffected, nil
+}
+
+func filterResponses(query *gorm.DB, provider, queryKey string) *gorm.DB {
+	if provider != "" {
+		query = query.Where("provider = ?", strings.ToLower(provider))
+	}
+	if queryKey != "" {
+		query = query.Where("query_key LIKE ?", "%"+strings.ToLower(strings.TrimSpace(queryKey))+"%")
+	}
+
+	return query
+}
@@ -0,0 +1,11 @@
+package providercache
+
+import (
+	"SongsLibrary/internal/providercache/dtos"
+	"context"
+)
+
+type UseCase interface {
+	GetResponses(context.Context, *dtos.GetResponsesDTO) (*dtos.ResponsesPage, error)
+	PurgeResponses(context.Context, *dtos.PurgeResponsesDTO) (int64, error)
+}
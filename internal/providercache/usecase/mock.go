@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/providercache/dtos"
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProviderCacheUseCase struct {
+	mock.Mock
+}
+
+func (m *MockProviderCacheUseCase) GetResponses(ctx context.Context, grdto *dtos.GetResponsesDTO) (*dtos.ResponsesPage, error) {
+	args := m.Called(ctx, grdto)
+	if page, ok := args.Get(0).(*dtos.ResponsesPage); ok {
+		return page, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockProviderCacheUseCase) PurgeResponses(ctx context.Context, prdto *dtos.PurgeResponsesDTO) (int64, error) {
+	args := m.Called(ctx, prdto)
+	return args.Get(0).(int64), args.Error(1)
+}
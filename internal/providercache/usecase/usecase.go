@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/providercache"
+	"SongsLibrary/internal/providercache/dtos"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+)
+
+type ProviderCacheUseCase struct {
+	responseRepo providercache.Repository
+}
+
+func NewProviderCacheUseCase(responseRepo providercache.Repository) *ProviderCacheUseCase {
+	return &ProviderCacheUseCase{responseRepo: responseRepo}
+}
+
+func (pcuc *ProviderCacheUseCase) GetResponses(ctx context.Context, grdto *dtos.GetResponsesDTO) (*dtos.ResponsesPage, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetResponses UseCase with parameters: %+v", grdto))
+
+	return pcuc.responseRepo.GetResponses(ctx, grdto)
+}
+
+func (pcuc *ProviderCacheUseCase) PurgeResponses(ctx context.Context, prdto *dtos.PurgeResponsesDTO) (int64, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered PurgeResponses UseCase with parameters: %+v", prdto))
+
+	purged, err := pcuc.responseRepo.DeleteResponses(ctx, prdto)
+	if err != nil {
+		return 0, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting PurgeResponses UseCase with %d purged responses", purged))
+
+	return purged, nil
+}
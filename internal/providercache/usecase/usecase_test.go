@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/providercache/dtos"
+	"SongsLibrary/internal/providercache/repository/postgres"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestPurgeResponsesUseCase_Success(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	pcuc := NewProviderCacheUseCase(mockRepo)
+
+	prdto := &dtos.PurgeResponsesDTO{Provider: "genius"}
+	mockRepo.On("DeleteResponses", mock.Anything, prdto).Return(int64(2), nil)
+
+	purged, err := pcuc.PurgeResponses(context.Background(), prdto)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPurgeResponsesUseCase_RepositoryError(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	pcuc := NewProviderCacheUseCase(mockRepo)
+
+	mockRepo.On("DeleteResponses", mock.Anything, mock.Anything).Return(int64(0), errors.New("db down"))
+
+	_, err := pcuc.PurgeResponses(context.Background(), &dtos.PurgeResponsesDTO{})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"SongsLibrary/internal/auth"
+	"SongsLibrary/internal/auth/constants"
+	"SongsLibrary/internal/ratelimit"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type Interceptor struct {
+	ipLimiter        *ratelimit.Limiter
+	principalLimiter *ratelimit.Limiter
+	exempt           []string
+}
+
+// NewInterceptor returns the interceptor limiting peer IPs with ipLimiter and
+// authenticated principals with principalLimiter. The exempt methods, such as
+// health checks, are never limited, as the HTTP probes are not.
+func NewInterceptor(ipLimiter, principalLimiter *ratelimit.Limiter, exempt ...string) *Interceptor {
+	return &Interceptor{ipLimiter: ipLimiter, principalLimiter: principalLimiter, exempt: exempt}
+}
+
+// Unary rejects with ResourceExhausted calls over the limit of their method
+// for their peer IP. It runs before authentication and so ignores
+// credentials, which are not verified yet.
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := i.allow(ctx, i.ipLimiter, info.FullMethod, ratelimit.IPClient(peerIP(ctx))); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Stream limits the opening of streams the same way as Unary limits calls.
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := i.allow(ss.Context(), i.ipLimiter, info.FullMethod, ratelimit.IPClient(peerIP(ss.Context()))); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// AllowPrincipal applies the limit of method to the authenticated principal,
+// whichever credential or peer it uses. It is called by the authentication
+// interceptor.
+func (i *Interceptor) AllowPrincipal(ctx context.Context, method string, principal *auth.Principal) error {
+	return i.allow(ctx, i.principalLimiter, method, ratelimit.PrincipalClient(principal.Subject))
+}
+
+func (i *Interceptor) allow(ctx context.Context, limiter *ratelimit.Limiter, method, client string) error {
+	if slices.Contains(i.exempt, method) {
+		return nil
+	}
+
+	allowed, retryAfter := limiter.Allow(method, client)
+	if allowed {
+		return nil
+	}
+
+	logrusCustom.Log(ctx, logrus.ErrorLevel, fmt.Sprintf("Rate limited %s for %s", method, client))
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(constants.RetryAfterHeader), strconv.Itoa(ratelimit.RetryAfterSeconds(retryAfter))))
+
+	return status.Error(codes.ResourceExhausted, ratelimit.RateLimited.Error())
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
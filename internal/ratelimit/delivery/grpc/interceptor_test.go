@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"SongsLibrary/internal/auth"
+	"SongsLibrary/internal/auth/constants"
+	"SongsLibrary/internal/ratelimit"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"testing"
+)
+
+func TestInterceptor_Unary(t *testing.T) {
+
+	limiter := ratelimit.NewLimiter(nil, map[string]ratelimit.Rule{"/songsLibrary.Song/CreateSong": {Rate: 0.1, Burst: 1}})
+	interceptor := NewInterceptor(limiter, ratelimit.NewLimiter(nil, nil)).Unary()
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/songsLibrary.Song/CreateSong"}
+
+	_, err := interceptor(ctx, nil, info, handler)
+	assert.NoError(t, err)
+
+	_, err = interceptor(ctx, nil, info, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/songsLibrary.Song/GetSongs"}, handler)
+	assert.NoError(t, err)
+}
+
+func TestInterceptor_UnaryIgnoresCredentials(t *testing.T) {
+
+	limiter := ratelimit.NewLimiter(nil, map[string]ratelimit.Rule{"/songsLibrary.Song/CreateSong": {Rate: 0.1, Burst: 1}})
+	interceptor := NewInterceptor(limiter, ratelimit.NewLimiter(nil, nil)).Unary()
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/songsLibrary.Song/CreateSong"}
+
+	_, err := interceptor(metadata.NewIncomingContext(ctx, metadata.Pairs(constants.APIKeyMetadata, "first")), nil, info, handler)
+	assert.NoError(t, err)
+
+	_, err = interceptor(metadata.NewIncomingContext(ctx, metadata.Pairs(constants.APIKeyMetadata, "second")), nil, info, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "unverified keys share the peer bucket")
+}
+
+func TestInterceptor_AllowPrincipal(t *testing.T) {
+
+	limiter := ratelimit.NewLimiter(nil, map[string]ratelimit.Rule{"/songsLibrary.Song/CreateSong": {Rate: 0.1, Burst: 1}})
+	interceptor := NewInterceptor(ratelimit.NewLimiter(nil, nil), limiter)
+
+	principal := &auth.Principal{Subject: "partner"}
+
+	assert.NoError(t, interceptor.AllowPrincipal(context.Background(), "/songsLibrary.Song/CreateSong", principal))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(interceptor.AllowPrincipal(context.Background(), "/songsLibrary.Song/CreateSong", principal)))
+	assert.NoError(t, interceptor.AllowPrincipal(context.Background(), "/songsLibrary.Song/CreateSong", &auth.Principal{Subject: "other"}))
+}
+
+func TestInterceptor_UnaryExempt(t *testing.T) {
+
+	limiter := ratelimit.NewLimiter(&ratelimit.Rule{Rate: 0.1, Burst: 1}, nil)
+	interceptor := NewInterceptor(limiter, ratelimit.NewLimiter(nil, nil), healthv1.Health_Check_FullMethodName).Unary()
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	for range 3 {
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: healthv1.Health_Check_FullMethodName}, handler)
+		assert.NoError(t, err, "health checks are never limited")
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/songsLibrary.Song/GetSongs"}
+
+	_, err := interceptor(ctx, nil, info, handler)
+	assert.NoError(t, err)
+
+	_, err = interceptor(ctx, nil, info, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestInterceptor_PrincipalsSharingAPeer(t *testing.T) {
+
+	ipLimiter := ratelimit.NewLimiter(nil, map[string]ratelimit.Rule{"/songsLibrary.Song/CreateSong": {Rate: 0.1, Burst: 10}})
+	principalLimiter := ratelimit.NewLimiter(nil, map[string]ratelimit.Rule{"/songsLibrary.Song/CreateSong": {Rate: 0.1, Burst: 1}})
+	interceptor := NewInterceptor(ipLimiter, principalLimiter)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/songsLibrary.Song/CreateSong"}
+
+	call := func(subject string) error {
+		if _, err := interceptor.Unary()(ctx, nil, info, handler); err != nil {
+			return err
+		}
+
+		return interceptor.AllowPrincipal(ctx, info.FullMethod, &auth.Principal{Subject: subject})
+	}
+
+	assert.NoError(t, call("partner"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call("partner")))
+	assert.NoError(t, call("other"), "a principal behind the same peer keeps its own bucket")
+}
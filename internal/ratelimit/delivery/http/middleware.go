@@ -0,0 +1,63 @@
+package http
+
+import (
+	"SongsLibrary/internal/auth"
+	"SongsLibrary/internal/auth/constants"
+	"SongsLibrary/internal/ratelimit"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strconv"
+)
+
+type Middleware struct {
+	ipLimiter        *ratelimit.Limiter
+	principalLimiter *ratelimit.Limiter
+}
+
+// NewMiddleware limits client IPs with ipLimiter and authenticated principals
+// with principalLimiter.
+func NewMiddleware(ipLimiter, principalLimiter *ratelimit.Limiter) *Middleware {
+	return &Middleware{ipLimiter: ipLimiter, principalLimiter: principalLimiter}
+}
+
+// Limit rejects with 429 and a Retry-After header requests over the limit of
+// their route for their client IP. It runs before authentication so that
+// rejected clients cost nothing beyond the bucket lookup, and so it ignores
+// credentials, which are not verified yet. The IP is only read from forwarding
+// headers sent by the trusted proxies of the router.
+func (m *Middleware) Limit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !m.allow(c, m.ipLimiter, ratelimit.IPClient(c.ClientIP())) {
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// AllowPrincipal applies the limit of the route to the authenticated
+// principal, whichever credential or IP it uses. It is called by the
+// authentication middleware and aborts the request with 429 when the
+// principal is over the limit.
+func (m *Middleware) AllowPrincipal(c *gin.Context, principal *auth.Principal) bool {
+	return m.allow(c, m.principalLimiter, ratelimit.PrincipalClient(principal.Subject))
+}
+
+func (m *Middleware) allow(c *gin.Context, limiter *ratelimit.Limiter, client string) bool {
+	route := c.Request.Method + " " + c.FullPath()
+
+	allowed, retryAfter := limiter.Allow(route, client)
+	if allowed {
+		return true
+	}
+
+	logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, fmt.Sprintf("Rate limited %s for %s", route, client))
+
+	c.Header(constants.RetryAfterHeader, strconv.Itoa(ratelimit.RetryAfterSeconds(retryAfter)))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": ratelimit.RateLimited.Error()})
+
+	return false
+}
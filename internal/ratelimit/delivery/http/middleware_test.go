@@ -0,0 +1,116 @@
+package http
+
+import (
+	"SongsLibrary/internal/auth"
+	"SongsLibrary/internal/auth/constants"
+	"SongsLibrary/internal/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func setup() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	limiter := ratelimit.NewLimiter(nil, map[string]ratelimit.Rule{"POST /api/songs": {Rate: 0.1, Burst: 1}})
+
+	r := gin.New()
+	_ = r.SetTrustedProxies(nil)
+	r.Use(NewMiddleware(limiter, ratelimit.NewLimiter(nil, nil)).Limit())
+	r.POST("/api/songs", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	r.GET("/api/songs", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	return r
+}
+
+func performRequest(r *gin.Engine, method, apiKey string, headers ...string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, "/api/songs", nil)
+	if apiKey != "" {
+		req.Header.Set(constants.APIKeyHeader, apiKey)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestMiddleware_Limit(t *testing.T) {
+	r := setup()
+
+	assert.Equal(t, http.StatusCreated, performRequest(r, http.MethodPost, "").Code)
+
+	w := performRequest(r, http.MethodPost, "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "10", w.Header().Get(constants.RetryAfterHeader))
+
+	assert.Equal(t, http.StatusOK, performRequest(r, http.MethodGet, "").Code)
+}
+
+func TestMiddleware_LimitIgnoresCredentials(t *testing.T) {
+	r := setup()
+
+	assert.Equal(t, http.StatusCreated, performRequest(r, http.MethodPost, uuid.NewString()).Code)
+
+	for _, apiKey := range []string{uuid.NewString(), uuid.NewString(), "invalid"} {
+		assert.Equal(t, http.StatusTooManyRequests, performRequest(r, http.MethodPost, apiKey).Code, "unverified keys share the IP bucket")
+	}
+}
+
+func TestMiddleware_LimitIgnoresForgedForwardedFor(t *testing.T) {
+	r := setup()
+
+	assert.Equal(t, http.StatusCreated, performRequest(r, http.MethodPost, "", "X-Forwarded-For", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, performRequest(r, http.MethodPost, "", "X-Forwarded-For", "10.0.0.2").Code)
+}
+
+func TestMiddleware_AllowPrincipal(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	limiter := ratelimit.NewLimiter(nil, map[string]ratelimit.Rule{"POST /api/songs": {Rate: 0.1, Burst: 1}})
+	m := NewMiddleware(ratelimit.NewLimiter(nil, nil), limiter)
+
+	r := gin.New()
+	r.POST("/api/songs", func(c *gin.Context) {
+		if !m.AllowPrincipal(c, &auth.Principal{Subject: c.GetHeader(constants.APIKeyHeader)}) {
+			return
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	assert.Equal(t, http.StatusCreated, performRequest(r, http.MethodPost, "partner", "X-Forwarded-For", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, performRequest(r, http.MethodPost, "partner", "X-Forwarded-For", "10.0.0.2").Code, "principals are limited whatever their IP")
+	assert.Equal(t, http.StatusCreated, performRequest(r, http.MethodPost, "other").Code)
+}
+
+func TestMiddleware_PrincipalsSharingAnIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ipLimiter := ratelimit.NewLimiter(nil, map[string]ratelimit.Rule{"POST /api/songs": {Rate: 0.1, Burst: 10}})
+	principalLimiter := ratelimit.NewLimiter(nil, map[string]ratelimit.Rule{"POST /api/songs": {Rate: 0.1, Burst: 2}})
+	m := NewMiddleware(ipLimiter, principalLimiter)
+
+	r := gin.New()
+	_ = r.SetTrustedProxies(nil)
+	r.Use(m.Limit())
+	r.POST("/api/songs", func(c *gin.Context) {
+		if !m.AllowPrincipal(c, &auth.Principal{Subject: c.GetHeader(constants.APIKeyHeader)}) {
+			return
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	for range 2 {
+		assert.Equal(t, http.StatusCreated, performRequest(r, http.MethodPost, "partner").Code)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, performRequest(r, http.MethodPost, "partner").Code)
+
+	for range 2 {
+		assert.Equal(t, http.StatusCreated, performRequest(r, http.MethodPost, "other").Code, "a principal behind the same IP keeps its own bucket")
+	}
+}
@@ -0,0 +1,8 @@
+package ratelimit
+
+import "errors"
+
+var (
+	RateLimited = errors.New("rate limit exceeded, retry later")
+	InvalidRule = errors.New("invalid rate limit rule, expected <count>/<s|m|h>[:burst]")
+)
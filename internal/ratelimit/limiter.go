@@ -0,0 +1,133 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often buckets that have refilled completely, and so
+// behave like new ones, are dropped.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	idle    time.Duration
+}
+
+// Limiter keeps one token bucket per route and client in memory. Routes
+// without a rule of their own use the fallback rule, or are not limited when
+// there is none.
+type Limiter struct {
+	mu        sync.Mutex
+	fallback  *Rule
+	rules     map[string]Rule
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewLimiter(fallback *Rule, rules map[string]Rule) *Limiter {
+	return &Limiter{
+		fallback:  fallback,
+		rules:     rules,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Config holds the rules in the formats of ParseRule and ParseRules.
+type Config struct {
+	Default string
+	Routes  string
+}
+
+// New builds the limiter described by config. An empty config limits nothing.
+func New(config Config) (*Limiter, error) {
+	var fallback *Rule
+	if config.Default != "" {
+		rule, err := ParseRule(config.Default)
+		if err != nil {
+			return nil, err
+		}
+		fallback = &rule
+	}
+
+	rules, err := ParseRules(config.Routes)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLimiter(fallback, rules), nil
+}
+
+// Allow takes a token from the bucket of client on route. When the bucket is
+// empty it returns false and how long until the next token is available.
+func (l *Limiter) Allow(route, client string) (bool, time.Duration) {
+	rule, ok := l.rules[route]
+	if !ok {
+		if l.fallback == nil {
+			return true, 0
+		}
+		rule = *l.fallback
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	key := route + " " + client
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: rule.Burst, idle: time.Duration(rule.Burst / rule.Rate * float64(time.Second))}
+		l.buckets[key] = b
+	} else {
+		b.tokens = math.Min(rule.Burst, b.tokens+now.Sub(b.updated).Seconds()*rule.Rate)
+	}
+	b.updated = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	return false, time.Duration((1 - b.tokens) / rule.Rate * float64(time.Second))
+}
+
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= b.idle {
+			delete(l.buckets, key)
+		}
+	}
+
+	l.lastSweep = now
+}
+
+// IPClient identifies a caller by its IP. It is the only identity available
+// before authentication, as credentials are not verified yet and could be
+// made up on every request to get a new bucket.
+func IPClient(ip string) string {
+	return "ip:" + ip
+}
+
+// PrincipalClient identifies an authenticated caller by the subject of its
+// principal, whatever credential it used.
+func PrincipalClient(subject string) string {
+	return "principal:" + subject
+}
+
+// RetryAfterSeconds rounds wait up to the whole seconds of a Retry-After
+// header.
+func RetryAfterSeconds(wait time.Duration) int {
+	return max(int(math.Ceil(wait.Seconds())), 1)
+}
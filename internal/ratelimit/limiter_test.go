@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("POST  /api/songs=10/m, /songsLibrary.Song/CreateSong=2/s:5")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]Rule{
+		"POST /api/songs":               {Rate: 10.0 / 60, Burst: 10},
+		"/songsLibrary.Song/CreateSong": {Rate: 2, Burst: 5},
+	}, rules)
+}
+
+func TestParseRule_Invalid(t *testing.T) {
+	for _, rule := range []string{"", "10", "10/d", "0/s", "ten/s", "10/s:0"} {
+		_, err := ParseRule(rule)
+
+		assert.True(t, errors.Is(err, InvalidRule), rule)
+	}
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	limiter := NewLimiter(nil, map[string]Rule{"POST /api/songs": {Rate: 1, Burst: 2}})
+	limiter.now = func() time.Time { return now }
+
+	allowed, _ := limiter.Allow("POST /api/songs", "ip:1")
+	assert.True(t, allowed)
+	allowed, _ = limiter.Allow("POST /api/songs", "ip:1")
+	assert.True(t, allowed)
+
+	allowed, retryAfter := limiter.Allow("POST /api/songs", "ip:1")
+	assert.False(t, allowed)
+	assert.Equal(t, time.Second, retryAfter)
+
+	allowed, _ = limiter.Allow("POST /api/songs", "ip:2")
+	assert.True(t, allowed, "clients have separate buckets")
+
+	allowed, _ = limiter.Allow("GET /api/songs", "ip:1")
+	assert.True(t, allowed, "routes without a rule are not limited")
+
+	now = now.Add(1500 * time.Millisecond)
+	allowed, _ = limiter.Allow("POST /api/songs", "ip:1")
+	assert.True(t, allowed)
+}
+
+func TestLimiter_Sweep(t *testing.T) {
+	now := time.Now()
+
+	limiter := NewLimiter(&Rule{Rate: 1, Burst: 1}, nil)
+	limiter.now = func() time.Time { return now }
+
+	limiter.Allow("GET /api/songs", "ip:1")
+	assert.Len(t, limiter.buckets, 1)
+
+	now = now.Add(2 * sweepInterval)
+	limiter.Allow("GET /api/songs", "ip:2")
+	assert.Len(t, limiter.buckets, 1)
+}
+
+func TestClient(t *testing.T) {
+	assert.Equal(t, "ip:10.0.0.1", IPClient("10.0.0.1"))
+	assert.Equal(t, "principal:partner", PrincipalClient("partner"))
+	assert.NotEqual(t, IPClient("partner"), PrincipalClient("partner"))
+}
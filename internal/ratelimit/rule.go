@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var rulePeriods = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// Rule is a token bucket: it holds up to Burst tokens and refills Rate tokens
+// per second. Every request takes one token.
+type Rule struct {
+	Rate  float64
+	Burst float64
+}
+
+// ParseRule parses "<count>/<s|m|h>[:burst]", e.g. "10/m" or "100/s:20". The
+// burst defaults to count.
+func ParseRule(rule string) (Rule, error) {
+	limit, burst, hasBurst := strings.Cut(strings.TrimSpace(rule), ":")
+
+	count, unit, ok := strings.Cut(limit, "/")
+	period, known := rulePeriods[unit]
+	if !ok || !known {
+		return Rule{}, fmt.Errorf("%w: %q", InvalidRule, rule)
+	}
+
+	requests, err := strconv.Atoi(count)
+	if err != nil || requests <= 0 {
+		return Rule{}, fmt.Errorf("%w: %q", InvalidRule, rule)
+	}
+
+	parsed := Rule{Rate: float64(requests) / period.Seconds(), Burst: float64(requests)}
+
+	if hasBurst {
+		burstSize, err := strconv.Atoi(burst)
+		if err != nil || burstSize <= 0 {
+			return Rule{}, fmt.Errorf("%w: %q", InvalidRule, rule)
+		}
+		parsed.Burst = float64(burstSize)
+	}
+
+	return parsed, nil
+}
+
+// ParseRules parses comma separated "<route>=<rule>" pairs. HTTP routes are
+// written as "<METHOD> <path>" with the path as registered in gin, e.g.
+// "POST /api/songs"; gRPC routes are full method names such as
+// "/songsLibrary.Song/CreateSong".
+func ParseRules(rules string) (map[string]Rule, error) {
+	parsed := make(map[string]Rule)
+
+	for _, pair := range strings.Split(rules, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		index := strings.LastIndex(pair, "=")
+		if index < 0 {
+			return nil, fmt.Errorf("%w: %q", InvalidRule, pair)
+		}
+
+		rule, err := ParseRule(pair[index+1:])
+		if err != nil {
+			return nil, err
+		}
+
+		parsed[strings.Join(strings.Fields(pair[:index]), " ")] = rule
+	}
+
+	return parsed, nil
+}
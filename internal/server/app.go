@@ -2,14 +2,56 @@ package server
 
 import (
 	_ "SongsLibrary/docs"
+	"SongsLibrary/internal/apikey"
+	apikeyhttp "SongsLibrary/internal/apikey/delivery/http"
+	apikeypostgres "SongsLibrary/internal/apikey/repository/postgres"
+	apikeyusecase "SongsLibrary/internal/apikey/usecase"
+	"SongsLibrary/internal/auth"
+	"SongsLibrary/internal/auth/authenticator"
+	authhttp "SongsLibrary/internal/auth/delivery/http"
+	"SongsLibrary/internal/author"
+	authorhttp "SongsLibrary/internal/author/delivery/http"
+	authorpostgres "SongsLibrary/internal/author/repository/postgres"
+	authorusecase "SongsLibrary/internal/author/usecase"
+	"SongsLibrary/internal/cache"
+	"SongsLibrary/internal/config"
 	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/health"
+	healthgrpc "SongsLibrary/internal/health/delivery/grpc"
+	healthhttp "SongsLibrary/internal/health/delivery/http"
+	"SongsLibrary/internal/httpclient"
+	httpclienthttp "SongsLibrary/internal/httpclient/delivery/http"
+	"SongsLibrary/internal/job"
+	jobhttp "SongsLibrary/internal/job/delivery/http"
+	jobpostgres "SongsLibrary/internal/job/repository/postgres"
+	jobusecase "SongsLibrary/internal/job/usecase"
+	"SongsLibrary/internal/job/worker"
+	"SongsLibrary/internal/logging"
+	logginggrpc "SongsLibrary/internal/logging/delivery/grpc"
+	logginghttp "SongsLibrary/internal/logging/delivery/http"
+	"SongsLibrary/internal/metrics"
+	metricshttp "SongsLibrary/internal/metrics/delivery/http"
+	"SongsLibrary/internal/migration"
+	"SongsLibrary/internal/outbox/publisher"
+	"SongsLibrary/internal/outbox/relay"
+	outboxpostgres "SongsLibrary/internal/outbox/repository/postgres"
+	"SongsLibrary/internal/providercache"
+	providercachehttp "SongsLibrary/internal/providercache/delivery/http"
+	providercachepostgres "SongsLibrary/internal/providercache/repository/postgres"
+	providercacheusecase "SongsLibrary/internal/providercache/usecase"
+	"SongsLibrary/internal/ratelimit"
+	ratelimithttp "SongsLibrary/internal/ratelimit/delivery/http"
 	"SongsLibrary/internal/song"
 	songhttp "SongsLibrary/internal/song/delivery/http"
+	songdtos "SongsLibrary/internal/song/dtos"
+	"SongsLibrary/internal/song/provider"
 	songpostgres "SongsLibrary/internal/song/repository/postgres"
 	songusecase "SongsLibrary/internal/song/usecase"
+	"SongsLibrary/internal/tracing"
 	"SongsLibrary/internal/validators"
 	logrusCustom "SongsLibrary/pkg/logger"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -17,140 +59,513 @@ import (
 	"github.com/sirupsen/logrus"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
+	"io"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
 	"time"
 )
 
 type App struct {
+	config     *config.Config
 	httpServer *http.Server
+	gRPCServer *grpc.Server
+	gRPCClient *grpc.ClientConn
+	db         *gorm.DB
 	songUC     song.UseCase
+	authorUC   author.UseCase
+	jobUC      job.UseCase
+	jobPool    *worker.Pool
+	relay      *relay.Relay
+	apiKeyUC   apikey.UseCase
+	cacheUC    providercache.UseCase
+	breakers   []*httpclient.Breaker
+	health     *health.Health
+	gRPCHealth *healthgrpc.Server
+	metrics    *metrics.Metrics
+	log        *logrus.Logger
+
+	shutdownTracing  func(context.Context) error
+	authn            auth.Authenticator
+	ipLimiter        *ratelimit.Limiter
+	principalLimiter *ratelimit.Limiter
 }
 
-func NewApp() (*App, error) {
+// NewApp wires the dependencies shared by the HTTP and gRPC servers: a single
+// DB pool, the SongData client connection, one use case per domain and the
+// worker pool executing queued jobs and the relay publishing outbox events.
+// Managed API keys are authenticated ahead of the static ones and carry the
+// quotas enforced by both servers. Everything logs to log: requests through
+// their context, background work through the context it is started with.
+func NewApp(cfg *config.Config, log *logrus.Logger) (*App, error) {
+
+	ctx := logrusCustom.NewContext(context.Background(), logrus.NewEntry(log))
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered NewApp function"))
+
+	shutdownTracing, err := tracing.Init(ctx, tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.OTLPEndpoint,
+		Insecure:    cfg.Tracing.OTLPInsecure,
+		File:        cfg.Tracing.File,
+		SampleRatio: cfg.Tracing.SampleRatio,
+		ServiceName: cfg.Tracing.ServiceName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tracing: %w", err)
+	}
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered NewApp function"))
+	appMetrics := metrics.New()
 
-	db := initDB()
+	db, migrationUC, err := initDB(ctx, cfg.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Use(metrics.NewGormPlugin(appMetrics)); err != nil {
+		return nil, err
+	}
+
+	if err := db.Use(tracing.NewGormPlugin()); err != nil {
+		return nil, err
+	}
 
 	songRepo := songpostgres.NewSongRepository(db)
-	musixMatchUseCase := songusecase.CreateMusixMatchUseCase(
-		os.Getenv("MMLAPI_BASE_URL"),
-		os.Getenv("MMLAPI_GET_SONG_IP_PATH"),
-		os.Getenv("MMLAPI_GET_LYRICS_PATH"),
-		os.Getenv("MMLAPI_API_KEY"),
-
-		os.Getenv("GAPI_BASE_URL"),
-		os.Getenv("GAPI_GET_SONG_RELEASE_DATE"),
-		os.Getenv("GAPI_AUTHORIZATION"),
-		&http.Client{},
-	)
+	authorRepo := authorpostgres.NewAuthorRepository(db)
+	jobRepo := jobpostgres.NewJobRepository(db)
+
+	apiKeyUC := apikeyusecase.NewAPIKeyUseCase(apikeypostgres.NewAPIKeyRepository(db))
+
+	responseRepo := providercachepostgres.NewProviderResponseRepository(db)
 
-	//conn, err := grpc.NewClient("localhost:3025", grpc.WithTransportCredentials(insecure.NewCredentials()))
-	conn, err := grpc.Dial("127.0.0.1:3024", grpc.WithInsecure(), grpc.WithBlock())
+	metadataProvider, conn, breakers, err := initMetadataProvider(ctx, cfg.Providers, responseRepo, appMetrics)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
+		return nil, err
+	}
+
+	songUC := songusecase.NewInstrumentedSongUseCase(initSongUseCase(ctx, songusecase.NewSongUseCase(songRepo, metadataProvider), cfg.SongCache), appMetrics)
+
+	jobPool := worker.NewPool(jobRepo, songUC, cfg.Jobs.Workers)
+
+	eventPublisher, err := publisher.New(cfg.Events.Publisher, cfg.Events.KafkaBrokers, cfg.Events.KafkaTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	authn, err := authenticator.New(authenticator.Config{
+		Disabled:    cfg.Auth.Disabled,
+		Issued:      apiKeyUC,
+		APIKeys:     cfg.Auth.APIKeys,
+		JWKSFile:    cfg.Auth.JWKSFile,
+		JWTIssuer:   cfg.Auth.JWTIssuer,
+		JWTAudience: cfg.Auth.JWTAudience,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("authentication: %w", err)
+	}
+	if _, disabled := authn.(authenticator.Disabled); disabled {
+		logrusCustom.Log(ctx, logrus.WarnLevel, "Authentication is disabled, every request is served as admin")
+	}
+
+	ipLimiter, err := ratelimit.New(ratelimit.Config{
+		Default: cfg.RateLimit.IPDefault,
+		Routes:  cfg.RateLimit.IPRoutes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rate limiting: %w", err)
+	}
+
+	principalLimiter, err := ratelimit.New(ratelimit.Config{
+		Default: cfg.RateLimit.Default,
+		Routes:  cfg.RateLimit.Routes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rate limiting: %w", err)
+	}
+
+	checks := health.New()
+	checks.Add("postgres", health.Postgres(db))
+	checks.Add("migrations", migrationUC.Check)
+	if conn != nil {
+		checks.Add(provider.SongDataName, health.GRPCConn(conn))
 	}
 
 	return &App{
-		songUC: songusecase.NewSongUseCase(songRepo, musixMatchUseCase, conn),
+		config:     cfg,
+		gRPCClient: conn,
+		db:         db,
+		songUC:     songUC,
+		authorUC:   authorusecase.NewAuthorUseCase(authorRepo),
+		jobUC:      jobusecase.NewJobUseCase(jobRepo, jobPool),
+		jobPool:    jobPool,
+		relay:      relay.NewRelay(outboxpostgres.NewOutboxRepository(db), eventPublisher, 0),
+		apiKeyUC:   apiKeyUC,
+		cacheUC:    providercacheusecase.NewProviderCacheUseCase(responseRepo),
+		breakers:   breakers,
+		health:     checks,
+		metrics:    appMetrics,
+		log:        log,
+
+		shutdownTracing:  shutdownTracing,
+		authn:            authn,
+		ipLimiter:        ipLimiter,
+		principalLimiter: principalLimiter,
 	}, nil
 }
 
-func (a *App) Run(port string) error {
+// Run serves REST and gRPC on the configured ports until SIGINT or SIGTERM is
+// received or one of the servers fails, then shuts both down together.
+func (a *App) Run() error {
+
+	ctx := logrusCustom.NewContext(context.Background(), logrus.NewEntry(a.log))
 
-	router := gin.Default()
+	httpPort, grpcPort := a.config.App.HTTPPort, a.config.App.GRPCPort
 
 	validate := validator.New()
 	err := validate.RegisterValidation("DateValidation", validators.DateValidation)
 	if err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
 
 		return err
 	}
 
-	songhttp.RegisterHTTPEndpoints(router, a.songUC, validate)
+	router := gin.New()
+	if err := router.SetTrustedProxies(a.config.App.TrustedProxies); err != nil {
+		return fmt.Errorf("trusted proxies: %w", err)
+	}
+	router.Use(gin.Recovery())
+	router.Use(otelgin.Middleware(a.config.Tracing.ServiceName, otelgin.WithFilter(traced)))
+	router.Use(metricshttp.NewMiddleware(a.metrics).Observe())
+	router.Use(logginghttp.NewMiddleware(a.log, "/healthz", "/readyz", "/metrics").Log())
+
+	// Probes and metrics are registered ahead of the rate limiter so that they
+	// are never throttled.
+	healthhttp.RegisterHTTPEndpoints(router, a.health)
+	metricshttp.RegisterHTTPEndpoints(router, a.metrics)
 
-	router.GET(os.Getenv("SWAGGER_PATH"), ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// Requests are limited per client IP before authentication and per
+	// principal by the authentication middleware.
+	rateLimiter := ratelimithttp.NewMiddleware(a.ipLimiter, a.principalLimiter)
+	router.Use(rateLimiter.Limit())
+
+	authMiddleware := authhttp.NewMiddleware(a.authn, a.apiKeyUC, rateLimiter)
+
+	songhttp.RegisterHTTPEndpoints(router, a.songUC, a.jobUC, validate, authMiddleware)
+	authorhttp.RegisterHTTPEndpoints(router, a.authorUC, validate, authMiddleware)
+	jobhttp.RegisterHTTPEndpoints(router, a.jobUC, authMiddleware)
+	apikeyhttp.RegisterHTTPEndpoints(router, a.apiKeyUC, authMiddleware)
+	providercachehttp.RegisterHTTPEndpoints(router, a.cacheUC, authMiddleware)
+	httpclienthttp.RegisterHTTPEndpoints(router, a.breakers, authMiddleware)
+	logginghttp.RegisterHTTPEndpoints(router, a.log, authMiddleware)
+
+	router.GET(a.config.App.SwaggerPath, ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	a.httpServer = &http.Server{
-		Addr:    ":" + port,
+		Addr:    ":" + httpPort,
 		Handler: router,
 	}
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Starting server on port %s", port))
+	a.gRPCServer, a.gRPCHealth = newGRPCServer(validate, a.ipLimiter, a.principalLimiter, a.authn, a.apiKeyUC, a.health, a.metrics, a.log, a.songUC, a.authorUC)
+
+	listen, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		return err
+	}
+
+	a.jobPool.Start(ctx)
+
+	a.relay.Start(ctx)
+
+	a.gRPCHealth.Start(ctx)
+
+	serveErr := make(chan error, 2)
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Starting server on port %s", httpPort))
 
 	go func() {
-		if err := a.httpServer.ListenAndServe(); err != nil {
-			logrusCustom.Logger.Fatalf("Failed to listen and serve: %+v", err)
+		if err := a.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("http server: %w", err)
+		}
+	}()
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Starting gRPC server on port %s", grpcPort))
+
+	go func() {
+		if err := a.gRPCServer.Serve(listen); err != nil {
+			serveErr <- fmt.Errorf("gRPC server: %w", err)
 		}
 	}()
 
 	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt, os.Interrupt)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	var runErr error
+	select {
+	case sig := <-quit:
+		logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Received signal %s", sig))
+	case runErr = <-serveErr:
+		logrusCustom.Log(ctx, logrus.ErrorLevel, fmt.Sprintf("Failed to serve: %v", runErr))
+	}
+
+	return errors.Join(runErr, a.shutdown(ctx))
+}
+
+func (a *App) shutdown(ctx context.Context) error {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, "Gracefully shutting down servers...")
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var errs []error
+
+	a.gRPCHealth.Shutdown()
+
+	if err := a.httpServer.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("http server shutdown: %w", err))
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		a.gRPCServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		logrusCustom.Log(ctx, logrus.ErrorLevel, "gRPC graceful stop timed out, forcing stop")
+		a.gRPCServer.Stop()
+	}
+
+	if err := a.jobPool.Stop(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := a.relay.Stop(ctx); err != nil {
+		errs = append(errs, err)
+	}
 
-	<-quit
-	logrusCustom.LogWithLocation(logrus.InfoLevel, "Gracefully shutting down server...")
+	if a.gRPCClient != nil {
+		if err := a.gRPCClient.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("gRPC client close: %w", err))
+		} else {
+			logrusCustom.Log(ctx, logrus.InfoLevel, "gRPC connection closed successfully")
+		}
+	}
 
-	ctx, shutdown := context.WithTimeout(context.Background(), 5*time.Second)
-	defer shutdown()
+	if sqlDB, err := a.db.DB(); err == nil {
+		if err := sqlDB.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("db close: %w", err))
+		}
+	}
 
-	return a.httpServer.Shutdown(ctx)
+	if err := a.shutdownTracing(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("tracing shutdown: %w", err))
+	}
+
+	return errors.Join(errs...)
 }
 
-func initDB() *gorm.DB {
+// initSongUseCase puts the read-through cache in front of songUC unless its
+// size is 0. The same instance serves HTTP, gRPC and the job workers, so songs
+// created by any of them invalidate the cache.
+func initSongUseCase(ctx context.Context, songUC song.UseCase, cfg config.SongCache) song.UseCase {
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, "Entered initDB function")
+	if cfg.Size == 0 {
+		logrusCustom.Log(ctx, logrus.InfoLevel, "Song cache is disabled")
 
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_PORT"),
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_NAME"),
-		os.Getenv("DB_SLLMODE"),
-	)
+		return songUC
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Caching up to %d song pages and lyrics for %s", cfg.Size, cfg.TTL))
+
+	return songusecase.NewCachedSongUseCase(songUC, cache.NewLRU[*songdtos.SongsPage](cfg.Size, cfg.TTL), cache.NewLRU[[]string](cfg.Size, cfg.TTL))
+}
+
+// initMetadataProvider builds the provider registry in the configured order.
+// The SongData service is only dialled when it is part of the order, and the
+// local provider is only available when a local file is configured, so a
+// "local" order needs no network at all. Answers of the remote providers are
+// cached in responses unless the cache TTL is 0. Musixmatch and Genius are
+// called through clients with retries and a circuit breaker each; the breakers
+// are returned for the admin API. Calls leaving the service are recorded in
+// appMetrics.
+func initMetadataProvider(ctx context.Context, cfg config.Providers, responses providercache.Repository, appMetrics *metrics.Metrics) (*provider.Registry, *grpc.ClientConn, []*httpclient.Breaker, error) {
+
+	order := strings.Join(cfg.Order, ",")
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Using metadata providers: %s", order))
+
+	if cfg.CacheTTL > 0 {
+		logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Caching provider responses for %s and not found answers for %s", cfg.CacheTTL, cfg.CacheNegativeTTL))
+	}
+
+	musixmatchClient, musixmatchBreaker := httpclient.NewClient(provider.MusixmatchName, cfg.Policy(cfg.Musixmatch.Timeout))
+	geniusClient, geniusBreaker := httpclient.NewClient(provider.GeniusName, cfg.Policy(cfg.Genius.Timeout))
+
+	available := []song.MetadataProvider{
+		provider.NewMusixmatchProvider(
+			cfg.Musixmatch.BaseURL,
+			cfg.Musixmatch.SongPath,
+			cfg.Musixmatch.LyricsPath,
+			cfg.Musixmatch.APIKey,
+			musixmatchClient,
+		),
+		provider.NewGeniusProvider(
+			cfg.Genius.BaseURL,
+			cfg.Genius.SearchPath,
+			cfg.Genius.Authorization,
+			geniusClient,
+		),
+	}
+
+	if cfg.LocalFile != "" {
+		localProvider, err := provider.NewLocalProvider(cfg.LocalFile)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		available = append(available, localProvider)
+	}
+
+	var conn *grpc.ClientConn
+	if slices.Contains(cfg.Order, provider.SongDataName) {
+		var err error
+		conn, err = grpc.NewClient(cfg.SongDataAddr,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+			grpc.WithUnaryInterceptor(logginggrpc.UnaryClient()),
+		)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to create SongData client: %w", err)
+		}
+
+		// Connecting in the background keeps startup independent of SongData;
+		// readiness reports the connection state until it is up.
+		conn.Connect()
+
+		available = append(available, provider.NewSongDataProvider(conn))
+	}
+
+	for i, metadataProvider := range available {
+		if metadataProvider.Name() == provider.LocalName {
+			continue
+		}
+
+		available[i] = provider.NewInstrumentedProvider(metadataProvider, appMetrics)
+		if cfg.CacheTTL > 0 {
+			available[i] = provider.NewCachedProvider(available[i], responses, cfg.CacheTTL, cfg.CacheNegativeTTL)
+		}
+	}
+
+	registry, err := provider.NewRegistryFromOrder(order, available...)
+	if err != nil {
+		if conn != nil {
+			conn.Close()
+		}
+		return nil, nil, nil, err
+	}
+
+	return registry, conn, []*httpclient.Breaker{musixmatchBreaker, geniusBreaker}, nil
+}
+
+// traced leaves probes and scrapes out of the traces.
+func traced(req *http.Request) bool {
+	switch req.URL.Path {
+	case "/healthz", "/readyz", "/metrics":
+		return false
+	default:
+		return true
+	}
+}
+
+// initDB refuses to return a database whose schema is behind the migrations
+// of the binary, which the "migrate" subcommand applies, and seeds an empty
+// database. The migration use case is returned for the readiness check.
+func initDB(ctx context.Context, cfg config.DB) (*gorm.DB, migration.UseCase, error) {
 
-	logrusCustom.LogWithLocation(logrus.DebugLevel, fmt.Sprintf("Loaded dsn: %s", dsn))
+	logrusCustom.Log(ctx, logrus.InfoLevel, "Entered initDB function")
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, "Connecting to db")
+	db, err := openDB(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	migrationUC, err := initMigrationUseCase(db)
+	if err != nil {
+		closeDB(db)
+
+		return nil, nil, err
+	}
+
+	if err := migrationUC.Check(ctx); err != nil {
+		closeDB(db)
+
+		return nil, nil, fmt.Errorf("%w, run the migrate up subcommand first", err)
+	}
+
+	initData(ctx, db)
+
+	return db, migrationUC, nil
+}
+
+func openDB(ctx context.Context, cfg config.DB) (*gorm.DB, error) {
+
+	dsn := postgresDSN(cfg)
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Connecting to db %s on %s:%s", cfg.Name, cfg.Host, cfg.Port))
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		NamingStrategy: schema.NamingStrategy{
 			SingularTable: true,
 		},
+		Logger: logging.NewGormLogger(logrusCustom.FromContext(ctx).Logger),
 	})
 	if err != nil {
-		logrusCustom.Logger.Fatalf("Failed to connect database")
+		return nil, fmt.Errorf("failed to connect database: %w", err)
 	}
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, "Successfully connected to db")
+	logrusCustom.Log(ctx, logrus.InfoLevel, "Successfully connected to db")
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, "Starting migrating db")
+	return db, nil
+}
 
-	err = db.AutoMigrate(&models.Song{})
-	if err != nil {
-		logrusCustom.Logger.Fatalf("Failed migrate db")
+func closeDB(db *gorm.DB) {
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
 	}
+}
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, "Successfully migrated to db")
-
-	initData(db)
-
-	return db
+func postgresDSN(cfg config.DB) string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Host,
+		cfg.Port,
+		cfg.User,
+		cfg.Password,
+		cfg.Name,
+		cfg.SSLMode,
+	)
 }
 
-func initData(db *gorm.DB) {
+func initData(ctx context.Context, db *gorm.DB) {
 	var count int64
 	db.Model(&models.Author{}).Count(&count)
 	if count == 0 {
-		logrusCustom.LogWithLocation(logrus.InfoLevel, "Adding initial data to the database")
+		logrusCustom.Log(ctx, logrus.InfoLevel, "Adding initial data to the database")
 
 		authors := []models.Author{
 			{
@@ -175,6 +590,7 @@ func initData(db *gorm.DB) {
 				ReleaseDate: releaseDateCastedFirstSong,
 				Text:        "some folks are born made to wave the flag\\nthey're red, white and blue\\nand when the band plays \\\"hail to the chief\\\"\\nthey point the cannon at you, lord\\n\\nit ain't me, it ain't me\\ni ain't no senator's son, son\\nit ain't me, it ain't me\\ni ain't no fortunate one\\n\\nsome folks are born, silver spoon in hand",
 				Link:        "https://www.musixmatch.com/lyrics/Creedence-Clearwater-Revival/Fortunate-Son?utm_source=application&utm_campaign=api&utm_medium=DanKoKode%3A1409625027081",
+				Language:    "english",
 			},
 			{
 				ID:          uuid.New(),
@@ -183,6 +599,7 @@ func initData(db *gorm.DB) {
 				ReleaseDate: releaseDateCastedThirdSong,
 				Text:        "что ж ты, фраер, сдал назад\\nне по масти я тебе\\nты смотри в мои глаза\\nбрось трепаться о судьбе\\n\\nведь с тобой мой мусорок\\nя попутала рамсы\\nзавязала узелок\\nкак тугие две косы\\n\\nпомню как ты подошел\\nкак поскрипывал паркет\\nкак поставил на мой стол\\nчайных роз большой букет\\n\\nя решила ты - скокарь\\nили вор-авторитет\\nоказалось просто тварь",
 				Link:        "https://www.musixmatch.com/lyrics/%D0%9C%D0%B8%D1%85%D0%B0%D0%B8%D0%BB-%D0%9A%D1%80%D1%83%D0%B3/%D0%A4%D1%80%D0%B0%D0%B5%D1%80?utm_source=application&utm_campaign=api&utm_medium=DanKoKode%3A1409625027081",
+				Language:    "russian",
 			},
 			{
 				ID:          uuid.New(),
@@ -191,14 +608,34 @@ func initData(db *gorm.DB) {
 				ReleaseDate: releaseDateCastedSecondSong,
 				Text:        "в тебе было столько желанья\\nи месяц над нами светил\\nкогда по маляве, придя на свиданье\\nя розы тебе подарил\\n\\nкакой ты казалась серьёзной\\nкачала в ответ головой\\nкогда я сказал, что отнял эти розы\\nв киоске на первой ямской\\n\\nкак было тепло, что нас с тобой вместе свело\\nдевочка-пай, рядом жиган и хулиган\\nв нашей твери нету таких даже среди шкур центровых\\nдевочка-пай, ты не грусти и не скучай\\n\\nпонты просадил я чуть позже\\nв делах узелки затянул",
 				Link:        "https://www.musixmatch.com/lyrics/%D0%9C%D0%B8%D1%85%D0%B0%D0%B8%D0%BB-%D0%9A%D1%80%D1%83%D0%B3/%D0%94%D0%B5%D0%B2%D0%BE%D1%87%D0%BA%D0%B0-%D0%9F%D0%B0%D0%B9?utm_source=application&utm_campaign=api&utm_medium=DanKoKode%3A1409625027081",
+				Language:    "russian",
 			},
 		}
 
 		db.Create(&authors)
 		db.Create(&songs)
 
-		logrusCustom.LogWithLocation(logrus.InfoLevel, "Initial data added successfully")
+		logrusCustom.Log(ctx, logrus.InfoLevel, "Initial data added successfully")
 	} else {
-		logrusCustom.LogWithLocation(logrus.InfoLevel, "Data already exists in the database, skipping initialization")
+		logrusCustom.Log(ctx, logrus.InfoLevel, "Data already exists in the database, skipping initialization")
 	}
 }
+
+// NewLogger returns the logger of cfg and the closer of its output. The
+// secrets of cfg never reach the output.
+func NewLogger(cfg *config.Config) (*logrus.Logger, io.Closer, error) {
+	return logrusCustom.New(logrusCustom.Config{
+		Level:      cfg.Logging.Level,
+		Format:     cfg.Logging.Format,
+		Output:     cfg.Logging.Output,
+		File:       cfg.Logging.File,
+		MaxSizeMB:  cfg.Logging.MaxSizeMB,
+		MaxBackups: cfg.Logging.MaxBackups,
+		MaxAgeDays: cfg.Logging.MaxAgeDays,
+		Redaction: logrusCustom.Redaction{
+			Secrets:     cfg.Secrets(),
+			QueryParams: cfg.Logging.RedactQueryParams,
+			Headers:     cfg.Logging.RedactHeaders,
+		},
+	})
+}
@@ -1,101 +1,58 @@
 package server
 
 import (
+	"SongsLibrary/internal/auth"
+	authgrpc "SongsLibrary/internal/auth/delivery/grpc"
+	"SongsLibrary/internal/author"
+	"SongsLibrary/internal/author/delivery/grpc/authorGRPC"
+	"SongsLibrary/internal/health"
+	healthgrpc "SongsLibrary/internal/health/delivery/grpc"
+	logginggrpc "SongsLibrary/internal/logging/delivery/grpc"
+	"SongsLibrary/internal/metrics"
+	metricsgrpc "SongsLibrary/internal/metrics/delivery/grpc"
+	"SongsLibrary/internal/ratelimit"
+	ratelimitgrpc "SongsLibrary/internal/ratelimit/delivery/grpc"
 	"SongsLibrary/internal/song"
 	"SongsLibrary/internal/song/delivery/grpc/songGRPC"
-	songpostgres "SongsLibrary/internal/song/repository/postgres"
-	songusecase "SongsLibrary/internal/song/usecase"
-	"SongsLibrary/internal/validators"
-	logrusCustom "SongsLibrary/pkg/logger"
-	"fmt"
+	songv1 "github.com/DanKo-code/Protobuf-For-Songs-Library-Upgraded/protos/gen/go/song"
 	"github.com/go-playground/validator/v10"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"net"
-	"net/http"
-	"os"
-	"os/signal"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"maps"
 )
 
-type AppGRPC struct {
-	gRPCServer *grpc.Server
-	gRPCClient *grpc.ClientConn
-	songUC     song.UseCase
-}
+// newGRPCServer traces, records and logs every call, then limits calls per
+// peer before authenticating them, so that rejected clients never reach the
+// authenticators, and per principal once they are authenticated. The health
+// service reports the readiness of the Song and Author services, needs no
+// credentials, is neither limited nor traced and its successful checks are
+// only logged at debug level.
+func newGRPCServer(validate *validator.Validate, ipLimiter, principalLimiter *ratelimit.Limiter, authenticator auth.Authenticator, quotas auth.QuotaEnforcer, checks *health.Health, appMetrics *metrics.Metrics, log *logrus.Logger, songUC song.UseCase, authorUC author.UseCase) (*grpc.Server, *healthgrpc.Server) {
+	methodRoles := maps.Clone(songGRPC.MethodRoles)
+	maps.Copy(methodRoles, authorGRPC.MethodRoles)
+	maps.Copy(methodRoles, healthgrpc.MethodRoles)
 
-func NewAppGRPC() (*AppGRPC, error) {
+	rateLimiter := ratelimitgrpc.NewInterceptor(ipLimiter, principalLimiter, healthv1.Health_Check_FullMethodName, healthv1.Health_Watch_FullMethodName)
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered NewApp function"))
+	interceptor := authgrpc.NewInterceptor(authenticator, quotas, rateLimiter, methodRoles)
 
-	db := initDB()
+	observer := metricsgrpc.NewInterceptor(appMetrics)
 
-	songRepo := songpostgres.NewSongRepository(db)
-	musixMatchUseCase := songusecase.CreateMusixMatchUseCase(
-		os.Getenv("MMLAPI_BASE_URL"),
-		os.Getenv("MMLAPI_GET_SONG_IP_PATH"),
-		os.Getenv("MMLAPI_GET_LYRICS_PATH"),
-		os.Getenv("MMLAPI_API_KEY"),
+	logger := logginggrpc.NewInterceptor(log, healthv1.Health_Check_FullMethodName, healthv1.Health_Watch_FullMethodName)
 
-		os.Getenv("GAPI_BASE_URL"),
-		os.Getenv("GAPI_GET_SONG_RELEASE_DATE"),
-		os.Getenv("GAPI_AUTHORIZATION"),
-		&http.Client{},
+	gRPCServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+		grpc.ChainUnaryInterceptor(observer.Unary(), logger.Unary(), rateLimiter.Unary(), interceptor.Unary()),
+		grpc.ChainStreamInterceptor(observer.Stream(), logger.Stream(), rateLimiter.Stream(), interceptor.Stream()),
 	)
 
-	//gRPCClient
-	conn, err := grpc.NewClient("localhost:3025", grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
-	}
-
-	return &AppGRPC{
-		songUC: songusecase.NewSongUseCase(songRepo, musixMatchUseCase, conn),
-	}, nil
-}
-
-func (app *AppGRPC) Run(port string) error {
-
-	validate := validator.New()
-	err := validate.RegisterValidation("DateValidation", validators.DateValidation)
-	if err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
-
-		return err
-	}
-
-	app.gRPCServer = grpc.NewServer()
-
-	songGRPC.Register(app.gRPCServer, validate, app.songUC)
-
-	listen, err := net.Listen("tcp", ":"+port)
-	if err != nil {
-		return err
-	}
-
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Starting gRPC server on port %s", port))
-
-	go func() {
-		if err := app.gRPCServer.Serve(listen); err != nil {
-			logrusCustom.Logger.Fatalf("Failed to serve: %+v", err)
-		}
-	}()
-
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt, os.Interrupt)
-
-	<-quit
-
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("stopping gRPC server %s", port))
-	app.gRPCServer.GracefulStop()
+	songGRPC.Register(gRPCServer, validate, songUC)
+	authorGRPC.Register(gRPCServer, validate, authorUC)
 
-	if app.gRPCClient != nil {
-		if err := app.gRPCClient.Close(); err != nil {
-			logrusCustom.LogWithLocation(logrus.ErrorLevel, fmt.Sprintf("Failed to close gRPC connection: %v", err))
-		} else {
-			logrusCustom.LogWithLocation(logrus.InfoLevel, "gRPC connection closed successfully")
-		}
-	}
+	healthServer := healthgrpc.Register(gRPCServer, checks, songv1.Song_ServiceDesc.ServiceName, songv1.Author_ServiceDesc.ServiceName)
 
-	return nil
+	return gRPCServer, healthServer
 }
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"SongsLibrary/internal/config"
+	"SongsLibrary/internal/song/importer"
+	songpostgres "SongsLibrary/internal/song/repository/postgres"
+	songusecase "SongsLibrary/internal/song/usecase"
+	"SongsLibrary/internal/validators"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"io"
+	"os"
+)
+
+// RunImport implements the "import" subcommand: it reads a CSV or NDJSON file,
+// or standard input when the file is "-", stores the songs and writes the
+// per-row report as JSON to out. The configuration flags are accepted next to
+// its own ones.
+func RunImport(args []string, out io.Writer) error {
+
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	filePath := flags.String("file", "-", "CSV or NDJSON file to import, - for standard input")
+	format := flags.String("format", "", "file format: csv or ndjson (detected from the file extension when omitted)")
+
+	cfg, err := config.Load(flags, args)
+	if err != nil {
+		return err
+	}
+
+	// Standard output carries the report, so logs meant for it go to standard
+	// error instead.
+	if cfg.Logging.Output == logrusCustom.OutputStdout {
+		cfg.Logging.Output = logrusCustom.OutputStderr
+	}
+
+	log, logCloser, err := NewLogger(cfg)
+	if err != nil {
+		return err
+	}
+	defer logCloser.Close()
+
+	ctx := logrusCustom.NewContext(context.Background(), logrus.NewEntry(log))
+
+	detectedFormat, err := importer.DetectFormat(*format, *filePath, "")
+	if err != nil {
+		return err
+	}
+
+	var input io.Reader = os.Stdin
+	if *filePath != "-" {
+		file, err := os.Open(*filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		input = file
+	}
+
+	validate := validator.New()
+	if err := validate.RegisterValidation("DateValidation", validators.DateValidation); err != nil {
+		return err
+	}
+
+	rows, err := importer.Parse(input, detectedFormat, validate)
+	if err != nil {
+		return err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Importing %d rows from %s", len(rows), *filePath))
+
+	db, _, err := initDB(ctx, cfg.DB)
+	if err != nil {
+		return err
+	}
+	defer closeDB(db)
+
+	songUC := songusecase.NewSongUseCase(songpostgres.NewSongRepository(db), nil)
+
+	report, err := songUC.ImportSongs(ctx, rows)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+
+	// Duplicates are expected when a seed file is imported again, invalid rows
+	// are not.
+	if report.Invalid > 0 {
+		return fmt.Errorf("import finished with %d created, %d duplicate and %d invalid rows", report.Created, report.Duplicates, report.Invalid)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"SongsLibrary/internal/config"
+	"SongsLibrary/internal/httpclient"
+	"SongsLibrary/internal/song/provider"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestNewLogger_RedactsSecrets drives the code paths that used to log
+// credentials, with every log level enabled, and checks that none of the
+// configured secrets reaches the log file.
+func TestNewLogger_RedactsSecrets(t *testing.T) {
+	for _, format := range []string{logrusCustom.FormatJSON, logrusCustom.FormatText} {
+		t.Run(format, func(t *testing.T) {
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// Providers that echo credentials must not leak them either.
+				http.Error(w, fmt.Sprintf("invalid credentials %s %s", r.URL.Query().Get("apikey"), r.Header.Get("Authorization")), http.StatusUnauthorized)
+			}))
+			defer api.Close()
+
+			cfg := config.Default()
+			cfg.DB.Host, cfg.DB.User, cfg.DB.Name = "localhost", "songs", "songs"
+			cfg.DB.Password = "db-p@ss word"
+			cfg.Auth.APIKeys = "static-admin-key:admin"
+			cfg.Providers.Musixmatch.BaseURL = api.URL + "/"
+			cfg.Providers.Musixmatch.APIKey = "musixmatch-key"
+			cfg.Providers.Genius.BaseURL = api.URL + "/"
+			cfg.Providers.Genius.SearchPath = "search?q=%s+%s"
+			cfg.Providers.Genius.Authorization = "Bearer genius-token"
+			cfg.Logging.Level = "trace"
+			cfg.Logging.Format = format
+			cfg.Logging.Output = logrusCustom.OutputFile
+			cfg.Logging.File = filepath.Join(t.TempDir(), "app.log")
+
+			log, closer, err := NewLogger(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := logrusCustom.NewContext(context.Background(), logrus.NewEntry(log))
+
+			logrusCustom.Log(ctx, logrus.DebugLevel, fmt.Sprintf("Loaded dsn: %s", postgresDSN(cfg.DB)))
+
+			client, _ := httpclient.NewClient(provider.MusixmatchName, httpclient.Policy{Timeout: cfg.Providers.Timeout, MaxRetries: 1})
+			musixmatch := provider.NewMusixmatchProvider(cfg.Providers.Musixmatch.BaseURL, cfg.Providers.Musixmatch.SongPath, cfg.Providers.Musixmatch.LyricsPath, cfg.Providers.Musixmatch.APIKey, client)
+			genius := provider.NewGeniusProvider(cfg.Providers.Genius.BaseURL, cfg.Providers.Genius.SearchPath, cfg.Providers.Genius.Authorization, client)
+
+			_, err = musixmatch.GetSongMetadata(ctx, "muse", "hysteria")
+			assert.Error(t, err)
+			_, err = musixmatch.GetLyrics(ctx, "42")
+			assert.Error(t, err)
+			_, err = genius.GetSongMetadata(ctx, "muse", "hysteria")
+			assert.Error(t, err)
+
+			req, _ := http.NewRequest(http.MethodGet, api.URL, nil)
+			req.Header.Set("Authorization", cfg.Providers.Genius.Authorization)
+			req.Header.Set("X-API-Key", "static-admin-key")
+			logrus.NewEntry(log).WithField("request", fmt.Sprintf("%+v", req)).Debug("Dumped request")
+
+			assert.NoError(t, closer.Close())
+
+			content, err := os.ReadFile(cfg.Logging.File)
+			if err != nil {
+				t.Fatal(err)
+			}
+			logs := string(content)
+
+			assert.Contains(t, logs, logrusCustom.Redacted)
+			for _, secret := range cfg.Secrets() {
+				assert.NotContains(t, logs, secret)
+			}
+			for _, fragment := range []string{"p@ss", "musixmatch-key", "genius-token", "static-admin-key"} {
+				assert.NotContains(t, logs, fragment)
+			}
+			assert.Contains(t, logs, "Authorization:["+logrusCustom.Redacted+"]", "masked values keep the layout of the entry")
+		})
+	}
+}
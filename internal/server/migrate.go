@@ -0,0 +1,117 @@
+package server
+
+import (
+	"SongsLibrary/internal/config"
+	"SongsLibrary/internal/db/migrations"
+	"SongsLibrary/internal/migration"
+	"SongsLibrary/internal/migration/constants"
+	migrationpostgres "SongsLibrary/internal/migration/repository/postgres"
+	migrationusecase "SongsLibrary/internal/migration/usecase"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"io"
+	"strconv"
+)
+
+// RunMigrate implements the "migrate" subcommand: "up" applies every pending
+// migration, "down" reverts the latest one, "to <version>" applies or reverts
+// migrations until the schema is at version, 0 reverting all of them, and
+// "status" only reports. The status of every migration is written as JSON to
+// out. The configuration flags are accepted after the command.
+func RunMigrate(args []string, out io.Writer) error {
+
+	if len(args) == 0 {
+		return migration.UnknownCommand
+	}
+
+	command, args := args[0], args[1:]
+
+	switch command {
+	case constants.CommandUp, constants.CommandDown, constants.CommandStatus, constants.CommandTo:
+	default:
+		return fmt.Errorf("%w: %s", migration.UnknownCommand, command)
+	}
+
+	var version int64
+	if command == constants.CommandTo {
+		if len(args) == 0 {
+			return migration.UnknownCommand
+		}
+
+		var err error
+		version, err = strconv.ParseInt(args[0], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("%w: %s", migration.UnknownVersion, args[0])
+		}
+
+		args = args[1:]
+	}
+
+	cfg, err := config.Load(flag.NewFlagSet("migrate", flag.ContinueOnError), args)
+	if err != nil {
+		return err
+	}
+
+	// Standard output carries the status, so logs meant for it go to standard
+	// error instead.
+	if cfg.Logging.Output == logrusCustom.OutputStdout {
+		cfg.Logging.Output = logrusCustom.OutputStderr
+	}
+
+	log, logCloser, err := NewLogger(cfg)
+	if err != nil {
+		return err
+	}
+	defer logCloser.Close()
+
+	ctx := logrusCustom.NewContext(context.Background(), logrus.NewEntry(log))
+
+	db, err := openDB(ctx, cfg.DB)
+	if err != nil {
+		return err
+	}
+	defer closeDB(db)
+
+	migrationUC, err := initMigrationUseCase(db)
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case constants.CommandUp:
+		err = migrationUC.Up(ctx)
+	case constants.CommandDown:
+		err = migrationUC.Down(ctx)
+	case constants.CommandTo:
+		err = migrationUC.To(ctx, version)
+	}
+	if err != nil {
+		return err
+	}
+
+	statuses, err := migrationUC.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(statuses)
+}
+
+// initMigrationUseCase migrates db with the migrations embedded in the binary.
+func initMigrationUseCase(db *gorm.DB) (*migrationusecase.MigrationUseCase, error) {
+
+	loaded, err := migration.Load(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	return migrationusecase.NewMigrationUseCase(migrationpostgres.NewMigrationRepository(db), loaded), nil
+}
@@ -1,12 +1,52 @@
 package constants
 
+import "time"
+
 const (
 	DefaultSongsPage     = 1
 	DefaultSongsPageSize = 3
+	DefaultSongsSort     = "name"
+	DefaultExportSort    = "group_name,name"
+
+	// MaxAuthorFacets caps the author facet to the authors with most songs.
+	MaxAuthorFacets = 50
 
 	DefaultLyricsPage     = 1
 	DefaultLyricsPageSize = 2
 
+	DefaultSearchPage     = 1
+	DefaultSearchPageSize = 10
+
+	TextSearchEnglish = "english"
+	TextSearchRussian = "russian"
+	TextSearchSimple  = "simple"
+
+	SearchHeadlineOptions = "StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=20, MinWords=5"
+
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+
+	ImportStatusCreated   = "created"
+	ImportStatusDuplicate = "duplicate"
+	ImportStatusInvalid   = "invalid"
+
+	ImportBatchSize   = 500
+	MaxImportFileSize = 32 << 20
+
+	ExportFormatCSV    = "csv"
+	ExportFormatJSON   = "json"
+	ExportFormatNDJSON = "ndjson"
+
+	ExportFlushInterval = 100
+
+	// DefaultCacheSize is the number of GetSongs pages and of songs' verses
+	// cached when SONG_CACHE_SIZE is not set.
+	DefaultCacheSize = 1000
+	DefaultCacheTTL  = 30 * time.Second
+
 	DbUniqueConstrintErr = "23505"
 	DateNilValue         = "0001-01-01 00:00:00 +0000 UTC"
 )
+
+// SongSortFields lists the fields GetSongs and ExportSongs can be sorted by.
+var SongSortFields = []string{"name", "release_date", "group_name"}
@@ -0,0 +1,18 @@
+package grpc
+
+import (
+	"SongsLibrary/internal/pagination"
+	songv1 "github.com/DanKo-code/Protobuf-For-Songs-Library-Upgraded/protos/gen/go/song"
+)
+
+// PaginationToProto converts the pagination envelope for the Song and Author
+// services, which share the message.
+func PaginationToProto(p pagination.Pagination) *songv1.Pagination {
+	return &songv1.Pagination{
+		Total:      p.Total,
+		Page:       int64(p.Page),
+		PageSize:   int64(p.PageSize),
+		TotalPages: int64(p.TotalPages),
+		NextCursor: p.NextCursor,
+	}
+}
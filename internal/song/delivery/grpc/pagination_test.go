@@ -0,0 +1,20 @@
+package grpc
+
+import (
+	"SongsLibrary/internal/pagination"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPaginationToProto(t *testing.T) {
+	p := pagination.New(21, 2, 10)
+	p.NextCursor = "next"
+
+	proto := PaginationToProto(p)
+
+	assert.Equal(t, int64(21), proto.GetTotal())
+	assert.Equal(t, int64(2), proto.GetPage())
+	assert.Equal(t, int64(10), proto.GetPageSize())
+	assert.Equal(t, int64(3), proto.GetTotalPages())
+	assert.Equal(t, "next", proto.GetNextCursor())
+}
@@ -1,13 +1,17 @@
 package songGRPC
 
 import (
+	"SongsLibrary/internal/auth/constants"
 	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/pagination"
 	"SongsLibrary/internal/song"
+	songgrpc "SongsLibrary/internal/song/delivery/grpc"
 	"SongsLibrary/internal/song/dtos"
 	logrusCustom "SongsLibrary/pkg/logger"
 	"context"
 	"fmt"
 	songv1 "github.com/DanKo-code/Protobuf-For-Songs-Library-Upgraded/protos/gen/go/song"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -24,6 +28,17 @@ type serverGRPC struct {
 	usecase  song.UseCase
 }
 
+// MethodRoles is the role each Song method requires.
+var MethodRoles = map[string]string{
+	songv1.Song_GetSongs_FullMethodName:      constants.RoleReader,
+	songv1.Song_GetSongLyrics_FullMethodName: constants.RoleReader,
+	songv1.Song_SearchSongs_FullMethodName:   constants.RoleReader,
+	songv1.Song_ExportSongs_FullMethodName:   constants.RoleReader,
+	songv1.Song_CreateSong_FullMethodName:    constants.RoleEditor,
+	songv1.Song_UpdateSong_FullMethodName:    constants.RoleEditor,
+	songv1.Song_DeleteSong_FullMethodName:    constants.RoleAdmin,
+}
+
 func Register(gRPC *grpc.Server, validator *validator.Validate, usecase song.UseCase) {
 	songv1.RegisterSongServer(gRPC, &serverGRPC{
 		validate: validator,
@@ -31,51 +46,95 @@ func Register(gRPC *grpc.Server, validator *validator.Validate, usecase song.Use
 	})
 }
 
+// validateDTO applies the same rules as the HTTP delivery: gin "binding" tags
+// first, then the custom "validate" tags registered on the shared validator.
+func (s *serverGRPC) validateDTO(dto interface{}) error {
+	if err := binding.Validator.ValidateStruct(dto); err != nil {
+		return err
+	}
+
+	return s.validate.Struct(dto)
+}
+
 func (s *serverGRPC) GetSongs(ctx context.Context, req *songv1.GetSongsRequest) (*songv1.GetSongsResponseList, error) {
 
 	var gsdto dtos.GetSongsDTO
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered GetSongs gRPC Hanlder with parameters: %+v", gsdto))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetSongs gRPC Hanlder with parameters: %+v", gsdto))
 
 	gsdto = dtos.GetSongsDTO{
-		Id:          req.GetId(),
-		Name:        req.GetName(),
-		GroupName:   req.GetGroupName(),
-		ReleaseDate: req.GetReleaseDate(),
-		Text:        req.GetText(),
-		Link:        req.GetLink(),
-		Page:        int(req.GetPage()),
-		PageSize:    int(req.GetPageSize()),
+		Id:              req.GetId(),
+		Name:            req.GetName(),
+		GroupName:       req.GetGroupName(),
+		ReleaseDate:     req.GetReleaseDate(),
+		ReleaseDateFrom: req.GetReleaseDateFrom(),
+		ReleaseDateTo:   req.GetReleaseDateTo(),
+		Year:            int(req.GetYear()),
+		Decade:          int(req.GetDecade()),
+		Text:            req.GetText(),
+		Link:            req.GetLink(),
+		Page:            int(req.GetPage()),
+		PageSize:        int(req.GetPageSize()),
+		Cursor:          req.GetCursor(),
+		Sort:            req.GetSort(),
+		Facets:          req.GetFacets(),
 	}
 
-	err := s.validate.Struct(gsdto)
+	err := s.validateDTO(gsdto)
 	if err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
 
-		return nil, status.Error(codes.InvalidArgument, "")
+		return nil, status.Error(codes.InvalidArgument, song.InvalidInputData.Error())
+	}
+
+	if !gsdto.ReleaseDatesValid() {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, song.InvalidReleaseDates.Error())
+
+		return nil, status.Error(codes.InvalidArgument, song.InvalidReleaseDates.Error())
+	}
+
+	if gsdto.Id != "" {
+		_, err = uuid.Parse(gsdto.Id)
+		if err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+			return nil, status.Error(codes.InvalidArgument, song.InvalidSongIdFormat.Error())
+		}
 	}
 
 	gsdto.SetDefaults()
-	logrusCustom.LogWithLocation(logrus.DebugLevel, fmt.Sprintf("Setted default parameters: %+v", gsdto))
+	logrusCustom.Log(ctx, logrus.DebugLevel, fmt.Sprintf("Setted default parameters: %+v", gsdto))
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	gsdto.Text = strings.ToLower(gsdto.Text)
 
-	songs, err := s.usecase.GetSongs(ctx, &gsdto)
+	page, err := s.usecase.GetSongs(ctx, &gsdto)
 	if err != nil {
 
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
 
 		if err.Error() == song.SongsNotFound.Error() {
 			return nil, status.Error(codes.NotFound, "")
 		}
 
+		if err.Error() == song.InvalidCursor.Error() {
+			return nil, status.Error(codes.InvalidArgument, song.InvalidCursor.Error())
+		}
+
+		if err.Error() == song.InvalidSort.Error() {
+			return nil, status.Error(codes.InvalidArgument, song.InvalidSort.Error())
+		}
+
 		return nil, status.Error(codes.Internal, "")
 	}
 
-	songsResponseList := convertSongToSongsResponseList(songs)
+	songsResponseList := convertSongToSongsResponseList(page.Songs)
+	songsResponseList.Pagination = songgrpc.PaginationToProto(page.Pagination(&gsdto))
+	if page.Facets != nil {
+		songsResponseList.Facets = convertSongFacets(page.Facets)
+	}
 
 	return songsResponseList, nil
 }
@@ -99,19 +158,35 @@ func convertSongToSongsResponseList(songsList []models.Song) *songv1.GetSongsRes
 	return &songsResponseList
 }
 
+func convertSongFacets(facets *dtos.SongFacets) *songv1.SongFacets {
+	convert := func(counts []dtos.FacetCount) []*songv1.FacetCount {
+		converted := make([]*songv1.FacetCount, len(counts))
+		for i, count := range counts {
+			converted[i] = &songv1.FacetCount{Value: count.Value, Count: count.Count}
+		}
+		return converted
+	}
+
+	return &songv1.SongFacets{
+		Authors: convert(facets.Authors),
+		Years:   convert(facets.Years),
+		Decades: convert(facets.Decades),
+	}
+}
+
 func (s *serverGRPC) DeleteSong(ctx context.Context, req *songv1.DeleteSongsRequest) (*songv1.DeleteSongsResponse, error) {
 	id := req.GetId()
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered DeleteSong Hanlder with parameter: %s", id))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered DeleteSong Hanlder with parameter: %s", id))
 
 	convertedId, err := uuid.Parse(id)
 	if err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
 
 		return nil, status.Error(codes.InvalidArgument, "")
 	}
 
-	logrusCustom.LogWithLocation(logrus.DebugLevel, fmt.Sprintf("Successfully converted songId to uuid format: %s", convertedId.String()))
+	logrusCustom.Log(ctx, logrus.DebugLevel, fmt.Sprintf("Successfully converted songId to uuid format: %s", convertedId.String()))
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
@@ -137,3 +212,323 @@ func (s *serverGRPC) DeleteSong(ctx context.Context, req *songv1.DeleteSongsRequ
 		Link:        deletedSong.Link,
 	}, nil
 }
+
+func (s *serverGRPC) UpdateSong(ctx context.Context, req *songv1.UpdateSongRequest) (*songv1.UpdateSongResponse, error) {
+
+	fieldsToUpdate := dtos.UpdateSongsDTO{
+		Name:        req.GetName(),
+		GroupId:     req.GetGroupId(),
+		ReleaseDate: req.GetReleaseDate(),
+		Text:        req.GetText(),
+		Link:        req.GetLink(),
+		Language:    req.GetLanguage(),
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered UpdateSong gRPC Hanlder with parameter: id: %s, %+v", req.GetId(), fieldsToUpdate))
+
+	convertedId, err := uuid.Parse(req.GetId())
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, song.InvalidSongIdFormat.Error())
+	}
+	logrusCustom.Log(ctx, logrus.DebugLevel, fmt.Sprintf("Successfully converted songId to uuid format: %s", convertedId.String()))
+
+	err = s.validateDTO(fieldsToUpdate)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, song.InvalidInputData.Error())
+	}
+	logrusCustom.Log(ctx, logrus.InfoLevel, "Successfully validated parameters")
+
+	var releaseDateCasted time.Time
+	if fieldsToUpdate.ReleaseDate != "" {
+		releaseDateCasted, err = time.Parse("2006-01-02", fieldsToUpdate.ReleaseDate)
+		if err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+			return nil, status.Error(codes.InvalidArgument, song.InvalidInputData.Error())
+		}
+	}
+
+	var convertedAuthorId uuid.UUID
+	if fieldsToUpdate.GroupId != "" {
+		convertedAuthorId, err = uuid.Parse(fieldsToUpdate.GroupId)
+		if err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+			return nil, status.Error(codes.InvalidArgument, song.InvalidAuthorIdFormat.Error())
+		}
+	}
+
+	var songToUpdate = models.Song{
+		ID:          convertedId,
+		Name:        strings.ToLower(fieldsToUpdate.Name),
+		AuthorId:    convertedAuthorId,
+		Text:        strings.ToLower(fieldsToUpdate.Text),
+		Link:        fieldsToUpdate.Link,
+		Language:    fieldsToUpdate.Language,
+		ReleaseDate: releaseDateCasted}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	updatedSong, err := s.usecase.UpdateSong(ctx, &songToUpdate)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		if err.Error() == song.SongsNotFound.Error() {
+			return nil, status.Error(codes.NotFound, song.SongsNotFound.Error())
+		}
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &songv1.UpdateSongResponse{
+		Id:          updatedSong.ID.String(),
+		Name:        updatedSong.Name,
+		AuthorId:    updatedSong.AuthorId.String(),
+		AuthorName:  updatedSong.Author.GroupName,
+		ReleaseDate: updatedSong.ReleaseDate.String(),
+		Text:        updatedSong.Text,
+		Link:        updatedSong.Link,
+	}, nil
+}
+
+func (s *serverGRPC) CreateSong(ctx context.Context, req *songv1.CreateSongRequest) (*songv1.CreateSongResponse, error) {
+
+	createSongDTO := dtos.CreateSongDTO{
+		Group: req.GetGroup(),
+		Song:  req.GetSong(),
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered CreateSong gRPC Hanlder with parameters: %+v", createSongDTO))
+
+	err := s.validateDTO(createSongDTO)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, song.InvalidInputData.Error())
+	}
+	logrusCustom.Log(ctx, logrus.InfoLevel, "Successfully validated parameters")
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	createdSong, err := s.usecase.CreateSong(ctx, strings.ToLower(createSongDTO.Group), strings.ToLower(createSongDTO.Song))
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		if err.Error() == song.ProviderUnavailable.Error() {
+			return nil, status.Error(codes.Unavailable, song.ProviderUnavailable.Error())
+		}
+
+		if err.Error() == song.ErrorGetSongData.Error() {
+			return nil, status.Error(codes.NotFound, song.ErrorGetSongData.Error())
+		}
+
+		if err.Error() == song.ErrorGetSongLyrics.Error() {
+			return nil, status.Error(codes.NotFound, song.ErrorGetSongLyrics.Error())
+		}
+
+		if err.Error() == song.AuthorAlreadyExists.Error() {
+			return nil, status.Error(codes.AlreadyExists, song.AuthorAlreadyExists.Error())
+		}
+
+		if err.Error() == song.AuthorSongDuplicate.Error() {
+			return nil, status.Error(codes.AlreadyExists, song.AuthorSongDuplicate.Error())
+		}
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &songv1.CreateSongResponse{
+		Id:          createdSong.ID.String(),
+		Name:        createdSong.Name,
+		AuthorId:    createdSong.AuthorId.String(),
+		AuthorName:  createdSong.Author.GroupName,
+		ReleaseDate: createdSong.ReleaseDate.String(),
+		Text:        createdSong.Text,
+		Link:        createdSong.Link,
+	}, nil
+}
+
+func (s *serverGRPC) GetSongLyrics(ctx context.Context, req *songv1.GetSongLyricsRequest) (*songv1.GetSongLyricsResponse, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetSongLyrics gRPC Hanlder with parameter: id: %s", req.GetId()))
+
+	convertedId, err := uuid.Parse(req.GetId())
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, song.InvalidSongIdFormat.Error())
+	}
+	logrusCustom.Log(ctx, logrus.DebugLevel, fmt.Sprintf("Successfully converted songId to uuid format: %s", convertedId.String()))
+
+	gsldto := dtos.GetSongLyricsDTO{
+		Id:       convertedId,
+		Page:     int(req.GetPage()),
+		PageSize: int(req.GetPageSize()),
+	}
+
+	err = s.validateDTO(gsldto)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, song.InvalidInputData.Error())
+	}
+	logrusCustom.Log(ctx, logrus.InfoLevel, "Successfully validated parameters")
+
+	gsldto.SetDefaults()
+	logrusCustom.Log(ctx, logrus.DebugLevel, fmt.Sprintf("Setted default parameters: %+v", gsldto))
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	lyrics, err := s.usecase.GetSongLyrics(ctx, &gsldto)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		if err.Error() == song.SongsNotFound.Error() {
+			return nil, status.Error(codes.NotFound, song.SongsNotFound.Error())
+		}
+
+		if err.Error() == song.ErrorGetSongData.Error() || err.Error() == song.ErrorGetSongLyrics.Error() {
+			return nil, status.Error(codes.NotFound, song.ErrorGetSongLyrics.Error())
+		}
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &songv1.GetSongLyricsResponse{
+		Lyrics:     lyrics.Verses,
+		Pagination: songgrpc.PaginationToProto(pagination.New(lyrics.Total, gsldto.Page, gsldto.PageSize)),
+	}, nil
+}
+
+func (s *serverGRPC) SearchSongs(ctx context.Context, req *songv1.SearchSongsRequest) (*songv1.SearchSongsResponseList, error) {
+
+	ssdto := dtos.SearchSongsDTO{
+		Query:    req.GetQuery(),
+		Language: req.GetLanguage(),
+		Page:     int(req.GetPage()),
+		PageSize: int(req.GetPageSize()),
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered SearchSongs gRPC Hanlder with parameters: %+v", ssdto))
+
+	err := s.validateDTO(ssdto)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, status.Error(codes.InvalidArgument, song.InvalidInputData.Error())
+	}
+
+	ssdto.SetDefaults()
+	logrusCustom.Log(ctx, logrus.DebugLevel, fmt.Sprintf("Setted default parameters: %+v", ssdto))
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	page, err := s.usecase.SearchSongs(ctx, &ssdto)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		if err.Error() == song.SongsNotFound.Error() {
+			return nil, status.Error(codes.NotFound, song.SongsNotFound.Error())
+		}
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var searchResponseList songv1.SearchSongsResponseList
+	for _, result := range page.Results {
+		searchResponseList.Songs = append(searchResponseList.GetSongs(), &songv1.SearchSongsResponse{
+			Id:          result.ID.String(),
+			Name:        result.Name,
+			AuthorId:    result.AuthorId.String(),
+			AuthorName:  result.Author.GroupName,
+			ReleaseDate: result.ReleaseDate.String(),
+			Link:        result.Link,
+			Language:    result.Language,
+			Rank:        result.Rank,
+			Headline:    result.Headline,
+		})
+	}
+	searchResponseList.Pagination = songgrpc.PaginationToProto(pagination.New(page.Total, ssdto.Page, ssdto.PageSize))
+
+	return &searchResponseList, nil
+}
+
+// ExportSongs streams every song matching the GetSongs filters, one message per
+// song, as the rows are read from the database.
+func (s *serverGRPC) ExportSongs(req *songv1.ExportSongsRequest, stream grpc.ServerStreamingServer[songv1.ExportSongsResponse]) error {
+
+	gsdto := dtos.GetSongsDTO{
+		Id:              req.GetId(),
+		Name:            req.GetName(),
+		GroupName:       req.GetGroupName(),
+		ReleaseDate:     req.GetReleaseDate(),
+		ReleaseDateFrom: req.GetReleaseDateFrom(),
+		ReleaseDateTo:   req.GetReleaseDateTo(),
+		Year:            int(req.GetYear()),
+		Decade:          int(req.GetDecade()),
+		Text:            req.GetText(),
+		Link:            req.GetLink(),
+		Sort:            req.GetSort(),
+	}
+
+	logrusCustom.Log(stream.Context(), logrus.InfoLevel, fmt.Sprintf("Entered ExportSongs gRPC Hanlder with parameters: %+v", gsdto))
+
+	err := s.validateDTO(gsdto)
+	if err != nil {
+		logrusCustom.Log(stream.Context(), logrus.ErrorLevel, err.Error())
+
+		return status.Error(codes.InvalidArgument, song.InvalidInputData.Error())
+	}
+
+	if !gsdto.ReleaseDatesValid() {
+		logrusCustom.Log(stream.Context(), logrus.ErrorLevel, song.InvalidReleaseDates.Error())
+
+		return status.Error(codes.InvalidArgument, song.InvalidReleaseDates.Error())
+	}
+
+	if gsdto.Id != "" {
+		_, err = uuid.Parse(gsdto.Id)
+		if err != nil {
+			logrusCustom.Log(stream.Context(), logrus.ErrorLevel, err.Error())
+
+			return status.Error(codes.InvalidArgument, song.InvalidSongIdFormat.Error())
+		}
+	}
+
+	gsdto.Text = strings.ToLower(gsdto.Text)
+
+	exported, err := s.usecase.ExportSongs(stream.Context(), &gsdto, func(exportSong dtos.ExportSong) error {
+		return stream.Send(&songv1.ExportSongsResponse{
+			Id:          exportSong.ID.String(),
+			Group:       exportSong.Group,
+			Song:        exportSong.Song,
+			ReleaseDate: exportSong.ReleaseDate,
+			Lyrics:      exportSong.Lyrics,
+			Link:        exportSong.Link,
+			Language:    exportSong.Language,
+		})
+	})
+	if err != nil {
+		logrusCustom.Log(stream.Context(), logrus.ErrorLevel, fmt.Sprintf("Export aborted after %d songs: %s", exported, err.Error()))
+
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+
+		if err.Error() == song.InvalidSort.Error() {
+			return status.Error(codes.InvalidArgument, song.InvalidSort.Error())
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package songGRPC
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/song"
+	"SongsLibrary/internal/song/dtos"
+	"SongsLibrary/internal/song/usecase"
+	"SongsLibrary/internal/validators"
+	"context"
+	songv1 "github.com/DanKo-code/Protobuf-For-Songs-Library-Upgraded/protos/gen/go/song"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func setup() (*serverGRPC, *usecase.MockSongUseCase) {
+
+	mockUseCase := new(usecase.MockSongUseCase)
+
+	validate := validator.New()
+	_ = validate.RegisterValidation("DateValidation", validators.DateValidation)
+
+	return &serverGRPC{validate: validate, usecase: mockUseCase}, mockUseCase
+}
+
+func TestCreateSongGRPC_Success(t *testing.T) {
+	s, mockUseCase := setup()
+
+	createdSong := &models.Song{
+		ID:          uuid.New(),
+		Name:        "fortunate son",
+		AuthorId:    uuid.New(),
+		Author:      models.Author{GroupName: "creedence clearwater revival"},
+		ReleaseDate: time.Date(1969, 11, 2, 0, 0, 0, 0, time.UTC),
+		Text:        "some folks are born made to wave the flag",
+		Link:        "http://example.com",
+	}
+
+	mockUseCase.On("CreateSong", mock.Anything, "creedence clearwater revival", "fortunate son").Return(createdSong, nil)
+
+	res, err := s.CreateSong(context.Background(), &songv1.CreateSongRequest{Group: "Creedence Clearwater Revival", Song: "Fortunate Son"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, createdSong.ID.String(), res.GetId())
+	assert.Equal(t, createdSong.Author.GroupName, res.GetAuthorName())
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestCreateSongGRPC_Duplicate(t *testing.T) {
+	s, mockUseCase := setup()
+
+	mockUseCase.On("CreateSong", mock.Anything, "testgroup", "testsong").Return(nil, song.AuthorSongDuplicate)
+
+	_, err := s.CreateSong(context.Background(), &songv1.CreateSongRequest{Group: "testgroup", Song: "testsong"})
+
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestUpdateSongGRPC_ValidateError(t *testing.T) {
+	s, _ := setup()
+
+	_, err := s.UpdateSong(context.Background(), &songv1.UpdateSongRequest{Id: uuid.New().String(), ReleaseDate: "2999-01-01"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUpdateSongGRPC_InvalidLink(t *testing.T) {
+	s, _ := setup()
+
+	_, err := s.UpdateSong(context.Background(), &songv1.UpdateSongRequest{Id: uuid.New().String(), Link: "123"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUpdateSongGRPC_NotFound(t *testing.T) {
+	s, mockUseCase := setup()
+
+	id := uuid.New()
+	songToUpdate := &models.Song{ID: id, Name: "testsong"}
+
+	mockUseCase.On("UpdateSong", mock.Anything, songToUpdate).Return(nil, song.SongsNotFound)
+
+	_, err := s.UpdateSong(context.Background(), &songv1.UpdateSongRequest{Id: id.String(), Name: "TestSong"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGetSongLyricsGRPC_Success(t *testing.T) {
+	s, mockUseCase := setup()
+
+	id := uuid.New()
+	gsldto := &dtos.GetSongLyricsDTO{Id: id, Page: 1, PageSize: 2}
+
+	mockUseCase.On("GetSongLyrics", mock.Anything, gsldto).Return(&dtos.LyricsPage{Verses: []string{"first verse", "second verse"}, Total: 5}, nil)
+
+	res, err := s.GetSongLyrics(context.Background(), &songv1.GetSongLyricsRequest{Id: id.String()})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first verse", "second verse"}, res.GetLyrics())
+	assert.Equal(t, int64(3), res.GetPagination().GetTotalPages())
+}
+
+func TestGetSongLyricsGRPC_InvalidId(t *testing.T) {
+	s, _ := setup()
+
+	_, err := s.GetSongLyrics(context.Background(), &songv1.GetSongLyricsRequest{Id: "not-a-uuid"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSearchSongsGRPC_Success(t *testing.T) {
+	s, mockUseCase := setup()
+
+	ssdto := &dtos.SearchSongsDTO{Query: "фраер", Language: "russian", Page: 1, PageSize: 10}
+
+	mockUseCase.On("SearchSongs", mock.Anything, ssdto).Return(&dtos.SongSearchPage{
+		Results: []dtos.SongSearchResult{
+			{Song: models.Song{ID: uuid.New(), Name: "фраер"}, Rank: 0.5, Headline: "что ж ты, <b>фраер</b>"},
+		},
+		Total: 1,
+	}, nil)
+
+	res, err := s.SearchSongs(context.Background(), &songv1.SearchSongsRequest{Query: "фраер", Language: "russian"})
+
+	assert.NoError(t, err)
+	assert.Len(t, res.GetSongs(), 1)
+	assert.Equal(t, "что ж ты, <b>фраер</b>", res.GetSongs()[0].GetHeadline())
+	assert.Equal(t, int64(1), res.GetPagination().GetTotal())
+}
+
+func TestSearchSongsGRPC_EmptyQuery(t *testing.T) {
+	s, _ := setup()
+
+	_, err := s.SearchSongs(context.Background(), &songv1.SearchSongsRequest{})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+type exportStream struct {
+	grpc.ServerStream
+	sent []*songv1.ExportSongsResponse
+}
+
+func (es *exportStream) Context() context.Context {
+	return context.Background()
+}
+
+func (es *exportStream) Send(res *songv1.ExportSongsResponse) error {
+	es.sent = append(es.sent, res)
+	return nil
+}
+
+func TestExportSongsGRPC_Success(t *testing.T) {
+	s, mockUseCase := setup()
+
+	exportSongs := []dtos.ExportSong{
+		{ID: uuid.New(), Group: "muse", Song: "hysteria", ReleaseDate: "2003-12-01", Language: "english"},
+		{ID: uuid.New(), Group: "muse", Song: "uprising", ReleaseDate: "2009-09-07", Language: "english"},
+	}
+
+	mockUseCase.On("ExportSongs", mock.Anything, &dtos.GetSongsDTO{GroupName: "muse"}, mock.Anything).Return(exportSongs, nil)
+
+	stream := &exportStream{}
+	err := s.ExportSongs(&songv1.ExportSongsRequest{GroupName: "muse"}, stream)
+
+	assert.NoError(t, err)
+	assert.Len(t, stream.sent, 2)
+	assert.Equal(t, exportSongs[1].ID.String(), stream.sent[1].GetId())
+	assert.Equal(t, "uprising", stream.sent[1].GetSong())
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestExportSongsGRPC_InvalidId(t *testing.T) {
+	s, _ := setup()
+
+	err := s.ExportSongs(&songv1.ExportSongsRequest{Id: "not-a-uuid"}, &exportStream{})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetSongsGRPC_NextCursor(t *testing.T) {
+	s, mockUseCase := setup()
+
+	gsdto := &dtos.GetSongsDTO{Page: 1, PageSize: 1, Cursor: "current"}
+	page := &dtos.SongsPage{
+		Songs:      []models.Song{{ID: uuid.New(), Name: "hysteria", Author: models.Author{GroupName: "muse"}}},
+		NextCursor: "next",
+		Total:      4,
+	}
+
+	mockUseCase.On("GetSongs", mock.Anything, gsdto).Return(page, nil)
+
+	res, err := s.GetSongs(context.Background(), &songv1.GetSongsRequest{PageSize: 1, Cursor: "current"})
+
+	assert.NoError(t, err)
+	assert.Len(t, res.GetSongs(), 1)
+	assert.Equal(t, "next", res.GetPagination().GetNextCursor())
+	assert.Equal(t, int64(4), res.GetPagination().GetTotal())
+	assert.Equal(t, int64(0), res.GetPagination().GetPage())
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGetSongsGRPC_InvalidSort(t *testing.T) {
+	s, mockUseCase := setup()
+
+	gsdto := &dtos.GetSongsDTO{Page: 1, PageSize: 3, Sort: "text"}
+
+	mockUseCase.On("GetSongs", mock.Anything, gsdto).Return(nil, song.InvalidSort)
+
+	_, err := s.GetSongs(context.Background(), &songv1.GetSongsRequest{Sort: "text"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetSongsGRPC_Facets(t *testing.T) {
+	s, mockUseCase := setup()
+
+	gsdto := &dtos.GetSongsDTO{Decade: 1970, Page: 1, PageSize: 3, Facets: true}
+	page := &dtos.SongsPage{
+		Songs: []models.Song{{ID: uuid.New(), Name: "bohemian rhapsody", Author: models.Author{GroupName: "queen"}}},
+		Total: 1,
+		Facets: &dtos.SongFacets{
+			Authors: []dtos.FacetCount{{Value: "queen", Count: 1}},
+			Years:   []dtos.FacetCount{{Value: "1975", Count: 1}},
+			Decades: []dtos.FacetCount{{Value: "1970", Count: 1}},
+		},
+	}
+
+	mockUseCase.On("GetSongs", mock.Anything, gsdto).Return(page, nil)
+
+	res, err := s.GetSongs(context.Background(), &songv1.GetSongsRequest{Decade: 1970, Facets: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "queen", res.GetFacets().GetAuthors()[0].GetValue())
+	assert.Equal(t, "1975", res.GetFacets().GetYears()[0].GetValue())
+	assert.Equal(t, int64(1), res.GetFacets().GetDecades()[0].GetCount())
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGetSongsGRPC_ReversedReleaseDates(t *testing.T) {
+	s, _ := setup()
+
+	_, err := s.GetSongs(context.Background(), &songv1.GetSongsRequest{ReleaseDateFrom: "1980-01-01", ReleaseDateTo: "1970-01-01"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
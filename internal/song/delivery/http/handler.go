@@ -2,76 +2,105 @@ package http
 
 import (
 	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/job"
+	"SongsLibrary/internal/pagination"
 	"SongsLibrary/internal/song"
+	"SongsLibrary/internal/song/constants"
 	"SongsLibrary/internal/song/dtos"
+	"SongsLibrary/internal/song/exporter"
+	"SongsLibrary/internal/song/importer"
 	logrusCustom "SongsLibrary/pkg/logger"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"io"
 	"net/http"
 	"strings"
 	"time"
 )
 
 type Handler struct {
-	useCase  song.UseCase
-	validate *validator.Validate
+	useCase    song.UseCase
+	jobUseCase job.UseCase
+	validate   *validator.Validate
 }
 
-func NewHandler(useCase song.UseCase, validate *validator.Validate) *Handler {
+func NewHandler(useCase song.UseCase, jobUseCase job.UseCase, validate *validator.Validate) *Handler {
 	return &Handler{
-		useCase:  useCase,
-		validate: validate,
+		useCase:    useCase,
+		jobUseCase: jobUseCase,
+		validate:   validate,
 	}
 }
 
 // GetSongs
 // @Summary Retrieve a list of songs
-// @Description Fetch a list of songs from the library with filtering options such as name, group name, release date, text, link, and pagination. Each song can be filtered based on the available query parameters.
+// @Description Fetch a list of songs from the library with filtering options such as name, group name, release date, text, link, and pagination. Each song can be filtered based on the available query parameters. Songs are ordered by name unless sort lists other fields. The response carries a pagination envelope with the total count and a next_cursor while more songs follow; pass it back as cursor to get the next page, in which case page is ignored. Page links are also sent in the Link header. With facets=true the response also holds song counts per author, release year and decade over all matching songs.
 // @Tags Songs
 // @Produce  json
 // @Param id query string false "UUID of the song" format(uuid)
 // @Param name query string false "Name of the song" maxlength(100)
 // @Param group_name query string false "Name of the group" maxlength(100)
 // @Param release_date query string false "Release date of the song" format(date)
+// @Param release_date_from query string false "Earliest release date, inclusive" format(date)
+// @Param release_date_to query string false "Latest release date, inclusive" format(date)
+// @Param year query int false "Release year" minimum(1900)
+// @Param decade query int false "First year of the release decade, e.g. 1970" minimum(1900)
 // @Param text query string false "Lyrics of the song" maxlength(10000)
 // @Param link query string false "Link to the song" format(url)
 // @Param page query int false "Page number for pagination" minimum(1)
 // @Param page_size query int false "Number of songs per page" minimum(1) maximum(100)
+// @Param cursor query string false "Opaque cursor from the next_cursor of a previous response"
+// @Param sort query string false "Comma separated sort fields, prefixed with - for descending order" example(-release_date,name) Enums(name, release_date, group_name)
+// @Param facets query bool false "Also return song counts per author, year and decade for the filters"
 // @Success 200 {array} models.Song "List of songs"
+// @Header 200 {string} Link "Links to the next, previous, first and last pages"
 // @Failure 400 {object} string "Invalid input data"
 // @Failure 404 {object} string "Songs not found"
 // @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/songs [get]
 func (h *Handler) GetSongs(c *gin.Context) {
 	var gsdto dtos.GetSongsDTO
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered GetSongs Hanlder with parameters: %+v", gsdto))
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered GetSongs Hanlder with parameters: %+v", gsdto))
 
 	if err := c.ShouldBindQuery(&gsdto); err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
 
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidInputData.Error()})
 		return
 	}
-	logrusCustom.LogWithLocation(logrus.DebugLevel, fmt.Sprintf("Successfully binded song parameters: %+v", gsdto))
+	logrusCustom.Log(c.Request.Context(), logrus.DebugLevel, fmt.Sprintf("Successfully binded song parameters: %+v", gsdto))
 
 	err := h.validate.Struct(gsdto)
 	if err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
 
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidInputData.Error()})
 		return
 	}
-	logrusCustom.LogWithLocation(logrus.InfoLevel, "Successfully validated parameters")
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, "Successfully validated parameters")
+
+	if !gsdto.ReleaseDatesValid() {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, song.InvalidReleaseDates.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidReleaseDates.Error()})
+		return
+	}
 
 	if gsdto.Id != "" {
 		_, err = uuid.Parse(gsdto.Id)
 		if err != nil {
-			logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+			logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
 
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidSongIdFormat.Error()})
 			return
@@ -79,28 +108,46 @@ func (h *Handler) GetSongs(c *gin.Context) {
 	}
 
 	gsdto.SetDefaults()
-	logrusCustom.LogWithLocation(logrus.DebugLevel, fmt.Sprintf("Setted default parameters: %+v", gsdto))
+	logrusCustom.Log(c.Request.Context(), logrus.DebugLevel, fmt.Sprintf("Setted default parameters: %+v", gsdto))
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
 	gsdto.Text = strings.ToLower(gsdto.Text)
 
-	songs, err := h.useCase.GetSongs(ctx, &gsdto)
+	page, err := h.useCase.GetSongs(ctx, &gsdto)
 	if err != nil {
 
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
 
 		if err.Error() == song.SongsNotFound.Error() {
 			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": song.SongsNotFound.Error()})
 			return
 		}
 
+		if err.Error() == song.InvalidCursor.Error() {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidCursor.Error()})
+			return
+		}
+
+		if err.Error() == song.InvalidSort.Error() {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidSort.Error()})
+			return
+		}
+
 		c.AbortWithStatus(http.StatusInternalServerError)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"songs": songs})
+	envelope := page.Pagination(&gsdto)
+	c.Header("Link", pagination.LinkHeader(c.Request.URL, envelope))
+
+	response := gin.H{"songs": page.Songs, "pagination": envelope}
+	if page.Facets != nil {
+		response["facets"] = page.Facets
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // DeleteSong
@@ -113,21 +160,26 @@ func (h *Handler) GetSongs(c *gin.Context) {
 // @Failure 400 {object} string "Invalid song ID format"
 // @Failure 404 {object} string "Song not found"
 // @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/songs/{id} [delete]
 func (h *Handler) DeleteSong(c *gin.Context) {
 	id := c.Param("id")
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered DeleteSong Hanlder with parameter: %s", id))
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered DeleteSong Hanlder with parameter: %s", id))
 
 	convertedId, err := uuid.Parse(id)
 	if err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
 
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidSongIdFormat.Error()})
 		return
 	}
 
-	logrusCustom.LogWithLocation(logrus.DebugLevel, fmt.Sprintf("Successfully converted songId to uuid format: %s", convertedId.String()))
+	logrusCustom.Log(c.Request.Context(), logrus.DebugLevel, fmt.Sprintf("Successfully converted songId to uuid format: %s", convertedId.String()))
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
@@ -158,44 +210,49 @@ func (h *Handler) DeleteSong(c *gin.Context) {
 // @Failure 400 {object} string "Invalid input data"
 // @Failure 404 {object} string "Song not found"
 // @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/songs/{id} [put]
 func (h *Handler) UpdateSong(c *gin.Context) {
 
 	var fieldsToUpdate dtos.UpdateSongsDTO
 	id := c.Param("id")
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered UpdateSong Hanlder with parameter: id: , %+v", fieldsToUpdate))
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered UpdateSong Hanlder with parameter: id: , %+v", fieldsToUpdate))
 
 	convertedId, err := uuid.Parse(id)
 	if err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
 
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidSongIdFormat.Error()})
 		return
 	}
-	logrusCustom.LogWithLocation(logrus.DebugLevel, fmt.Sprintf("Successfully converted songId to uuid format: %s", convertedId.String()))
+	logrusCustom.Log(c.Request.Context(), logrus.DebugLevel, fmt.Sprintf("Successfully converted songId to uuid format: %s", convertedId.String()))
 
 	if err := c.ShouldBindJSON(&fieldsToUpdate); err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
 
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidInputData.Error()})
 		return
 	}
-	logrusCustom.LogWithLocation(logrus.DebugLevel, fmt.Sprintf("Successfully binded song parameters: %+v", fieldsToUpdate))
+	logrusCustom.Log(c.Request.Context(), logrus.DebugLevel, fmt.Sprintf("Successfully binded song parameters: %+v", fieldsToUpdate))
 
 	err = h.validate.Struct(fieldsToUpdate)
 	if err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
 
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidInputData.Error()})
 		return
 	}
-	logrusCustom.LogWithLocation(logrus.InfoLevel, "Successfully validated parameters")
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, "Successfully validated parameters")
 
 	var releaseDateCasted time.Time
 	if fieldsToUpdate.ReleaseDate != "" {
 		releaseDateCasted, err = time.Parse("2006-01-02", fieldsToUpdate.ReleaseDate)
 		if err != nil {
-			logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+			logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
 
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidInputData.Error()})
 			return
@@ -206,7 +263,7 @@ func (h *Handler) UpdateSong(c *gin.Context) {
 	if fieldsToUpdate.GroupId != "" {
 		convertedAuthorId, err = uuid.Parse(fieldsToUpdate.GroupId)
 		if err != nil {
-			logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+			logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
 
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidAuthorIdFormat.Error()})
 			return
@@ -219,6 +276,7 @@ func (h *Handler) UpdateSong(c *gin.Context) {
 		AuthorId:    convertedAuthorId,
 		Text:        strings.ToLower(fieldsToUpdate.Text),
 		Link:        fieldsToUpdate.Link,
+		Language:    fieldsToUpdate.Language,
 		ReleaseDate: releaseDateCasted}
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
@@ -241,37 +299,50 @@ func (h *Handler) UpdateSong(c *gin.Context) {
 
 // CreateSong
 // @Summary Create a new song
-// @Description Create a new song in the library by providing song details in the request body. The group and song name will be converted to lowercase before saving.
+// @Description Create a new song in the library by providing song details in the request body. The group and song name will be converted to lowercase before saving. With async=true the metadata lookup runs in the background: the response is 202 with the queued job, whose status can be polled at the URL in the Location header.
 // @Tags Songs
 // @Produce json
 // @Param createSongDTO body dtos.CreateSongDTO true "Details of the song to create"
+// @Param async query bool false "Queue the creation as a background job"
 // @Success 200 {object} models.Song "Created song details"
+// @Success 202 {object} models.Job "Queued job"
 // @Failure 400 {object} string "Invalid input data"
 // @Failure 404 {object} string "Data not found"
 // @Failure 409 {object} string "Song already exists"
 // @Failure 500 {object} string "Internal server error"
+// @Failure 503 {object} string "Metadata providers unavailable"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/songs [post]
 func (h *Handler) CreateSong(c *gin.Context) {
 	var createSongDTO dtos.CreateSongDTO
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered CreateSongs Hanlder with parameters: %+v", createSongDTO))
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered CreateSongs Hanlder with parameters: %+v", createSongDTO))
 
 	if err := c.ShouldBindJSON(&createSongDTO); err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
 
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidInputData.Error()})
 		return
 	}
-	logrusCustom.LogWithLocation(logrus.DebugLevel, fmt.Sprintf("Successfully binded song parameters: %+v", createSongDTO))
+	logrusCustom.Log(c.Request.Context(), logrus.DebugLevel, fmt.Sprintf("Successfully binded song parameters: %+v", createSongDTO))
 
 	err := h.validate.Struct(createSongDTO)
 	if err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
 
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidInputData.Error()})
 		return
 	}
-	logrusCustom.LogWithLocation(logrus.InfoLevel, "Successfully validated parameters")
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, "Successfully validated parameters")
+
+	if c.Query("async") == "true" {
+		h.enqueueCreateSong(c, createSongDTO)
+		return
+	}
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 	defer cancel()
@@ -279,6 +350,11 @@ func (h *Handler) CreateSong(c *gin.Context) {
 	createSong, err := h.useCase.CreateSong(ctx, strings.ToLower(createSongDTO.Group), strings.ToLower(createSongDTO.Song))
 	if err != nil {
 
+		if err.Error() == song.ProviderUnavailable.Error() {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": song.ProviderUnavailable.Error()})
+			return
+		}
+
 		if err.Error() == song.ErrorGetSongData.Error() {
 			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": song.ErrorGetSongData.Error()})
 			return
@@ -306,6 +382,223 @@ func (h *Handler) CreateSong(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"Created Song": createSong})
 }
 
+func (h *Handler) enqueueCreateSong(c *gin.Context, createSongDTO dtos.CreateSongDTO) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	queuedJob, err := h.jobUseCase.EnqueueCreateSong(ctx, strings.ToLower(createSongDTO.Group), strings.ToLower(createSongDTO.Song))
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/api/jobs/%s", queuedJob.ID))
+	c.JSON(http.StatusAccepted, gin.H{"job": queuedJob})
+}
+
+// ImportSongs
+// @Summary Bulk import songs from a CSV or NDJSON file
+// @Description Import songs without looking up metadata. The file is sent either as the "file" field of a multipart form or as the raw request body. CSV files need a header row with the columns group, song, release_date, lyrics and link; NDJSON files hold one JSON object with the same keys per line. Every row is validated and reported as created, duplicate or invalid.
+// @Tags Songs
+// @Accept mpfd,plain
+// @Produce json
+// @Param format query string false "File format, detected from the file name or content type when omitted" Enums(csv, ndjson)
+// @Param file formData file false "CSV or NDJSON file"
+// @Success 200 {object} dtos.ImportReport "Per-row import report"
+// @Failure 400 {object} string "Unsupported format or unreadable file"
+// @Failure 413 {object} string "File too large"
+// @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/songs/import [post]
+func (h *Handler) ImportSongs(c *gin.Context) {
+
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered ImportSongs Hanlder with content type: %s", c.ContentType()))
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, constants.MaxImportFileSize)
+
+	var body io.Reader = c.Request.Body
+	var fileName string
+
+	if c.ContentType() == "multipart/form-data" {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": song.InvalidImportFile.Error()})
+				return
+			}
+
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidImportFile.Error()})
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidImportFile.Error()})
+			return
+		}
+		defer file.Close()
+
+		body = file
+		fileName = fileHeader.Filename
+	}
+
+	format, err := importer.DetectFormat(c.Query("format"), fileName, c.ContentType())
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.UnsupportedImportType.Error()})
+		return
+	}
+
+	rows, err := importer.Parse(body, format, h.validate)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": song.InvalidImportFile.Error()})
+			return
+		}
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Successfully parsed %d rows", len(rows)))
+
+	report, err := h.useCase.ImportSongs(c.Request.Context(), rows)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ExportSongs
+// @Summary Export songs as CSV, JSON or NDJSON
+// @Description Stream every song matching the same filters as GET /api/songs. Paging parameters are ignored. Rows are written as they are read from the database; if the export fails midway the response is cut short, so a JSON export is left without its closing bracket.
+// @Tags Songs
+// @Produce json,plain
+// @Param format query string false "Export format" Enums(csv, json, ndjson) default(json)
+// @Param id query string false "UUID of the song" format(uuid)
+// @Param name query string false "Name of the song" maxlength(100)
+// @Param group_name query string false "Name of the group" maxlength(100)
+// @Param release_date query string false "Release date of the song" format(date)
+// @Param release_date_from query string false "Earliest release date, inclusive" format(date)
+// @Param release_date_to query string false "Latest release date, inclusive" format(date)
+// @Param year query int false "Release year" minimum(1900)
+// @Param decade query int false "First year of the release decade, e.g. 1970" minimum(1900)
+// @Param text query string false "Lyrics of the song" maxlength(10000)
+// @Param link query string false "Link to the song" format(url)
+// @Param sort query string false "Comma separated sort fields, prefixed with - for descending order; defaults to group_name,name" Enums(name, release_date, group_name)
+// @Success 200 {array} dtos.ExportSong "Exported songs"
+// @Failure 400 {object} string "Invalid input data"
+// @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/songs/export [get]
+func (h *Handler) ExportSongs(c *gin.Context) {
+	var esdto dtos.ExportSongsDTO
+
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered ExportSongs Hanlder with query: %s", c.Request.URL.RawQuery))
+
+	if err := c.ShouldBindQuery(&esdto); err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidInputData.Error()})
+		return
+	}
+
+	err := h.validate.Struct(esdto)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidInputData.Error()})
+		return
+	}
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, "Successfully validated parameters")
+
+	if !esdto.ReleaseDatesValid() {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, song.InvalidReleaseDates.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidReleaseDates.Error()})
+		return
+	}
+
+	if esdto.Id != "" {
+		_, err = uuid.Parse(esdto.Id)
+		if err != nil {
+			logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidSongIdFormat.Error()})
+			return
+		}
+	}
+
+	// The sort has to be checked before the status line is sent.
+	if _, err = pagination.ParseSort(esdto.Sort, constants.SongSortFields); err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidSort.Error()})
+		return
+	}
+
+	if esdto.Format == "" {
+		esdto.Format = constants.ExportFormatJSON
+	}
+
+	esdto.Text = strings.ToLower(esdto.Text)
+
+	c.Header("Content-Type", exporter.ContentType(esdto.Format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="songs.%s"`, esdto.Format))
+	c.Status(http.StatusOK)
+
+	writer, err := exporter.NewWriter(c.Writer, esdto.Format)
+	if err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+		return
+	}
+
+	written := 0
+	exported, err := h.useCase.ExportSongs(c.Request.Context(), &esdto.GetSongsDTO, func(exportSong dtos.ExportSong) error {
+		if err := writer.Write(exportSong); err != nil {
+			return err
+		}
+
+		written++
+		if written%constants.ExportFlushInterval == 0 {
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		// The status line has already been sent, so the truncated body is the
+		// only way to tell the client that the export is incomplete.
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, fmt.Sprintf("Export aborted after %d songs: %s", exported, err.Error()))
+		return
+	}
+
+	if err := writer.Close(); err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+	}
+}
+
 // GetSongLyrics
 // @Summary Retrieve lyrics of a song
 // @Description Fetch the lyrics of a specific song identified by its ID. Optional query parameters can be used to filter the results further.
@@ -315,27 +608,33 @@ func (h *Handler) CreateSong(c *gin.Context) {
 // @Param page query int false "Page number for pagination" minimum(1)
 // @Param page_size query int false "Number of songs per page" minimum(1) maximum(100)
 // @Success 200 {object} string "Lyrics of the song"
+// @Header 200 {string} Link "Links to the next, previous, first and last pages"
 // @Failure 400 {object} string "Invalid input data"
 // @Failure 404 {object} string "Song not found"
 // @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/songs/{id}/lyrics [get]
 func (h *Handler) GetSongLyrics(c *gin.Context) {
 
 	var gsldtp dtos.GetSongLyricsDTO
 	id := c.Param("id")
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered GetSongLyrics Hanlder with parameter: id: , %s", id))
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered GetSongLyrics Hanlder with parameter: id: , %s", id))
 
 	convertedId, err := uuid.Parse(id)
 	if err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
 
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidSongIdFormat.Error()})
 		return
 	}
-	logrusCustom.LogWithLocation(logrus.DebugLevel, fmt.Sprintf("Successfully converted songId to uuid format: %s", convertedId.String()))
+	logrusCustom.Log(c.Request.Context(), logrus.DebugLevel, fmt.Sprintf("Successfully converted songId to uuid format: %s", convertedId.String()))
 
 	if err := c.ShouldBindQuery(&gsldtp); err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
 
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidInputData.Error()})
 		return
@@ -344,16 +643,16 @@ func (h *Handler) GetSongLyrics(c *gin.Context) {
 
 	err = h.validate.Struct(gsldtp)
 	if err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
 
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidInputData.Error()})
 		return
 	}
-	logrusCustom.LogWithLocation(logrus.InfoLevel, "Successfully validated parameters")
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, "Successfully validated parameters")
 
 	gsldtp.SetDefaults()
 
-	logrusCustom.LogWithLocation(logrus.DebugLevel, fmt.Sprintf("Setted default parameters: %+v", gsldtp))
+	logrusCustom.Log(c.Request.Context(), logrus.DebugLevel, fmt.Sprintf("Setted default parameters: %+v", gsldtp))
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
@@ -375,5 +674,65 @@ func (h *Handler) GetSongLyrics(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"lyrics": lyrics})
+	envelope := pagination.New(lyrics.Total, gsldtp.Page, gsldtp.PageSize)
+	c.Header("Link", pagination.LinkHeader(c.Request.URL, envelope))
+
+	c.JSON(http.StatusOK, gin.H{"lyrics": lyrics.Verses, "pagination": envelope})
+}
+
+// SearchSongs
+// @Summary Full-text search over songs
+// @Description Search song names and lyrics with Postgres full-text search. The query supports web search syntax (quoted phrases, "or", "-" for exclusion). Results are ordered by relevance and contain a lyrics snippet with the matched words wrapped in <b> tags.
+// @Tags Songs
+// @Produce json
+// @Param q query string true "Search query" maxlength(200)
+// @Param language query string false "Text search configuration to parse the query with" Enums(english, russian, simple)
+// @Param page query int false "Page number for pagination" minimum(1)
+// @Param page_size query int false "Number of results per page" minimum(1) maximum(100)
+// @Success 200 {array} dtos.SongSearchResult "Ranked search results"
+// @Header 200 {string} Link "Links to the next, previous, first and last pages"
+// @Failure 400 {object} string "Invalid input data"
+// @Failure 404 {object} string "Songs not found"
+// @Failure 500 {object} string "Internal server error"
+// @Failure 401 {object} string "Missing or invalid credentials"
+// @Failure 403 {object} string "Insufficient role"
+// @Failure 429 {object} string "Rate limit exceeded"
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/songs/search [get]
+func (h *Handler) SearchSongs(c *gin.Context) {
+	var ssdto dtos.SearchSongsDTO
+
+	if err := c.ShouldBindQuery(&ssdto); err != nil {
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": song.InvalidInputData.Error()})
+		return
+	}
+	logrusCustom.Log(c.Request.Context(), logrus.InfoLevel, fmt.Sprintf("Entered SearchSongs Hanlder with parameters: %+v", ssdto))
+
+	ssdto.SetDefaults()
+	logrusCustom.Log(c.Request.Context(), logrus.DebugLevel, fmt.Sprintf("Setted default parameters: %+v", ssdto))
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	page, err := h.useCase.SearchSongs(ctx, &ssdto)
+	if err != nil {
+
+		logrusCustom.Log(c.Request.Context(), logrus.ErrorLevel, err.Error())
+
+		if err.Error() == song.SongsNotFound.Error() {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": song.SongsNotFound.Error()})
+			return
+		}
+
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	envelope := pagination.New(page.Total, ssdto.Page, ssdto.PageSize)
+	c.Header("Link", pagination.LinkHeader(c.Request.URL, envelope))
+
+	c.JSON(http.StatusOK, gin.H{"songs": page.Results, "pagination": envelope})
 }
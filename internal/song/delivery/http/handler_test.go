@@ -1,8 +1,14 @@
 package http
 
 import (
+	"SongsLibrary/internal/auth/authenticator"
+	authhttp "SongsLibrary/internal/auth/delivery/http"
 	"SongsLibrary/internal/db/models"
+	jobconstants "SongsLibrary/internal/job/constants"
+	jobusecase "SongsLibrary/internal/job/usecase"
+	"SongsLibrary/internal/pagination"
 	"SongsLibrary/internal/song"
+	"SongsLibrary/internal/song/constants"
 	"SongsLibrary/internal/song/dtos"
 	"SongsLibrary/internal/song/usecase"
 	"SongsLibrary/internal/validators"
@@ -17,26 +23,33 @@ import (
 	"github.com/stretchr/testify/mock"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
 
 func setup() (*gin.Engine, *usecase.MockSongUseCase, *validator.Validate) {
+	r, mockUseCase, _, validate := setupWithJobs()
+
+	return r, mockUseCase, validate
+}
+
+func setupWithJobs() (*gin.Engine, *usecase.MockSongUseCase, *jobusecase.MockJobUseCase, *validator.Validate) {
 	gin.SetMode(gin.TestMode)
-	logrusCustom.InitLogger()
 
 	mockUseCase := new(usecase.MockSongUseCase)
+	mockJobUseCase := new(jobusecase.MockJobUseCase)
 
 	validate := validator.New()
 	err := validate.RegisterValidation("DateValidation", validators.DateValidation)
 	if err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(context.Background(), logrus.ErrorLevel, err.Error())
 	}
 
 	r := gin.Default()
-	RegisterHTTPEndpoints(r, mockUseCase, validate)
+	RegisterHTTPEndpoints(r, mockUseCase, mockJobUseCase, validate, authhttp.NewMiddleware(authenticator.Disabled{}, nil, nil))
 
-	return r, mockUseCase, validate
+	return r, mockUseCase, mockJobUseCase, validate
 }
 
 func performRequest(r *gin.Engine, method, url string) (*httptest.ResponseRecorder, error) {
@@ -71,7 +84,7 @@ func TestGetSongsHandler_Success(t *testing.T) {
 		},
 	}
 
-	mockUseCase.On("GetSongs", mock.Anything, &gsdto).Return(mockSongs, nil)
+	mockUseCase.On("GetSongs", mock.Anything, &gsdto).Return(&dtos.SongsPage{Songs: mockSongs, NextCursor: "next", Total: 25}, nil)
 
 	w, err := performRequest(r, http.MethodGet, "/api/songs?name=testsong&group_name=testgroup&page=1&page_size=10")
 	if err != nil {
@@ -80,12 +93,128 @@ func TestGetSongsHandler_Success(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var response map[string][]models.Song
+	var response struct {
+		Songs      []models.Song         `json:"songs"`
+		Pagination pagination.Pagination `json:"pagination"`
+	}
 	err = json.NewDecoder(w.Body).Decode(&response)
 	if err != nil {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
-	assert.Equal(t, mockSongs, response["songs"])
+	assert.Equal(t, mockSongs, response.Songs)
+	assert.Equal(t, pagination.Pagination{Total: 25, Page: 1, PageSize: 10, TotalPages: 3, NextCursor: "next"}, response.Pagination)
+
+	link := w.Header().Get("Link")
+	assert.Contains(t, link, `</api/songs?group_name=testgroup&name=testsong&page=2&page_size=10>; rel="next"`)
+	assert.Contains(t, link, `</api/songs?group_name=testgroup&name=testsong&page=3&page_size=10>; rel="last"`)
+	assert.NotContains(t, link, `rel="prev"`)
+}
+
+func TestGetSongsHandler_Cursor(t *testing.T) {
+	r, mockUseCase, _ := setup()
+
+	gsdto := dtos.GetSongsDTO{
+		Page:     1,
+		PageSize: 3,
+		Cursor:   "eyJuIjoiYSJ9",
+	}
+
+	mockUseCase.On("GetSongs", mock.Anything, &gsdto).Return(nil, song.InvalidCursor)
+
+	w, err := performRequest(r, http.MethodGet, "/api/songs?cursor=eyJuIjoiYSJ9")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGetSongsHandler_InvalidSort(t *testing.T) {
+	r, mockUseCase, _ := setup()
+
+	gsdto := dtos.GetSongsDTO{
+		Page:     1,
+		PageSize: 3,
+		Sort:     "-text",
+	}
+
+	mockUseCase.On("GetSongs", mock.Anything, &gsdto).Return(nil, song.InvalidSort)
+
+	w, err := performRequest(r, http.MethodGet, "/api/songs?sort=-text")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGetSongsHandler_ReleaseDateRange(t *testing.T) {
+	r, mockUseCase, _ := setup()
+
+	gsdto := dtos.GetSongsDTO{
+		ReleaseDateFrom: "1970-01-01",
+		ReleaseDateTo:   "1979-12-31",
+		Page:            1,
+		PageSize:        3,
+		Facets:          true,
+	}
+
+	facets := &dtos.SongFacets{
+		Authors: []dtos.FacetCount{{Value: "queen", Count: 2}},
+		Years:   []dtos.FacetCount{{Value: "1975", Count: 1}, {Value: "1977", Count: 1}},
+		Decades: []dtos.FacetCount{{Value: "1970", Count: 2}},
+	}
+
+	mockUseCase.On("GetSongs", mock.Anything, &gsdto).Return(&dtos.SongsPage{
+		Songs:  []models.Song{{ID: uuid.New(), Name: "bohemian rhapsody"}, {ID: uuid.New(), Name: "we will rock you"}},
+		Total:  2,
+		Facets: facets,
+	}, nil)
+
+	w, err := performRequest(r, http.MethodGet, "/api/songs?release_date_from=1970-01-01&release_date_to=1979-12-31&facets=true")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Facets dtos.SongFacets `json:"facets"`
+	}
+	err = json.NewDecoder(w.Body).Decode(&response)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	assert.Equal(t, *facets, response.Facets)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestGetSongsHandler_InvalidReleaseDates(t *testing.T) {
+	r, mockUseCase, _ := setup()
+
+	for _, query := range []string{"release_date_from=1980-01-01&release_date_to=1979-12-31", "decade=1975", "year=1800"} {
+		w, err := performRequest(r, http.MethodGet, "/api/songs?"+query)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+	mockUseCase.AssertNotCalled(t, "GetSongs", mock.Anything, mock.Anything)
+}
+
+func TestExportSongsHandler_InvalidSort(t *testing.T) {
+	r, mockUseCase, _ := setup()
+
+	w, err := performRequest(r, http.MethodGet, "/api/songs/export?sort=text")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUseCase.AssertNotCalled(t, "ExportSongs", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestGetSongsHandler_FailureSongsNotFound(t *testing.T) {
@@ -136,7 +265,7 @@ func TestGetSongsHandler_BindQueryError(t *testing.T) {
 func TestGetSongsHandler_ValidateQueryError(t *testing.T) {
 	r, _, _ := setup()
 
-	w, err := performRequest(r, http.MethodGet, "/api/songs?release_date=2026-01-01")
+	w, err := performRequest(r, http.MethodGet, "/api/songs?release_date=2999-01-01")
 	if err != nil {
 		t.Fatalf("Request failed: %v", err)
 	}
@@ -172,3 +301,175 @@ func TestGetSongsHandler_TimeoutFailure(t *testing.T) {
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
+
+func TestSearchSongsHandler_Success(t *testing.T) {
+	r, mockUseCase, _ := setup()
+
+	ssdto := dtos.SearchSongsDTO{
+		Query:    "fortunate son",
+		Page:     1,
+		PageSize: 10,
+	}
+
+	mockResults := []dtos.SongSearchResult{
+		{
+			Song: models.Song{
+				ID:       uuid.New(),
+				Name:     "fortunate son",
+				AuthorId: uuid.New(),
+				Author:   models.Author{GroupName: "creedence clearwater revival"},
+				Language: "english",
+			},
+			Rank:     0.8,
+			Headline: "i ain't no <b>fortunate</b> one",
+		},
+	}
+
+	mockUseCase.On("SearchSongs", mock.Anything, &ssdto).Return(&dtos.SongSearchPage{Results: mockResults, Total: 1}, nil)
+
+	w, err := performRequest(r, http.MethodGet, "/api/songs/search?q=fortunate+son")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Songs      []dtos.SongSearchResult `json:"songs"`
+		Pagination pagination.Pagination   `json:"pagination"`
+	}
+	err = json.NewDecoder(w.Body).Decode(&response)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	assert.Equal(t, mockResults, response.Songs)
+	assert.Equal(t, int64(1), response.Pagination.Total)
+}
+
+func TestSearchSongsHandler_MissingQuery(t *testing.T) {
+	r, _, _ := setup()
+
+	w, err := performRequest(r, http.MethodGet, "/api/songs/search?language=klingon")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCreateSongHandler_Async(t *testing.T) {
+	r, mockUseCase, mockJobUseCase, _ := setupWithJobs()
+
+	queuedJob := &models.Job{ID: uuid.New(), Type: jobconstants.JobTypeCreateSong, Status: jobconstants.JobStatusPending}
+	mockJobUseCase.On("EnqueueCreateSong", mock.Anything, "muse", "hysteria").Return(queuedJob, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/songs?async=true", strings.NewReader(`{"group":"Muse","song":"Hysteria"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, "/api/jobs/"+queuedJob.ID.String(), w.Header().Get("Location"))
+
+	var response map[string]models.Job
+	err := json.NewDecoder(w.Body).Decode(&response)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	assert.Equal(t, queuedJob.ID, response["job"].ID)
+
+	mockJobUseCase.AssertExpectations(t)
+	mockUseCase.AssertNotCalled(t, "CreateSong", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateSongHandler_ProviderUnavailable(t *testing.T) {
+	r, mockUseCase, _ := setup()
+
+	mockUseCase.On("CreateSong", mock.Anything, "muse", "hysteria").Return(nil, song.ProviderUnavailable)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/songs", strings.NewReader(`{"group":"Muse","song":"Hysteria"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestImportSongsHandler_Success(t *testing.T) {
+	r, mockUseCase, _ := setup()
+
+	report := &dtos.ImportReport{Created: 1, Invalid: 1, Rows: []dtos.ImportRowResult{
+		{Line: 2, Group: "muse", Song: "hysteria", Status: constants.ImportStatusCreated},
+		{Line: 3, Group: "muse", Status: constants.ImportStatusInvalid, Error: "song is required"},
+	}}
+
+	mockUseCase.On("ImportSongs", mock.Anything, mock.MatchedBy(func(rows []dtos.ImportRow) bool {
+		return len(rows) == 2 && rows[0].Error == "" && rows[0].Song.Song == "hysteria" && rows[1].Error != ""
+	})).Return(report, nil)
+
+	body := "group,song,release_date,lyrics,link\nMuse,Hysteria,2003-12-01,,\nMuse,,2003-12-01,,\n"
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/songs/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.ImportReport
+	err := json.NewDecoder(w.Body).Decode(&response)
+	if err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	assert.Equal(t, *report, response)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestImportSongsHandler_UnsupportedFormat(t *testing.T) {
+	r, _, _ := setup()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/songs/import", strings.NewReader("<songs/>"))
+	req.Header.Set("Content-Type", "application/xml")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestExportSongsHandler_NDJSON(t *testing.T) {
+	r, mockUseCase, _ := setup()
+
+	exportSongs := []dtos.ExportSong{
+		{ID: uuid.New(), Group: "muse", Song: "hysteria", ReleaseDate: "2003-12-01"},
+		{ID: uuid.New(), Group: "muse", Song: "uprising", ReleaseDate: "2009-09-07"},
+	}
+
+	mockUseCase.On("ExportSongs", mock.Anything, &dtos.GetSongsDTO{GroupName: "muse"}, mock.Anything).Return(exportSongs, nil)
+
+	w, err := performRequest(r, http.MethodGet, "/api/songs/export?format=ndjson&group_name=muse")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Len(t, lines, 2)
+
+	var decoded dtos.ExportSong
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &decoded))
+	assert.Equal(t, exportSongs[0], decoded)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestExportSongsHandler_InvalidFormat(t *testing.T) {
+	r, _, _ := setup()
+
+	w, err := performRequest(r, http.MethodGet, "/api/songs/export?format=xml")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -1,20 +1,30 @@
 package http
 
 import (
+	"SongsLibrary/internal/auth/constants"
+	authhttp "SongsLibrary/internal/auth/delivery/http"
+	"SongsLibrary/internal/job"
 	"SongsLibrary/internal/song"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 )
 
-func RegisterHTTPEndpoints(router *gin.Engine, uc song.UseCase, validator *validator.Validate) {
-	h := NewHandler(uc, validator)
+func RegisterHTTPEndpoints(router *gin.Engine, uc song.UseCase, jobUC job.UseCase, validator *validator.Validate, authMiddleware *authhttp.Middleware) {
+	h := NewHandler(uc, jobUC, validator)
 
-	authEndPoints := router.Group("/api")
+	authEndPoints := router.Group("/api", authMiddleware.Authenticate())
 	{
-		authEndPoints.GET("/songs", h.GetSongs)
-		authEndPoints.DELETE("/songs/:id", h.DeleteSong)
-		authEndPoints.PUT("/songs/:id", h.UpdateSong)
-		authEndPoints.POST("/songs", h.CreateSong)
-		authEndPoints.GET("/songs/:id/lyrics", h.GetSongLyrics)
+		reader := authMiddleware.RequireRole(constants.RoleReader)
+		editor := authMiddleware.RequireRole(constants.RoleEditor)
+		admin := authMiddleware.RequireRole(constants.RoleAdmin)
+
+		authEndPoints.GET("/songs", reader, h.GetSongs)
+		authEndPoints.GET("/songs/search", reader, h.SearchSongs)
+		authEndPoints.GET("/songs/export", reader, h.ExportSongs)
+		authEndPoints.DELETE("/songs/:id", admin, h.DeleteSong)
+		authEndPoints.PUT("/songs/:id", editor, h.UpdateSong)
+		authEndPoints.POST("/songs", editor, h.CreateSong)
+		authEndPoints.POST("/songs/import", editor, h.ImportSongs)
+		authEndPoints.GET("/songs/:id/lyrics", reader, h.GetSongLyrics)
 	}
 }
@@ -0,0 +1,42 @@
+package dtos
+
+import (
+	"SongsLibrary/internal/db/models"
+	"github.com/google/uuid"
+)
+
+// ExportSongsDTO takes the GetSongsDTO filters; page and page_size are ignored
+// because an export always covers every matching song.
+type ExportSongsDTO struct {
+	GetSongsDTO
+	Format string `form:"format" binding:"omitempty,oneof=csv json ndjson"`
+}
+
+// ExportSong is one exported record. Its keys match ImportSongDTO, so an
+// export can be imported again.
+type ExportSong struct {
+	ID          uuid.UUID `json:"id"`
+	Group       string    `json:"group"`
+	Song        string    `json:"song"`
+	ReleaseDate string    `json:"release_date"`
+	Lyrics      string    `json:"lyrics"`
+	Link        string    `json:"link"`
+	Language    string    `json:"language"`
+}
+
+func NewExportSong(s *models.Song) ExportSong {
+	exportSong := ExportSong{
+		ID:       s.ID,
+		Group:    s.Author.GroupName,
+		Song:     s.Name,
+		Lyrics:   s.Text,
+		Link:     s.Link,
+		Language: s.Language,
+	}
+
+	if !s.ReleaseDate.IsZero() {
+		exportSong.ReleaseDate = s.ReleaseDate.Format("2006-01-02")
+	}
+
+	return exportSong
+}
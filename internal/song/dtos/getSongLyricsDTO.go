@@ -19,3 +19,8 @@ func (dto *GetSongLyricsDTO) SetDefaults() {
 		dto.PageSize = constants.DefaultLyricsPageSize
 	}
 }
+
+type LyricsPage struct {
+	Verses []string
+	Total  int64
+}
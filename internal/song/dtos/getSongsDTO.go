@@ -1,18 +1,51 @@
 package dtos
 
 import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/pagination"
 	"SongsLibrary/internal/song/constants"
 )
 
 type GetSongsDTO struct {
-	Id          string `form:"id" binding:"omitempty"`
-	Name        string `form:"name" binding:"omitempty,max=100"`
-	GroupName   string `form:"group_name" binding:"omitempty,max=100"`
-	ReleaseDate string `form:"release_date" validate:"DateValidation"`
-	Text        string `form:"text" binding:"omitempty,max=10000"`
-	Link        string `form:"link" binding:"omitempty,url"`
-	Page        int    `form:"page" binding:"omitempty,min=1"`
-	PageSize    int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Id              string `form:"id" binding:"omitempty"`
+	Name            string `form:"name" binding:"omitempty,max=100"`
+	GroupName       string `form:"group_name" binding:"omitempty,max=100"`
+	ReleaseDate     string `form:"release_date" validate:"DateValidation"`
+	ReleaseDateFrom string `form:"release_date_from" validate:"DateValidation"`
+	ReleaseDateTo   string `form:"release_date_to" validate:"DateValidation"`
+	Year            int    `form:"year" binding:"omitempty,min=1900,max=9999"`
+	Decade          int    `form:"decade" binding:"omitempty,min=1900,max=9990"`
+	Text            string `form:"text" binding:"omitempty,max=10000"`
+	Link            string `form:"link" binding:"omitempty,url"`
+	Page            int    `form:"page" binding:"omitempty,min=1"`
+	PageSize        int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+	Cursor          string `form:"cursor" binding:"omitempty,max=1024"`
+	Sort            string `form:"sort" binding:"omitempty,max=200"`
+	Facets          bool   `form:"facets"`
+}
+
+// SongsPage is one page of GetSongs results. Total counts every song matching
+// the filters. NextCursor continues after the last song of the page and is
+// empty when there are no more songs.
+type SongsPage struct {
+	Songs      []models.Song
+	Total      int64
+	NextCursor string
+	Facets     *SongFacets
+}
+
+// SongFacets counts the songs matching the GetSongs filters per author group
+// name, per release year and per release decade. Authors with the most songs
+// come first; years and decades are in chronological order.
+type SongFacets struct {
+	Authors []FacetCount `json:"authors"`
+	Years   []FacetCount `json:"years"`
+	Decades []FacetCount `json:"decades"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
 }
 
 func (dto *GetSongsDTO) SetDefaults() {
@@ -23,3 +56,28 @@ func (dto *GetSongsDTO) SetDefaults() {
 		dto.PageSize = constants.DefaultSongsPageSize
 	}
 }
+
+// ReleaseDatesValid reports whether the release date range is not reversed and
+// the decade, if set, is the first year of a decade. Dates are compared as
+// strings, which works because DateValidation only lets YYYY-MM-DD through.
+func (dto *GetSongsDTO) ReleaseDatesValid() bool {
+	if dto.ReleaseDateFrom != "" && dto.ReleaseDateTo != "" && dto.ReleaseDateFrom > dto.ReleaseDateTo {
+		return false
+	}
+
+	return dto.Decade%10 == 0
+}
+
+// Pagination describes page within the request gsdto. A cursor request has no
+// page number.
+func (page *SongsPage) Pagination(gsdto *GetSongsDTO) pagination.Pagination {
+	pageNumber := gsdto.Page
+	if gsdto.Cursor != "" {
+		pageNumber = 0
+	}
+
+	envelope := pagination.New(page.Total, pageNumber, gsdto.PageSize)
+	envelope.NextCursor = page.NextCursor
+
+	return envelope
+}
@@ -0,0 +1,37 @@
+package dtos
+
+import "github.com/google/uuid"
+
+// ImportSongDTO is one row of a bulk import file. CSV files use the json
+// names as column headers.
+type ImportSongDTO struct {
+	Group       string `json:"group" binding:"required,max=100"`
+	Song        string `json:"song" binding:"required,max=100"`
+	ReleaseDate string `json:"release_date" binding:"required" validate:"DateValidation"`
+	Lyrics      string `json:"lyrics" binding:"omitempty,max=10000"`
+	Link        string `json:"link" binding:"omitempty,url"`
+}
+
+// ImportRow is a parsed row together with its line number in the file. Error
+// is set when the row could not be decoded or failed validation.
+type ImportRow struct {
+	Line  int
+	Song  ImportSongDTO
+	Error string
+}
+
+type ImportRowResult struct {
+	Line   int        `json:"line"`
+	Group  string     `json:"group,omitempty"`
+	Song   string     `json:"song,omitempty"`
+	Status string     `json:"status"`
+	SongId *uuid.UUID `json:"song_id,omitempty"`
+	Error  string     `json:"error,omitempty"`
+}
+
+type ImportReport struct {
+	Created    int               `json:"created"`
+	Duplicates int               `json:"duplicates"`
+	Invalid    int               `json:"invalid"`
+	Rows       []ImportRowResult `json:"rows"`
+}
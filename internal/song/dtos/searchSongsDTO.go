@@ -0,0 +1,35 @@
+package dtos
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/song/constants"
+)
+
+type SearchSongsDTO struct {
+	Query    string `form:"q" binding:"required,max=200"`
+	Language string `form:"language" binding:"omitempty,oneof=english russian simple"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+func (dto *SearchSongsDTO) SetDefaults() {
+	if dto.Page == 0 {
+		dto.Page = constants.DefaultSearchPage
+	}
+	if dto.PageSize == 0 {
+		dto.PageSize = constants.DefaultSearchPageSize
+	}
+}
+
+// SongSearchResult is a song matched by full-text search together with its
+// relevance rank and a lyrics snippet with the matched words wrapped in <b>.
+type SongSearchResult struct {
+	models.Song
+	Rank     float64
+	Headline string
+}
+
+type SongSearchPage struct {
+	Results []SongSearchResult
+	Total   int64
+}
@@ -6,4 +6,5 @@ type UpdateSongsDTO struct {
 	ReleaseDate string `json:"release_date" binding:"omitempty" validate:"DateValidation"`
 	Text        string `json:"text" binding:"omitempty,max=10000"`
 	Link        string `form:"link" binding:"omitempty,url"`
+	Language    string `json:"language" binding:"omitempty,oneof=english russian simple"`
 }
@@ -1,6 +1,9 @@
 package song
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	InvalidInputData      = errors.New("invalid input data")
@@ -12,4 +15,16 @@ var (
 	InvalidAuthorIdFormat = errors.New("invalid author id format")
 	ErrorGetSongData      = errors.New("error get song data")
 	ErrorGetSongLyrics    = errors.New("error get song lyrics")
+	UnsupportedImportType = errors.New("unsupported import format, expected csv or ndjson")
+	InvalidImportFile     = errors.New("invalid import file")
+	InvalidCursor         = errors.New("invalid cursor")
+	InvalidReleaseDates   = errors.New("invalid release date filters, release_date_from must not be after release_date_to and decade must be a multiple of 10")
+	InvalidSort           = errors.New("invalid sort, expected a comma separated list of name, release_date and group_name, each optionally prefixed with -")
+
+	// MetadataNotFound is returned by providers that have no match for a song,
+	// as opposed to failing to answer.
+	MetadataNotFound = fmt.Errorf("%w: no matching track", ErrorGetSongData)
+	// ProviderUnavailable is returned when a provider could not answer, e.g.
+	// it timed out, kept failing with 429 or 5xx, or its breaker is open.
+	ProviderUnavailable = errors.New("metadata provider unavailable, retry later")
 )
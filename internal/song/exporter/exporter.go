@@ -0,0 +1,120 @@
+package exporter
+
+import (
+	"SongsLibrary/internal/song/constants"
+	"SongsLibrary/internal/song/dtos"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Writer encodes exported songs one at a time. Close completes the document,
+// e.g. the closing bracket of a JSON array, and must only be called once every
+// song has been written, so that a failed export stays visibly truncated.
+type Writer interface {
+	Write(dtos.ExportSong) error
+	Close() error
+}
+
+var csvHeader = []string{"id", "group", "song", "release_date", "lyrics", "link", "language"}
+
+func NewWriter(w io.Writer, format string) (Writer, error) {
+	switch format {
+	case constants.ExportFormatCSV:
+		return newCSVWriter(w)
+	case constants.ExportFormatJSON:
+		return &jsonWriter{w: w}, nil
+	case constants.ExportFormatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+func ContentType(format string) string {
+	switch format {
+	case constants.ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case constants.ExportFormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return nil, err
+	}
+
+	return &csvWriter{writer: writer}, nil
+}
+
+func (cw *csvWriter) Write(s dtos.ExportSong) error {
+	if err := cw.writer.Write([]string{s.ID.String(), s.Group, s.Song, s.ReleaseDate, s.Lyrics, s.Link, s.Language}); err != nil {
+		return err
+	}
+
+	// Flush on every record so the csv package does not hold rows back from
+	// the caller's flushes.
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+func (cw *csvWriter) Close() error {
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+type jsonWriter struct {
+	w       io.Writer
+	written bool
+}
+
+func (jw *jsonWriter) Write(s dtos.ExportSong) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	separator := ","
+	if !jw.written {
+		separator = "["
+		jw.written = true
+	}
+
+	if _, err := io.WriteString(jw.w, separator); err != nil {
+		return err
+	}
+
+	_, err = jw.w.Write(data)
+	return err
+}
+
+func (jw *jsonWriter) Close() error {
+	closing := "]"
+	if !jw.written {
+		closing = "[]"
+	}
+
+	_, err := io.WriteString(jw.w, closing)
+	return err
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (nw *ndjsonWriter) Write(s dtos.ExportSong) error {
+	return nw.encoder.Encode(s)
+}
+
+func (nw *ndjsonWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,72 @@
+package exporter
+
+import (
+	"SongsLibrary/internal/song/constants"
+	"SongsLibrary/internal/song/dtos"
+	"bytes"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+var exportSongs = []dtos.ExportSong{
+	{ID: uuid.MustParse("7b1c3a56-4d3f-4c56-9a3e-2d1f0c9b8a71"), Group: "muse", Song: "hysteria", ReleaseDate: "2003-12-01", Lyrics: "it's bugging me,\ngrating me", Link: "https://example.com", Language: "english"},
+	{ID: uuid.MustParse("1f0e2d3c-4b5a-4968-8776-655443322110"), Group: "muse", Song: "uprising", ReleaseDate: "2009-09-07", Language: "english"},
+}
+
+func export(t *testing.T, format string, songs []dtos.ExportSong) string {
+	var buf bytes.Buffer
+
+	writer, err := NewWriter(&buf, format)
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+
+	for _, s := range songs {
+		if err := writer.Write(s); err != nil {
+			t.Fatalf("Failed to write song: %v", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close writer: %v", err)
+	}
+
+	return buf.String()
+}
+
+func TestJSONWriter(t *testing.T) {
+	var decoded []dtos.ExportSong
+	err := json.Unmarshal([]byte(export(t, constants.ExportFormatJSON, exportSongs)), &decoded)
+
+	assert.NoError(t, err)
+	assert.Equal(t, exportSongs, decoded)
+
+	assert.Equal(t, "[]", export(t, constants.ExportFormatJSON, nil))
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(export(t, constants.ExportFormatNDJSON, exportSongs)), "\n")
+
+	assert.Len(t, lines, 2)
+
+	var decoded dtos.ExportSong
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &decoded))
+	assert.Equal(t, exportSongs[1], decoded)
+}
+
+func TestCSVWriter(t *testing.T) {
+	output := export(t, constants.ExportFormatCSV, exportSongs)
+
+	assert.True(t, strings.HasPrefix(output, "id,group,song,release_date,lyrics,link,language\n"))
+	assert.Contains(t, output, "\"it's bugging me,\ngrating me\"")
+	assert.True(t, strings.HasSuffix(output, "1f0e2d3c-4b5a-4968-8776-655443322110,muse,uprising,2009-09-07,,,english\n"))
+}
+
+func TestNewWriter_UnsupportedFormat(t *testing.T) {
+	_, err := NewWriter(&bytes.Buffer{}, "xml")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,178 @@
+package importer
+
+import (
+	"SongsLibrary/internal/song"
+	"SongsLibrary/internal/song/constants"
+	"SongsLibrary/internal/song/dtos"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// DetectFormat picks the import format from an explicit value, then from the
+// file extension and finally from the content type.
+func DetectFormat(format, fileName, contentType string) (string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(fileName)) {
+		case ".csv":
+			format = constants.ImportFormatCSV
+		case ".ndjson", ".jsonl":
+			format = constants.ImportFormatNDJSON
+		}
+	}
+
+	if format == "" {
+		switch {
+		case strings.HasPrefix(contentType, "text/csv"):
+			format = constants.ImportFormatCSV
+		case strings.HasPrefix(contentType, "application/x-ndjson"), strings.HasPrefix(contentType, "application/jsonl"):
+			format = constants.ImportFormatNDJSON
+		}
+	}
+
+	format = strings.ToLower(format)
+	if format != constants.ImportFormatCSV && format != constants.ImportFormatNDJSON {
+		return "", song.UnsupportedImportType
+	}
+
+	return format, nil
+}
+
+// Parse reads every row of r and validates it with the same rules as the
+// HTTP and gRPC deliveries. Rows that fail are returned with Error set; an
+// error is only returned when the file as a whole cannot be read.
+func Parse(r io.Reader, format string, validate *validator.Validate) ([]dtos.ImportRow, error) {
+	var rows []dtos.ImportRow
+	var err error
+
+	switch format {
+	case constants.ImportFormatCSV:
+		rows, err = parseCSV(r)
+	case constants.ImportFormatNDJSON:
+		rows, err = parseNDJSON(r)
+	default:
+		return nil, song.UnsupportedImportType
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		if rows[i].Error != "" {
+			continue
+		}
+
+		rows[i].Song.Group = strings.ToLower(strings.TrimSpace(rows[i].Song.Group))
+		rows[i].Song.Song = strings.ToLower(strings.TrimSpace(rows[i].Song.Song))
+		// Lyrics are stored lowercase like on every other write path, as the
+		// text filter of GetSongs is matched against lowercase lyrics.
+		rows[i].Song.Lyrics = strings.ToLower(rows[i].Song.Lyrics)
+
+		if err := binding.Validator.ValidateStruct(rows[i].Song); err != nil {
+			rows[i].Error = err.Error()
+			continue
+		}
+
+		if err := validate.Struct(rows[i].Song); err != nil {
+			rows[i].Error = err.Error()
+		}
+	}
+
+	return rows, nil
+}
+
+func parseCSV(r io.Reader) ([]dtos.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", song.InvalidImportFile, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	for _, required := range []string{"group", "song"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", song.InvalidImportFile, required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	var rows []dtos.ImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rows = append(rows, dtos.ImportRow{Line: parseErr.StartLine, Error: parseErr.Err.Error()})
+				continue
+			}
+
+			return nil, fmt.Errorf("%w: %v", song.InvalidImportFile, err)
+		}
+
+		rows = append(rows, dtos.ImportRow{
+			Line: line,
+			Song: dtos.ImportSongDTO{
+				Group:       field(record, "group"),
+				Song:        field(record, "song"),
+				ReleaseDate: field(record, "release_date"),
+				Lyrics:      field(record, "lyrics"),
+				Link:        field(record, "link"),
+			},
+		})
+	}
+
+	return rows, nil
+}
+
+func parseNDJSON(r io.Reader) ([]dtos.ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), constants.MaxImportFileSize)
+
+	var rows []dtos.ImportRow
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		row := dtos.ImportRow{Line: line}
+		if err := json.Unmarshal(data, &row.Song); err != nil {
+			row.Error = err.Error()
+		}
+
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", song.InvalidImportFile, err)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,102 @@
+package importer
+
+import (
+	"SongsLibrary/internal/song"
+	"SongsLibrary/internal/song/constants"
+	"SongsLibrary/internal/validators"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func newValidator(t *testing.T) *validator.Validate {
+	validate := validator.New()
+	if err := validate.RegisterValidation("DateValidation", validators.DateValidation); err != nil {
+		t.Fatalf("Failed to register validation: %v", err)
+	}
+	return validate
+}
+
+func TestParseCSV(t *testing.T) {
+	input := "group,song,release_date,lyrics,link\n" +
+		"Muse,Hysteria,2003-12-01,\"It's bugging me\ngrating me\",https://example.com/hysteria\n" +
+		"Muse,,2003-12-01,,\n" +
+		"Muse,Uprising,2999-01-01,,\n" +
+		"Muse,Starlight,2006-09-04,,not a link\n"
+
+	rows, err := Parse(strings.NewReader(input), constants.ImportFormatCSV, newValidator(t))
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 4)
+
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Empty(t, rows[0].Error)
+	assert.Equal(t, "muse", rows[0].Song.Group)
+	assert.Equal(t, "hysteria", rows[0].Song.Song)
+	assert.Equal(t, "it's bugging me\ngrating me", rows[0].Song.Lyrics)
+
+	assert.Equal(t, 4, rows[1].Line)
+	assert.NotEmpty(t, rows[1].Error, "missing song name")
+	assert.NotEmpty(t, rows[2].Error, "release date in the future")
+	assert.NotEmpty(t, rows[3].Error, "invalid link")
+}
+
+func TestParseCSV_MissingColumn(t *testing.T) {
+	_, err := Parse(strings.NewReader("group,release_date\nmuse,2003-12-01\n"), constants.ImportFormatCSV, newValidator(t))
+
+	assert.True(t, errors.Is(err, song.InvalidImportFile))
+}
+
+func TestParseNDJSON(t *testing.T) {
+	input := `{"group":"Muse","song":"Hysteria","release_date":"2003-12-01","lyrics":"It's bugging me"}` + "\n" +
+		"\n" +
+		`{"group":"Muse","song":` + "\n" +
+		`{"group":"Muse","song":"Uprising"}` + "\n"
+
+	rows, err := Parse(strings.NewReader(input), constants.ImportFormatNDJSON, newValidator(t))
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+
+	assert.Equal(t, 1, rows[0].Line)
+	assert.Empty(t, rows[0].Error)
+	assert.Equal(t, "hysteria", rows[0].Song.Song)
+
+	assert.Equal(t, 3, rows[1].Line)
+	assert.NotEmpty(t, rows[1].Error, "malformed JSON")
+
+	assert.Equal(t, 4, rows[2].Line)
+	assert.NotEmpty(t, rows[2].Error, "missing release date")
+}
+
+// TestParse_LyricsSearchable filters imported lyrics the way GetSongs does:
+// the text filter is lowercased and matched case-sensitively.
+func TestParse_LyricsSearchable(t *testing.T) {
+	input := `{"group":"Muse","song":"Hysteria","release_date":"2003-12-01","lyrics":"It's Bugging Me, Grating Me"}` + "\n"
+
+	rows, err := Parse(strings.NewReader(input), constants.ImportFormatNDJSON, newValidator(t))
+
+	assert.NoError(t, err)
+	if assert.Len(t, rows, 1) {
+		assert.Contains(t, rows[0].Song.Lyrics, strings.ToLower("Bugging Me, GRATING"))
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	format, err := DetectFormat("", "songs.CSV", "")
+	assert.NoError(t, err)
+	assert.Equal(t, constants.ImportFormatCSV, format)
+
+	format, err = DetectFormat("", "", "application/x-ndjson")
+	assert.NoError(t, err)
+	assert.Equal(t, constants.ImportFormatNDJSON, format)
+
+	format, err = DetectFormat("ndjson", "songs.csv", "text/csv")
+	assert.NoError(t, err)
+	assert.Equal(t, constants.ImportFormatNDJSON, format)
+
+	_, err = DetectFormat("", "songs.xml", "application/xml")
+	assert.Equal(t, song.UnsupportedImportType, err)
+}
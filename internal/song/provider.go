@@ -0,0 +1,68 @@
+package song
+
+import (
+	"context"
+	"time"
+)
+
+// Metadata is what a MetadataProvider knows about a track. Providers fill
+// only the fields they have; empty fields are completed by the next provider.
+type Metadata struct {
+	Providers   []string
+	TrackId     string
+	TrackName   string
+	ArtistName  string
+	ReleaseDate time.Time
+	Link        string
+	Lyrics      string
+}
+
+type MetadataProvider interface {
+	Name() string
+	GetSongMetadata(ctx context.Context, groupName, songName string) (*Metadata, error)
+}
+
+// Merge copies into m every field that m is still missing.
+func (m *Metadata) Merge(other *Metadata) {
+	m.Providers = append(m.Providers, other.Providers...)
+
+	if m.TrackId == "" {
+		m.TrackId = other.TrackId
+	}
+	if m.TrackName == "" {
+		m.TrackName = other.TrackName
+	}
+	if m.ArtistName == "" {
+		m.ArtistName = other.ArtistName
+	}
+	if m.ReleaseDate.IsZero() {
+		m.ReleaseDate = other.ReleaseDate
+	}
+	if m.Link == "" {
+		m.Link = other.Link
+	}
+	if m.Lyrics == "" {
+		m.Lyrics = other.Lyrics
+	}
+}
+
+func (m *Metadata) IsComplete() bool {
+	return m.TrackName != "" && m.ArtistName != "" && !m.ReleaseDate.IsZero() && m.Link != "" && m.Lyrics != ""
+}
+
+type knownMetadataKey struct{}
+
+// NewKnownMetadataContext returns a copy of ctx carrying the metadata found by
+// the providers asked before, so that a provider can build on it, e.g. fetch
+// lyrics by the TrackId another provider found.
+func NewKnownMetadataContext(ctx context.Context, known Metadata) context.Context {
+	return context.WithValue(ctx, knownMetadataKey{}, known)
+}
+
+// KnownMetadata returns the metadata carried by ctx, which is empty when no
+// provider was asked before.
+func KnownMetadata(ctx context.Context) Metadata {
+	known, _ := ctx.Value(knownMetadataKey{}).(Metadata)
+
+	return known
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/providercache"
+	"SongsLibrary/internal/song"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+// CachedProvider answers from the provider response cache before asking the
+// wrapped provider, and stores the answers it gets for ttl. "Not found"
+// answers are cached for negativeTTL, or not at all when it is zero. The cache
+// is best effort: when it fails, the provider is asked as if it missed.
+type CachedProvider struct {
+	provider    song.MetadataProvider
+	responses   providercache.Repository
+	ttl         time.Duration
+	negativeTTL time.Duration
+}
+
+func NewCachedProvider(provider song.MetadataProvider, responses providercache.Repository, ttl, negativeTTL time.Duration) *CachedProvider {
+	return &CachedProvider{
+		provider:    provider,
+		responses:   responses,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+	}
+}
+
+// Name is the name of the wrapped provider, so that the cache is transparent
+// to the provider order.
+func (cp *CachedProvider) Name() string {
+	return cp.provider.Name()
+}
+
+func (cp *CachedProvider) GetSongMetadata(ctx context.Context, groupName, songName string) (*song.Metadata, error) {
+	queryKey := providercache.NormalizeQuery(groupName, songName)
+
+	// An answer built on the track id found by an earlier provider may leave
+	// out what the provider finds on its own, so it is cached apart.
+	if trackId := song.KnownMetadata(ctx).TrackId; trackId != "" {
+		queryKey += "|track:" + trackId
+	}
+
+	cached, err := cp.responses.GetResponse(ctx, cp.Name(), queryKey)
+	switch {
+	case err == nil && cached.NotFound:
+		logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Provider cache hit for %s %s: not found", cp.Name(), queryKey))
+
+		return nil, song.MetadataNotFound
+	case err == nil:
+		var metadata song.Metadata
+		if err := json.Unmarshal([]byte(cached.Payload), &metadata); err == nil {
+			logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Provider cache hit for %s %s", cp.Name(), queryKey))
+
+			return &metadata, nil
+		}
+
+		logrusCustom.Log(ctx, logrus.WarnLevel, fmt.Sprintf("Ignoring unreadable cached response of %s for %s", cp.Name(), queryKey))
+	case !errors.Is(err, providercache.ResponseNotFound):
+		logrusCustom.Log(ctx, logrus.WarnLevel, fmt.Sprintf("Provider cache lookup failed: %s", err.Error()))
+	}
+
+	metadata, err := cp.provider.GetSongMetadata(ctx, groupName, songName)
+	switch {
+	case errors.Is(err, song.MetadataNotFound):
+		if cp.negativeTTL > 0 {
+			cp.save(ctx, &models.ProviderResponse{Provider: cp.Name(), QueryKey: queryKey, NotFound: true, ExpiresAt: time.Now().Add(cp.negativeTTL)})
+		}
+
+		return nil, err
+	case err != nil:
+		return nil, err
+	}
+
+	payload, err := json.Marshal(metadata)
+	if err == nil {
+		cp.save(ctx, &models.ProviderResponse{Provider: cp.Name(), QueryKey: queryKey, Payload: string(payload), ExpiresAt: time.Now().Add(cp.ttl)})
+	}
+
+	return metadata, nil
+}
+
+func (cp *CachedProvider) save(ctx context.Context, response *models.ProviderResponse) {
+	if err := cp.responses.SaveResponse(ctx, response); err != nil {
+		logrusCustom.Log(ctx, logrus.WarnLevel, fmt.Sprintf("Failed to cache response of %s for %s: %s", response.Provider, response.QueryKey, err.Error()))
+	}
+}
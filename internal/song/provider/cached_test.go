@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/providercache"
+	"SongsLibrary/internal/providercache/repository/postgres"
+	"SongsLibrary/internal/song"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestCachedProvider_Hit(t *testing.T) {
+
+	inner := &MockProvider{ProviderName: MusixmatchName}
+	mockRepo := new(postgres.MockRepository)
+
+	mockRepo.On("GetResponse", mock.Anything, MusixmatchName, "muse|hysteria").Return(&models.ProviderResponse{
+		Payload: `{"Providers":["musixmatch"],"TrackName":"hysteria","Lyrics":"it's bugging me"}`,
+	}, nil)
+
+	metadata, err := NewCachedProvider(inner, mockRepo, time.Hour, time.Hour).GetSongMetadata(context.Background(), " Muse ", "HYSTERIA")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hysteria", metadata.TrackName)
+	inner.AssertNotCalled(t, "GetSongMetadata", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCachedProvider_MissStoresResponse(t *testing.T) {
+
+	inner := &MockProvider{ProviderName: GeniusName}
+	mockRepo := new(postgres.MockRepository)
+
+	inner.On("GetSongMetadata", mock.Anything, "muse", "hysteria").Return(&song.Metadata{Providers: []string{GeniusName}, TrackName: "hysteria"}, nil)
+	mockRepo.On("GetResponse", mock.Anything, GeniusName, "muse|hysteria").Return(nil, providercache.ResponseNotFound)
+	mockRepo.On("SaveResponse", mock.Anything, mock.MatchedBy(func(r *models.ProviderResponse) bool {
+		return r.Provider == GeniusName && !r.NotFound && r.Payload != "" && time.Until(r.ExpiresAt) > 59*time.Minute
+	})).Return(nil)
+
+	metadata, err := NewCachedProvider(inner, mockRepo, time.Hour, time.Minute).GetSongMetadata(context.Background(), "muse", "hysteria")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hysteria", metadata.TrackName)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCachedProvider_NegativeCaching(t *testing.T) {
+
+	inner := &MockProvider{ProviderName: GeniusName}
+	mockRepo := new(postgres.MockRepository)
+
+	inner.On("GetSongMetadata", mock.Anything, "muse", "unknown").Return(nil, song.MetadataNotFound)
+	mockRepo.On("GetResponse", mock.Anything, GeniusName, "muse|unknown").Return(nil, providercache.ResponseNotFound).Once()
+	mockRepo.On("SaveResponse", mock.Anything, mock.MatchedBy(func(r *models.ProviderResponse) bool { return r.NotFound })).Return(nil)
+	mockRepo.On("GetResponse", mock.Anything, GeniusName, "muse|unknown").Return(&models.ProviderResponse{NotFound: true}, nil).Once()
+
+	cached := NewCachedProvider(inner, mockRepo, time.Hour, time.Minute)
+
+	_, err := cached.GetSongMetadata(context.Background(), "muse", "unknown")
+	assert.ErrorIs(t, err, song.MetadataNotFound)
+
+	_, err = cached.GetSongMetadata(context.Background(), "muse", "unknown")
+	assert.ErrorIs(t, err, song.MetadataNotFound)
+
+	inner.AssertNumberOfCalls(t, "GetSongMetadata", 1)
+}
+
+func TestCachedProvider_FailuresNotCached(t *testing.T) {
+
+	inner := &MockProvider{ProviderName: MusixmatchName}
+	mockRepo := new(postgres.MockRepository)
+
+	inner.On("GetSongMetadata", mock.Anything, "muse", "hysteria").Return(nil, song.ErrorGetSongData)
+	mockRepo.On("GetResponse", mock.Anything, MusixmatchName, "muse|hysteria").Return(nil, errors.New("db down"))
+
+	_, err := NewCachedProvider(inner, mockRepo, time.Hour, time.Hour).GetSongMetadata(context.Background(), "muse", "hysteria")
+
+	assert.Equal(t, song.ErrorGetSongData, err)
+	mockRepo.AssertNotCalled(t, "SaveResponse", mock.Anything, mock.Anything)
+}
+
+func TestCachedProvider_KnownTrackCachedApart(t *testing.T) {
+
+	inner := &MockProvider{ProviderName: MusixmatchName}
+	mockRepo := new(postgres.MockRepository)
+
+	known := song.NewKnownMetadataContext(context.Background(), song.Metadata{Providers: []string{SongDataName}, TrackId: "42"})
+
+	inner.On("GetSongMetadata", known, "muse", "hysteria").Return(&song.Metadata{Providers: []string{MusixmatchName}, TrackId: "42", Lyrics: "it's bugging me"}, nil)
+	inner.On("GetSongMetadata", context.Background(), "muse", "hysteria").Return(&song.Metadata{Providers: []string{MusixmatchName}, TrackId: "42", TrackName: "hysteria", ArtistName: "muse", Lyrics: "it's bugging me"}, nil)
+	mockRepo.On("GetResponse", mock.Anything, MusixmatchName, "muse|hysteria|track:42").Return(nil, providercache.ResponseNotFound)
+	mockRepo.On("GetResponse", mock.Anything, MusixmatchName, "muse|hysteria").Return(nil, providercache.ResponseNotFound)
+	mockRepo.On("SaveResponse", mock.Anything, mock.Anything).Return(nil)
+
+	cached := NewCachedProvider(inner, mockRepo, time.Hour, time.Hour)
+
+	metadata, err := cached.GetSongMetadata(known, "muse", "hysteria")
+	assert.NoError(t, err)
+	assert.Empty(t, metadata.TrackName)
+
+	metadata, err = cached.GetSongMetadata(context.Background(), "muse", "hysteria")
+	assert.NoError(t, err)
+	assert.Equal(t, "hysteria", metadata.TrackName, "the partial answer of the known track is not served to a plain lookup")
+
+	mockRepo.AssertCalled(t, "SaveResponse", mock.Anything, mock.MatchedBy(func(r *models.ProviderResponse) bool { return r.QueryKey == "muse|hysteria|track:42" }))
+	mockRepo.AssertCalled(t, "SaveResponse", mock.Anything, mock.MatchedBy(func(r *models.ProviderResponse) bool { return r.QueryKey == "muse|hysteria" }))
+	inner.AssertNumberOfCalls(t, "GetSongMetadata", 2)
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"SongsLibrary/internal/song"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const GeniusName = "genius"
+
+// GeniusProvider searches Genius for a track. Genius does not serve lyrics
+// through its API, so it only contributes names, release date and link.
+type GeniusProvider struct {
+	baseURL                string
+	getSongReleaseDatePath string
+	authorization          string
+	client                 *http.Client
+}
+
+func NewGeniusProvider(baseURL, getSongReleaseDatePath, authorization string, client *http.Client) *GeniusProvider {
+	return &GeniusProvider{
+		baseURL:                baseURL,
+		getSongReleaseDatePath: getSongReleaseDatePath,
+		authorization:          authorization,
+		client:                 client,
+	}
+}
+
+type GetSongReleaseDateResult struct {
+	Response struct {
+		HitsList []HitWrapper `json:"hits"`
+	} `json:"response"`
+}
+
+type HitWrapper struct {
+	Result Result `json:"result"`
+}
+
+type Result struct {
+	Title         string `json:"title"`
+	Url           string `json:"url"`
+	PrimaryArtist struct {
+		Name string `json:"name"`
+	} `json:"primary_artist"`
+	ReleaseDateComponents struct {
+		Year  int `json:"year"`
+		Month int `json:"month"`
+		Day   int `json:"day"`
+	} `json:"release_date_components"`
+}
+
+func (gp *GeniusProvider) Name() string {
+	return GeniusName
+}
+
+func (gp *GeniusProvider) GetSongMetadata(ctx context.Context, groupName, songName string) (*song.Metadata, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetSongMetadata Genius provider with parameters: groupName:%s, song:%s", groupName, songName))
+
+	geniusUrl := fmt.Sprintf(gp.baseURL+gp.getSongReleaseDatePath, url.QueryEscape(songName), url.QueryEscape(groupName))
+	logrusCustom.Log(ctx, logrus.DebugLevel, fmt.Sprintf("Builded GetSongReleaseDate URL: geniusUrl:%s", geniusUrl))
+
+	reqGenius, err := http.NewRequestWithContext(ctx, "GET", geniusUrl, nil)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+		return nil, song.ErrorGetSongData
+	}
+	reqGenius.Header.Set("Authorization", gp.authorization)
+
+	respGenius, err := gp.client.Do(reqGenius)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+		return nil, song.ProviderUnavailable
+	}
+	defer respGenius.Body.Close()
+
+	bodyGenius, err := io.ReadAll(respGenius.Body)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+		return nil, song.ErrorGetSongData
+	}
+
+	if respGenius.StatusCode != http.StatusOK {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, fmt.Sprintf("unexpected status code: %d, body: %s", respGenius.StatusCode, bodyGenius))
+		return nil, statusError(respGenius.StatusCode, song.ErrorGetSongData)
+	}
+
+	var getSongReleaseDateResult GetSongReleaseDateResult
+	if err := json.Unmarshal(bodyGenius, &getSongReleaseDateResult); err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+		return nil, song.ErrorGetSongData
+	}
+
+	if len(getSongReleaseDateResult.Response.HitsList) == 0 {
+		return nil, song.MetadataNotFound
+	}
+
+	result := getSongReleaseDateResult.Response.HitsList[0].Result
+
+	metadata := &song.Metadata{
+		Providers:  []string{GeniusName},
+		TrackName:  strings.ToLower(result.Title),
+		ArtistName: strings.ToLower(result.PrimaryArtist.Name),
+		Link:       result.Url,
+	}
+
+	rdc := result.ReleaseDateComponents
+	if rdc.Year != 0 {
+		metadata.ReleaseDate = time.Date(rdc.Year, time.Month(max(rdc.Month, 1)), max(rdc.Day, 1), 0, 0, 0, 0, time.UTC)
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetSongMetadata Genius provider with release date: %s", metadata.ReleaseDate))
+
+	return metadata, nil
+}
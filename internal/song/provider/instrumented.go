@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"SongsLibrary/internal/metrics"
+	"SongsLibrary/internal/metrics/constants"
+	"SongsLibrary/internal/song"
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+var tracer = otel.Tracer("SongsLibrary/internal/song/provider")
+
+// InstrumentedProvider records the outcome and latency of the calls to the
+// wrapped provider and traces them. It goes beneath the response cache so that
+// only calls leaving the service are counted.
+type InstrumentedProvider struct {
+	provider song.MetadataProvider
+	metrics  *metrics.Metrics
+}
+
+func NewInstrumentedProvider(provider song.MetadataProvider, metrics *metrics.Metrics) *InstrumentedProvider {
+	return &InstrumentedProvider{provider: provider, metrics: metrics}
+}
+
+func (ip *InstrumentedProvider) Name() string {
+	return ip.provider.Name()
+}
+
+func (ip *InstrumentedProvider) GetSongMetadata(ctx context.Context, groupName, songName string) (*song.Metadata, error) {
+	started := time.Now()
+
+	ctx, span := tracer.Start(ctx, "MetadataProvider.GetSongMetadata", trace.WithAttributes(attribute.String("provider", ip.Name())))
+	defer span.End()
+
+	metadata, err := ip.provider.GetSongMetadata(ctx, groupName, songName)
+
+	outcome := constants.OutcomeSuccess
+	switch {
+	case err == nil:
+	case errors.Is(err, song.MetadataNotFound):
+		outcome = constants.OutcomeNotFound
+	case errors.Is(err, song.ProviderUnavailable):
+		outcome = constants.OutcomeUnavailable
+	default:
+		outcome = constants.OutcomeError
+	}
+
+	span.SetAttributes(attribute.String("outcome", outcome))
+	if outcome == constants.OutcomeError || outcome == constants.OutcomeUnavailable {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	ip.metrics.ObserveProviderCall(ip.Name(), outcome, time.Since(started))
+
+	return metadata, err
+}
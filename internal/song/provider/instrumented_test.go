@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"SongsLibrary/internal/metrics"
+	"SongsLibrary/internal/song"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInstrumentedProvider_RecordsOutcomes(t *testing.T) {
+	m := metrics.New()
+
+	inner := &MockProvider{ProviderName: GeniusName}
+	inner.On("GetSongMetadata", mock.Anything, "muse", "hysteria").Return(&song.Metadata{Link: "https://genius.com/Muse-hysteria-lyrics"}, nil)
+	inner.On("GetSongMetadata", mock.Anything, "muse", "unknown").Return(nil, song.MetadataNotFound)
+	inner.On("GetSongMetadata", mock.Anything, "muse", "uprising").Return(nil, song.ProviderUnavailable)
+
+	instrumented := NewInstrumentedProvider(inner, m)
+	assert.Equal(t, GeniusName, instrumented.Name())
+
+	metadata, err := instrumented.GetSongMetadata(context.Background(), "muse", "hysteria")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://genius.com/Muse-hysteria-lyrics", metadata.Link)
+
+	_, err = instrumented.GetSongMetadata(context.Background(), "muse", "unknown")
+	assert.ErrorIs(t, err, song.MetadataNotFound)
+
+	_, err = instrumented.GetSongMetadata(context.Background(), "muse", "uprising")
+	assert.ErrorIs(t, err, song.ProviderUnavailable)
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	for _, line := range []string{
+		`provider_calls_total{outcome="success",provider="genius"} 1`,
+		`provider_calls_total{outcome="not_found",provider="genius"} 1`,
+		`provider_calls_total{outcome="unavailable",provider="genius"} 1`,
+		`provider_call_duration_seconds_count{provider="genius"} 3`,
+	} {
+		assert.Contains(t, w.Body.String(), line)
+	}
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"SongsLibrary/internal/song"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"os"
+	"strings"
+	"time"
+)
+
+const LocalName = "local"
+
+// LocalSong is one entry of the JSON file served by LocalProvider.
+type LocalSong struct {
+	Group       string `json:"group"`
+	Song        string `json:"song"`
+	ReleaseDate string `json:"release_date"`
+	Lyrics      string `json:"lyrics"`
+	Link        string `json:"link"`
+}
+
+// LocalProvider serves metadata from a JSON array of LocalSong loaded at
+// startup, so songs can be created without any network access.
+type LocalProvider struct {
+	songs map[string]*song.Metadata
+}
+
+func NewLocalProvider(path string) (*LocalProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read local provider file: %w", err)
+	}
+
+	var localSongs []LocalSong
+	if err := json.Unmarshal(data, &localSongs); err != nil {
+		return nil, fmt.Errorf("parse local provider file %s: %w", path, err)
+	}
+
+	songs := make(map[string]*song.Metadata, len(localSongs))
+	for i, localSong := range localSongs {
+		metadata := &song.Metadata{
+			Providers:  []string{LocalName},
+			TrackName:  strings.ToLower(localSong.Song),
+			ArtistName: strings.ToLower(localSong.Group),
+			Link:       localSong.Link,
+			Lyrics:     strings.ToLower(localSong.Lyrics),
+		}
+
+		if localSong.ReleaseDate != "" {
+			metadata.ReleaseDate, err = time.Parse("2006-01-02", localSong.ReleaseDate)
+			if err != nil {
+				return nil, fmt.Errorf("parse release date of entry %d in %s: %w", i, path, err)
+			}
+		}
+
+		songs[localKey(localSong.Group, localSong.Song)] = metadata
+	}
+
+	return &LocalProvider{songs: songs}, nil
+}
+
+func (lp *LocalProvider) Name() string {
+	return LocalName
+}
+
+func (lp *LocalProvider) GetSongMetadata(ctx context.Context, groupName, songName string) (*song.Metadata, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetSongMetadata Local provider with parameters: groupName:%s, song:%s", groupName, songName))
+
+	metadata, ok := lp.songs[localKey(groupName, songName)]
+	if !ok {
+		return nil, song.MetadataNotFound
+	}
+
+	found := *metadata
+	return &found, nil
+}
+
+func localKey(groupName, songName string) string {
+	return strings.ToLower(strings.TrimSpace(groupName)) + "\x00" + strings.ToLower(strings.TrimSpace(songName))
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"SongsLibrary/internal/song"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLocalProvider_GetSongMetadata(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "songs.json")
+	err := os.WriteFile(path, []byte(`[
+		{"group": "Creedence Clearwater Revival", "song": "Fortunate Son", "release_date": "1969-11-02", "lyrics": "Some folks are born", "link": "http://example.com"}
+	]`), 0o644)
+	if err != nil {
+		t.Fatalf("Failed to write songs file: %v", err)
+	}
+
+	lp, err := NewLocalProvider(path)
+	if err != nil {
+		t.Fatalf("Failed to create local provider: %v", err)
+	}
+
+	metadata, err := lp.GetSongMetadata(context.Background(), "creedence clearwater revival", " fortunate son ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "fortunate son", metadata.TrackName)
+	assert.Equal(t, "some folks are born", metadata.Lyrics)
+	assert.Equal(t, time.Date(1969, 11, 2, 0, 0, 0, 0, time.UTC), metadata.ReleaseDate)
+
+	_, err = lp.GetSongMetadata(context.Background(), "creedence clearwater revival", "unknown")
+	assert.ErrorIs(t, err, song.ErrorGetSongData)
+}
@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"SongsLibrary/internal/song"
+	"context"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProvider struct {
+	mock.Mock
+	ProviderName string
+}
+
+func (m *MockProvider) Name() string {
+	return m.ProviderName
+}
+
+func (m *MockProvider) GetSongMetadata(ctx context.Context, groupName, songName string) (*song.Metadata, error) {
+	args := m.Called(ctx, groupName, songName)
+	if metadata, ok := args.Get(0).(*song.Metadata); ok {
+		return metadata, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"SongsLibrary/internal/song"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const MusixmatchName = "musixmatch"
+
+type MusixmatchProvider struct {
+	baseURL       string
+	getSongIPPath string
+	getLyricsPath string
+	apiKey        string
+	client        *http.Client
+}
+
+func NewMusixmatchProvider(baseURL, getSongIPPath, getLyricsPath, apiKey string, client *http.Client) *MusixmatchProvider {
+	return &MusixmatchProvider{
+		baseURL:       baseURL,
+		getSongIPPath: getSongIPPath,
+		getLyricsPath: getLyricsPath,
+		apiKey:        apiKey,
+		client:        client,
+	}
+}
+
+type GetSongIPResult struct {
+	Message struct {
+		Body struct {
+			TrackList []TrackWrapper `json:"track_list"`
+		} `json:"body"`
+	} `json:"message"`
+}
+
+type TrackWrapper struct {
+	Track Track `json:"track"`
+}
+
+type Track struct {
+	Id          int    `json:"commontrack_id"`
+	ReleaseDate string `json:"updated_time"`
+	Link        string `json:"track_share_url"`
+	TrackName   string `json:"track_name"`
+	ArtistName  string `json:"artist_name"`
+}
+
+type GetSongLyricsResult struct {
+	Message struct {
+		Body struct {
+			Lyrics struct {
+				LyricsBody string `json:"lyrics_body"`
+			} `json:"lyrics"`
+		} `json:"body"`
+	} `json:"message"`
+}
+
+func (mp *MusixmatchProvider) Name() string {
+	return MusixmatchName
+}
+
+func (mp *MusixmatchProvider) GetSongMetadata(ctx context.Context, groupName, songName string) (*song.Metadata, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetSongMetadata Musixmatch provider with parameters: groupName:%s, song:%s", groupName, songName))
+
+	// SongData answers with the Musixmatch id of the track, which is all the
+	// lyrics lookup needs.
+	if trackId := song.KnownMetadata(ctx).TrackId; trackId != "" {
+		lyrics, err := mp.GetLyrics(ctx, trackId)
+		if err != nil {
+			return nil, err
+		}
+
+		logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetSongMetadata Musixmatch provider with lyrics of known track: ip:%s", trackId))
+
+		return &song.Metadata{Providers: []string{MusixmatchName}, TrackId: trackId, Lyrics: lyrics}, nil
+	}
+
+	track, err := mp.searchTrack(ctx, groupName, songName)
+	if err != nil {
+		return nil, err
+	}
+
+	trackId := strconv.Itoa(track.Id)
+
+	lyrics, err := mp.GetLyrics(ctx, trackId)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &song.Metadata{
+		Providers:  []string{MusixmatchName},
+		TrackId:    trackId,
+		TrackName:  strings.ToLower(track.TrackName),
+		ArtistName: strings.ToLower(track.ArtistName),
+		Link:       track.Link,
+		Lyrics:     lyrics,
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetSongMetadata Musixmatch provider with song data: ip:%s, link:%s", metadata.TrackId, metadata.Link))
+
+	return metadata, nil
+}
+
+func (mp *MusixmatchProvider) searchTrack(ctx context.Context, groupName, songName string) (*Track, error) {
+	musixMatchUrl := fmt.Sprintf(mp.baseURL+mp.getSongIPPath, url.QueryEscape(groupName), url.QueryEscape(songName), mp.apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", musixMatchUrl, nil)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+		return nil, song.ErrorGetSongData
+	}
+
+	resp, err := mp.client.Do(req)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, requestError(err).Error())
+		return nil, song.ProviderUnavailable
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+		return nil, song.ErrorGetSongData
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, fmt.Sprintf("unexpected status code: %d, body: %s", resp.StatusCode, body))
+		return nil, statusError(resp.StatusCode, song.ErrorGetSongData)
+	}
+
+	var getSongIPResult GetSongIPResult
+	if err := json.Unmarshal(body, &getSongIPResult); err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+		return nil, song.ErrorGetSongData
+	}
+
+	if len(getSongIPResult.Message.Body.TrackList) == 0 {
+		return nil, song.MetadataNotFound
+	}
+
+	return &getSongIPResult.Message.Body.TrackList[0].Track, nil
+}
+
+func (mp *MusixmatchProvider) GetLyrics(ctx context.Context, ip string) (string, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetLyrics Musixmatch provider with parameter: ip:%s", ip))
+
+	musixMatchUrl := fmt.Sprintf(mp.baseURL+mp.getLyricsPath, ip, mp.apiKey)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", musixMatchUrl, nil)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return "", song.ErrorGetSongLyrics
+	}
+
+	resp, err := mp.client.Do(req)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, requestError(err).Error())
+
+		return "", song.ProviderUnavailable
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return "", song.ErrorGetSongLyrics
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, fmt.Sprintf("unexpected status code: %d, body: %s", resp.StatusCode, body))
+
+		return "", statusError(resp.StatusCode, song.ErrorGetSongLyrics)
+	}
+
+	var getSongLyricsResult GetSongLyricsResult
+	if err := json.Unmarshal(body, &getSongLyricsResult); err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, fmt.Sprintf("unexpected status code: %d, body: %s", resp.StatusCode, body))
+
+		return "", song.ErrorGetSongLyrics
+	}
+
+	lyricsBodyBorder := "\n...\n\n******* This Lyrics is NOT for Commercial use *******"
+	lyricsBody := getSongLyricsResult.Message.Body.Lyrics.LyricsBody
+
+	lyricsBody = strings.Split(lyricsBody, lyricsBodyBorder)[0]
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetLyrics Musixmatch provider with song lyrics: %+v", lyricsBody))
+
+	return strings.ToLower(lyricsBody), nil
+}
+
+// requestError drops the URL a *url.Error is reported with, as the URLs of
+// Musixmatch carry the API key.
+func requestError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Errorf("%s request: %w", urlErr.Op, urlErr.Err)
+	}
+
+	return err
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"SongsLibrary/internal/song"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const testMusixmatchKey = "musixmatch-secret"
+
+func newMusixmatchServer(searches *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/track.search":
+			searches.Add(1)
+			w.Write([]byte(`{"message":{"body":{"track_list":[{"track":{"commontrack_id":42,"track_name":"Hysteria","artist_name":"Muse","track_share_url":"http://musixmatch.example.com/42"}}]}}}`))
+		case "/track.lyrics.get":
+			if r.URL.Query().Get("commontrack_id") != "42" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(`{"message":{"body":{"lyrics":{"lyrics_body":"It's bugging me"}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newTestMusixmatchProvider(server *httptest.Server) *MusixmatchProvider {
+	return NewMusixmatchProvider(server.URL+"/", "track.search?q_artist=%s&q_track=%s&apikey=%s", "track.lyrics.get?commontrack_id=%s&apikey=%s", testMusixmatchKey, server.Client())
+}
+
+func TestMusixmatchProvider_SearchesTrack(t *testing.T) {
+
+	var searches atomic.Int32
+	server := newMusixmatchServer(&searches)
+	defer server.Close()
+
+	log, hook := test.NewNullLogger()
+	log.SetLevel(logrus.DebugLevel)
+	ctx := logrusCustom.NewContext(context.Background(), logrus.NewEntry(log))
+
+	metadata, err := newTestMusixmatchProvider(server).GetSongMetadata(ctx, "muse", "hysteria")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), searches.Load())
+	assert.Equal(t, "42", metadata.TrackId)
+	assert.Equal(t, "hysteria", metadata.TrackName)
+	assert.Equal(t, "it's bugging me", metadata.Lyrics)
+
+	for _, entry := range hook.AllEntries() {
+		assert.False(t, strings.Contains(entry.Message, testMusixmatchKey), "the API key is never logged: %s", entry.Message)
+	}
+}
+
+func TestMusixmatchProvider_KnownTrackSkipsSearch(t *testing.T) {
+
+	var searches atomic.Int32
+	server := newMusixmatchServer(&searches)
+	defer server.Close()
+
+	ctx := song.NewKnownMetadataContext(context.Background(), song.Metadata{Providers: []string{SongDataName}, TrackId: "42", TrackName: "hysteria"})
+
+	metadata, err := newTestMusixmatchProvider(server).GetSongMetadata(ctx, "muse", "hysteria")
+
+	assert.NoError(t, err)
+	assert.Zero(t, searches.Load(), "the track id of SongData is used as it is")
+	assert.Equal(t, "42", metadata.TrackId)
+	assert.Equal(t, "it's bugging me", metadata.Lyrics)
+}
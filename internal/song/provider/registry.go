@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"SongsLibrary/internal/song"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
+)
+
+const RegistryName = "registry"
+
+// Registry asks its providers in order and merges their answers until the
+// metadata is complete. Every provider is given what the previous ones found
+// through song.KnownMetadata. A failing provider is skipped, so later providers
+// act as fallbacks.
+type Registry struct {
+	providers []song.MetadataProvider
+}
+
+func NewRegistry(providers ...song.MetadataProvider) *Registry {
+	return &Registry{providers: providers}
+}
+
+// NewRegistryFromOrder builds a Registry from a comma-separated list of
+// provider names, e.g. "local,musixmatch,genius".
+func NewRegistryFromOrder(order string, available ...song.MetadataProvider) (*Registry, error) {
+	byName := make(map[string]song.MetadataProvider, len(available))
+	for _, provider := range available {
+		byName[provider.Name()] = provider
+	}
+
+	var providers []song.MetadataProvider
+	for _, name := range strings.Split(order, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		provider, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown or unconfigured metadata provider %q", name)
+		}
+
+		providers = append(providers, provider)
+	}
+
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("no metadata providers configured")
+	}
+
+	return NewRegistry(providers...), nil
+}
+
+func (r *Registry) Name() string {
+	return RegistryName
+}
+
+func (r *Registry) GetSongMetadata(ctx context.Context, groupName, songName string) (*song.Metadata, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetSongMetadata Registry with parameters: groupName:%s, song:%s", groupName, songName))
+
+	metadata := &song.Metadata{}
+	unavailable := false
+
+	for _, provider := range r.providers {
+		providerMetadata, err := provider.GetSongMetadata(song.NewKnownMetadataContext(ctx, *metadata), groupName, songName)
+		if err != nil {
+			logrusCustom.Log(ctx, logrus.WarnLevel, fmt.Sprintf("Metadata provider %s failed: %s", provider.Name(), err.Error()))
+
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			unavailable = unavailable || errors.Is(err, song.ProviderUnavailable)
+
+			continue
+		}
+
+		metadata.Merge(providerMetadata)
+
+		if metadata.IsComplete() {
+			break
+		}
+	}
+
+	// A provider that could not answer might have had the missing fields, so
+	// the lookup is worth retrying rather than reported as not found.
+	if unavailable && (metadata.TrackName == "" || metadata.ArtistName == "" || metadata.ReleaseDate.IsZero() || metadata.Lyrics == "") {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, song.ProviderUnavailable.Error())
+
+		return nil, song.ProviderUnavailable
+	}
+
+	// Songs are stored with their release date, so a song no provider dated
+	// is as incomplete as one without a name.
+	if metadata.TrackName == "" || metadata.ArtistName == "" || metadata.ReleaseDate.IsZero() {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, song.ErrorGetSongData.Error())
+
+		return nil, song.ErrorGetSongData
+	}
+
+	if metadata.Lyrics == "" {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, song.ErrorGetSongLyrics.Error())
+
+		return nil, song.ErrorGetSongLyrics
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetSongMetadata Registry with metadata from: %s", strings.Join(metadata.Providers, ",")))
+
+	return metadata, nil
+}
+
+// statusError maps an unexpected status code of a provider to the error to
+// return: throttling and server errors are transient, anything else is
+// reported as fallback.
+func statusError(code int, fallback error) error {
+	if code == http.StatusTooManyRequests || code >= http.StatusInternalServerError {
+		return song.ProviderUnavailable
+	}
+
+	return fallback
+}
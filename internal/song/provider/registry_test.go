@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"SongsLibrary/internal/song"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestRegistry_MergesProvidersInOrder(t *testing.T) {
+
+	first := &MockProvider{ProviderName: MusixmatchName}
+	second := &MockProvider{ProviderName: GeniusName}
+
+	first.On("GetSongMetadata", mock.Anything, "testgroup", "testsong").Return(&song.Metadata{
+		Providers:  []string{MusixmatchName},
+		TrackName:  "testsong",
+		ArtistName: "testgroup",
+		Link:       "http://musixmatch.example.com",
+		Lyrics:     "test lyrics",
+	}, nil)
+	second.On("GetSongMetadata", mock.Anything, "testgroup", "testsong").Return(&song.Metadata{
+		Providers:   []string{GeniusName},
+		TrackName:   "other name",
+		ReleaseDate: time.Date(1981, 9, 23, 0, 0, 0, 0, time.UTC),
+		Link:        "http://genius.example.com",
+	}, nil)
+
+	metadata, err := NewRegistry(first, second).GetSongMetadata(context.Background(), "testgroup", "testsong")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "testsong", metadata.TrackName)
+	assert.Equal(t, "http://musixmatch.example.com", metadata.Link)
+	assert.Equal(t, time.Date(1981, 9, 23, 0, 0, 0, 0, time.UTC), metadata.ReleaseDate)
+	assert.Equal(t, []string{MusixmatchName, GeniusName}, metadata.Providers)
+}
+
+func TestRegistry_FallsBackOnError(t *testing.T) {
+
+	failing := &MockProvider{ProviderName: MusixmatchName}
+	fallback := &MockProvider{ProviderName: LocalName}
+	unused := &MockProvider{ProviderName: GeniusName}
+
+	failing.On("GetSongMetadata", mock.Anything, "testgroup", "testsong").Return(nil, song.ErrorGetSongData)
+	fallback.On("GetSongMetadata", mock.Anything, "testgroup", "testsong").Return(&song.Metadata{
+		Providers:   []string{LocalName},
+		TrackName:   "testsong",
+		ArtistName:  "testgroup",
+		ReleaseDate: time.Date(1981, 9, 23, 0, 0, 0, 0, time.UTC),
+		Link:        "http://example.com",
+		Lyrics:      "test lyrics",
+	}, nil)
+
+	metadata, err := NewRegistry(failing, fallback, unused).GetSongMetadata(context.Background(), "testgroup", "testsong")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{LocalName}, metadata.Providers)
+	unused.AssertNotCalled(t, "GetSongMetadata", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRegistry_NoLyrics(t *testing.T) {
+
+	withoutLyrics := &MockProvider{ProviderName: SongDataName}
+	withoutLyrics.On("GetSongMetadata", mock.Anything, "testgroup", "testsong").Return(&song.Metadata{
+		Providers:   []string{SongDataName},
+		TrackName:   "testsong",
+		ArtistName:  "testgroup",
+		ReleaseDate: time.Date(1981, 9, 23, 0, 0, 0, 0, time.UTC),
+	}, nil)
+
+	_, err := NewRegistry(withoutLyrics).GetSongMetadata(context.Background(), "testgroup", "testsong")
+
+	assert.ErrorIs(t, err, song.ErrorGetSongLyrics)
+}
+
+func TestNewRegistryFromOrder_UnknownProvider(t *testing.T) {
+	_, err := NewRegistryFromOrder("local,unknown", &MockProvider{ProviderName: LocalName})
+
+	assert.Error(t, err)
+}
+
+func TestRegistry_UnavailableProviderIsNotNotFound(t *testing.T) {
+
+	down := &MockProvider{ProviderName: MusixmatchName}
+	partial := &MockProvider{ProviderName: GeniusName}
+
+	down.On("GetSongMetadata", mock.Anything, "testgroup", "testsong").Return(nil, song.ProviderUnavailable)
+	partial.On("GetSongMetadata", mock.Anything, "testgroup", "testsong").Return(&song.Metadata{
+		Providers:  []string{GeniusName},
+		TrackName:  "testsong",
+		ArtistName: "testgroup",
+	}, nil)
+
+	_, err := NewRegistry(down, partial).GetSongMetadata(context.Background(), "testgroup", "testsong")
+
+	assert.Equal(t, song.ProviderUnavailable, err)
+}
+
+func TestRegistry_PassesKnownMetadata(t *testing.T) {
+
+	first := &MockProvider{ProviderName: SongDataName}
+	second := &MockProvider{ProviderName: MusixmatchName}
+
+	first.On("GetSongMetadata", mock.Anything, "testgroup", "testsong").Return(&song.Metadata{
+		Providers:   []string{SongDataName},
+		TrackId:     "42",
+		TrackName:   "testsong",
+		ArtistName:  "testgroup",
+		ReleaseDate: time.Date(1981, 9, 23, 0, 0, 0, 0, time.UTC),
+	}, nil)
+	second.On("GetSongMetadata", mock.MatchedBy(func(ctx context.Context) bool {
+		return song.KnownMetadata(ctx).TrackId == "42"
+	}), "testgroup", "testsong").Return(&song.Metadata{
+		Providers: []string{MusixmatchName},
+		TrackId:   "42",
+		Lyrics:    "test lyrics",
+	}, nil)
+
+	metadata, err := NewRegistry(first, second).GetSongMetadata(context.Background(), "testgroup", "testsong")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "test lyrics", metadata.Lyrics)
+	second.AssertExpectations(t)
+}
+
+func TestRegistry_NoReleaseDate(t *testing.T) {
+
+	withoutDate := &MockProvider{ProviderName: MusixmatchName}
+	withoutDate.On("GetSongMetadata", mock.Anything, "testgroup", "testsong").Return(&song.Metadata{
+		Providers:  []string{MusixmatchName},
+		TrackName:  "testsong",
+		ArtistName: "testgroup",
+		Lyrics:     "test lyrics",
+	}, nil)
+
+	_, err := NewRegistry(withoutDate).GetSongMetadata(context.Background(), "testgroup", "testsong")
+
+	assert.ErrorIs(t, err, song.ErrorGetSongData)
+
+	down := &MockProvider{ProviderName: GeniusName}
+	down.On("GetSongMetadata", mock.Anything, "testgroup", "testsong").Return(nil, song.ProviderUnavailable)
+
+	_, err = NewRegistry(withoutDate, down).GetSongMetadata(context.Background(), "testgroup", "testsong")
+
+	assert.Equal(t, song.ProviderUnavailable, err, "the provider that could not answer might know the date")
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"SongsLibrary/internal/song"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"fmt"
+	songv1pb "github.com/DanKo-code/Protobuf-For-Songs-Library-Upgraded/protos/gen/go/song"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"time"
+)
+
+const SongDataName = "songdata"
+
+// SongDataProvider asks the SongData gRPC service for track metadata. The
+// service does not return lyrics, so it has to be followed by a provider that
+// does.
+type SongDataProvider struct {
+	client songv1pb.SongDataClient
+}
+
+func NewSongDataProvider(conn *grpc.ClientConn) *SongDataProvider {
+	return &SongDataProvider{client: songv1pb.NewSongDataClient(conn)}
+}
+
+func (sdp *SongDataProvider) Name() string {
+	return SongDataName
+}
+
+func (sdp *SongDataProvider) GetSongMetadata(ctx context.Context, groupName, songName string) (*song.Metadata, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetSongMetadata SongData provider with parameters: groupName:%s, song:%s", groupName, songName))
+
+	getSongDataResponse, err := sdp.client.GetSongData(ctx, &songv1pb.GetSongDataRequest{Group: groupName, SongName: songName})
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		switch status.Code(err) {
+		case codes.NotFound:
+			return nil, song.MetadataNotFound
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+			return nil, song.ProviderUnavailable
+		}
+
+		return nil, song.ErrorGetSongData
+	}
+
+	metadata := &song.Metadata{
+		Providers:  []string{SongDataName},
+		TrackId:    getSongDataResponse.GetIp(),
+		TrackName:  strings.ToLower(getSongDataResponse.GetTrackName()),
+		ArtistName: strings.ToLower(getSongDataResponse.GetArtistName()),
+		Link:       getSongDataResponse.GetLink(),
+	}
+
+	if releaseDate := getSongDataResponse.GetReleaseDate(); releaseDate != "" {
+		metadata.ReleaseDate, err = time.Parse("2006-01-02 15:04:05 -0700 MST", releaseDate)
+		if err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+		}
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetSongMetadata SongData provider with song data: ip:%s, link:%s", metadata.TrackId, metadata.Link))
+
+	return metadata, nil
+}
@@ -9,10 +9,13 @@ import (
 )
 
 type Repository interface {
-	GetSongs(context.Context, *dtos.GetSongsDTO) ([]models.Song, error)
+	GetSongs(context.Context, *dtos.GetSongsDTO) (*dtos.SongsPage, error)
 	DeleteSong(ctx context.Context, id uuid.UUID) (*models.Song, error)
 	UpdateSong(context.Context, *models.Song) (*models.Song, error)
 	CreateSong(ctx context.Context, releaseDate time.Time, group string, songName string, lyrics string, link string) (*models.Song, error)
 	GetSong(context.Context, uuid.UUID) (*models.Song, error)
 	GetAuthorByName(ctx context.Context, authorName string) (*models.Author, error)
+	SearchSongs(context.Context, *dtos.SearchSongsDTO) (*dtos.SongSearchPage, error)
+	ImportSongs(ctx context.Context, rows []dtos.ImportRow) ([]dtos.ImportRowResult, error)
+	ExportSongs(ctx context.Context, gsdto *dtos.GetSongsDTO, emit func(*models.Song) error) (int, error)
 }
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/pagination"
+	"SongsLibrary/internal/song"
+	"SongsLibrary/internal/song/constants"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// songSortColumns maps sort fields to columns. "id" is not a public sort field
+// but is appended to every sort to make the order total.
+var songSortColumns = map[string]string{
+	"name":         "song.name",
+	"release_date": "song.release_date",
+	"group_name":   "author.group_name",
+	"id":           "song.id",
+}
+
+// songCursor is the keyset position after which the next page starts: the
+// sort it was created for and the sort values of the last song. It is handed
+// to clients base64-encoded so they treat it as opaque.
+type songCursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+func sortValue(s *models.Song, field string) string {
+	switch field {
+	case "name":
+		return s.Name
+	case "release_date":
+		return s.ReleaseDate.UTC().Format(time.RFC3339Nano)
+	case "group_name":
+		return s.Author.GroupName
+	default:
+		return s.ID.String()
+	}
+}
+
+func encodeCursor(keys []pagination.SortKey, lastSong *models.Song) string {
+	cursor := songCursor{Sort: pagination.String(keys), Values: make([]string, len(keys))}
+	for i, key := range keys {
+		cursor.Values[i] = sortValue(lastSong, key.Field)
+	}
+
+	data, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor returns the typed sort values stored in cursor. The cursor is
+// rejected when it was created for a different sort.
+func decodeCursor(cursor string, keys []pagination.SortKey) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, song.InvalidCursor
+	}
+
+	var decoded songCursor
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, song.InvalidCursor
+	}
+
+	if decoded.Sort != pagination.String(keys) || len(decoded.Values) != len(keys) {
+		return nil, song.InvalidCursor
+	}
+
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		switch key.Field {
+		case "release_date":
+			values[i], err = time.Parse(time.RFC3339Nano, decoded.Values[i])
+		case "id":
+			values[i], err = uuid.Parse(decoded.Values[i])
+		default:
+			values[i] = decoded.Values[i]
+		}
+		if err != nil {
+			return nil, song.InvalidCursor
+		}
+	}
+
+	return values, nil
+}
+
+// keysetCondition selects the rows that come after values in the order given
+// by keys. Keys may mix directions, so the condition is spelled out as
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... rather than a row comparison.
+func keysetCondition(keys []pagination.SortKey, values []interface{}) (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+
+	for i, key := range keys {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, songSortColumns[keys[j].Field]+" = ?")
+			args = append(args, values[j])
+		}
+
+		operator := ">"
+		if key.Desc {
+			operator = "<"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s ?", songSortColumns[key.Field], operator))
+		args = append(args, values[i])
+
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// songSortKeys parses the sort parameter, falling back to fallback, and
+// appends the id tiebreaker.
+func songSortKeys(sort, fallback string) ([]pagination.SortKey, error) {
+	if sort == "" {
+		sort = fallback
+	}
+
+	keys, err := pagination.ParseSort(sort, constants.SongSortFields)
+	if err != nil {
+		return nil, song.InvalidSort
+	}
+
+	return append(keys, pagination.SortKey{Field: "id"}), nil
+}
+
+func orderClause(keys []pagination.SortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = songSortColumns[key.Field]
+		if key.Desc {
+			parts[i] += " DESC"
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func sortsByGroupName(keys []pagination.SortKey) bool {
+	for _, key := range keys {
+		if key.Field == "group_name" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -13,10 +13,10 @@ type MockRepository struct {
 	mock.Mock
 }
 
-func (m *MockRepository) GetSongs(ctx context.Context, gsdto *dtos.GetSongsDTO) ([]models.Song, error) {
+func (m *MockRepository) GetSongs(ctx context.Context, gsdto *dtos.GetSongsDTO) (*dtos.SongsPage, error) {
 	args := m.Called(ctx, gsdto)
-	if songs, ok := args.Get(0).([]models.Song); ok {
-		return songs, args.Error(1)
+	if page, ok := args.Get(0).(*dtos.SongsPage); ok {
+		return page, args.Error(1)
 	}
 	return nil, args.Error(1)
 }
@@ -60,3 +60,36 @@ func (m *MockRepository) GetAuthorByName(ctx context.Context, authorName string)
 	}
 	return nil, args.Error(1)
 }
+
+func (m *MockRepository) SearchSongs(ctx context.Context, ssdto *dtos.SearchSongsDTO) (*dtos.SongSearchPage, error) {
+	args := m.Called(ctx, ssdto)
+	if page, ok := args.Get(0).(*dtos.SongSearchPage); ok {
+		return page, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockRepository) ImportSongs(ctx context.Context, rows []dtos.ImportRow) ([]dtos.ImportRowResult, error) {
+	args := m.Called(ctx, rows)
+	if results, ok := args.Get(0).([]dtos.ImportRowResult); ok {
+		return results, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ExportSongs passes the songs given to Return, if any, to emit.
+func (m *MockRepository) ExportSongs(ctx context.Context, gsdto *dtos.GetSongsDTO, emit func(*models.Song) error) (int, error) {
+	args := m.Called(ctx, gsdto, emit)
+
+	songs, _ := args.Get(0).([]models.Song)
+
+	exported := 0
+	for i := range songs {
+		if err := emit(&songs[i]); err != nil {
+			return exported, err
+		}
+		exported++
+	}
+
+	return exported, args.Error(1)
+}
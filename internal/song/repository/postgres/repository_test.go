@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/song"
+	"SongsLibrary/internal/song/constants"
 	"SongsLibrary/internal/song/dtos"
 	"context"
 	"github.com/google/uuid"
@@ -33,12 +35,62 @@ func TestGetSongsRepository_Success(t *testing.T) {
 		},
 	}
 
-	mockRepo.On("GetSongs", mock.Anything, gsdto).Return(mockSongs, nil)
+	mockRepo.On("GetSongs", mock.Anything, gsdto).Return(&dtos.SongsPage{Songs: mockSongs}, nil)
 
 	ctx := context.Background()
-	songs, err := mockRepo.GetSongs(ctx, gsdto)
+	page, err := mockRepo.GetSongs(ctx, gsdto)
 
 	assert.NoError(t, err)
-	assert.Equal(t, mockSongs, songs)
+	assert.Equal(t, mockSongs, page.Songs)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestSongCursor_RoundTrip(t *testing.T) {
+	keys, err := songSortKeys("-release_date,name", constants.DefaultSongsSort)
+	assert.NoError(t, err)
+
+	lastSong := &models.Song{ID: uuid.New(), Name: "девочка - пай", ReleaseDate: time.Date(1999, 4, 1, 0, 0, 0, 0, time.UTC)}
+
+	values, err := decodeCursor(encodeCursor(keys, lastSong), keys)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{lastSong.ReleaseDate, lastSong.Name, lastSong.ID}, values)
+}
+
+func TestSongCursor_Invalid(t *testing.T) {
+	keys, _ := songSortKeys("", constants.DefaultSongsSort)
+
+	for _, cursor := range []string{"not base64!", "bm90IGpzb24", "eyJuIjoiYSJ9"} {
+		_, err := decodeCursor(cursor, keys)
+
+		assert.Equal(t, song.InvalidCursor, err, cursor)
+	}
+}
+
+func TestSongCursor_SortMismatch(t *testing.T) {
+	nameKeys, _ := songSortKeys("name", constants.DefaultSongsSort)
+	dateKeys, _ := songSortKeys("-release_date", constants.DefaultSongsSort)
+
+	_, err := decodeCursor(encodeCursor(nameKeys, &models.Song{ID: uuid.New(), Name: "hysteria"}), dateKeys)
+
+	assert.Equal(t, song.InvalidCursor, err)
+}
+
+func TestSongSortKeys_Invalid(t *testing.T) {
+	for _, sort := range []string{"id", "text", "name,-name"} {
+		_, err := songSortKeys(sort, constants.DefaultSongsSort)
+
+		assert.Equal(t, song.InvalidSort, err, sort)
+	}
+}
+
+func TestKeysetCondition_MixedDirections(t *testing.T) {
+	keys, _ := songSortKeys("-release_date,name", constants.DefaultSongsSort)
+	values := []interface{}{"2003-12-01", "hysteria", "id"}
+
+	condition, args := keysetCondition(keys, values)
+
+	assert.Equal(t, "((song.release_date < ?) OR (song.release_date = ? AND song.name > ?) OR (song.release_date = ? AND song.name = ? AND song.id > ?))", condition)
+	assert.Equal(t, []interface{}{"2003-12-01", "2003-12-01", "hysteria", "2003-12-01", "hysteria", "id"}, args)
+	assert.Equal(t, "song.release_date DESC, song.name, song.id", orderClause(keys))
+}
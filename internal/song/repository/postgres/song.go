@@ -2,9 +2,12 @@ package postgres
 
 import (
 	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/outbox"
+	outboxconstants "SongsLibrary/internal/outbox/constants"
 	"SongsLibrary/internal/song"
 	"SongsLibrary/internal/song/constants"
 	"SongsLibrary/internal/song/dtos"
+	"SongsLibrary/internal/song/textsearch"
 	logrusCustom "SongsLibrary/pkg/logger"
 	"context"
 	"errors"
@@ -13,6 +16,7 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"strings"
 	"time"
 )
@@ -25,86 +29,269 @@ func NewSongRepository(db *gorm.DB) *SongRepository {
 	return &SongRepository{db: db}
 }
 
-func (sr *SongRepository) GetSongs(ctx context.Context, gsdto *dtos.GetSongsDTO) ([]models.Song, error) {
+// GetSongs returns one page of songs in the requested sort order, by name
+// unless specified, with the song id as final tiebreaker. With a cursor the
+// page starts right after the cursor position (keyset pagination) and page is
+// ignored; otherwise page and page_size select it with OFFSET. One extra row
+// is read to find out whether a next page exists. Facets, when requested, are
+// counted over every song matching the filters, not just the page.
+func (sr *SongRepository) GetSongs(ctx context.Context, gsdto *dtos.GetSongsDTO) (*dtos.SongsPage, error) {
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered GetSongs Repository with parameters: %+v", gsdto))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetSongs Repository with parameters: %+v", gsdto))
+
+	keys, err := songSortKeys(gsdto.Sort, constants.DefaultSongsSort)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
 
 	var songs []models.Song
 
-	query := sr.db.WithContext(ctx).Model(&models.Song{})
+	query := applySongFilters(sr.db.WithContext(ctx).Model(&models.Song{}), gsdto)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	var facets *dtos.SongFacets
+	if gsdto.Facets {
+		facets, err = songFacets(query)
+		if err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+			return nil, err
+		}
+	}
+
+	if sortsByGroupName(keys) {
+		query = query.Joins("JOIN author ON author.id = song.author_id")
+	}
+
+	if gsdto.Cursor != "" {
+		after, err := decodeCursor(gsdto.Cursor, keys)
+		if err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+			return nil, err
+		}
+
+		condition, args := keysetCondition(keys, after)
+		query = query.Where(condition, args...)
+	} else {
+		offset := (gsdto.Page - 1) * gsdto.PageSize
+		query = query.Offset(offset)
+	}
+
+	query = query.Order(orderClause(keys)).Limit(gsdto.PageSize + 1)
+
+	query = query.Preload("Author")
+
+	if err := query.Find(&songs).Error; err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	if len(songs) == 0 {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, song.SongsNotFound.Error())
+
+		return nil, song.SongsNotFound
+	}
+
+	page := &dtos.SongsPage{Songs: songs, Total: total, Facets: facets}
+	if len(songs) > gsdto.PageSize {
+		page.Songs = songs[:gsdto.PageSize]
+		page.NextCursor = encodeCursor(keys, &page.Songs[gsdto.PageSize-1])
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetSongs Repository with songs: %+v, total: %d, next cursor: %s", page.Songs, page.Total, page.NextCursor))
+
+	return page, nil
+}
+
+// yearStart is January 1st of year. Years and decades are filtered as date
+// ranges rather than with EXTRACT so an index on release_date can be used.
+func yearStart(year int) time.Time {
+	return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// songFacets counts the songs selected by the filtered query per author, per
+// release year and per release decade.
+func songFacets(query *gorm.DB) (*dtos.SongFacets, error) {
+	var facets dtos.SongFacets
+
+	if err := query.Session(&gorm.Session{}).
+		Joins("JOIN author ON author.id = song.author_id").
+		Select("author.group_name AS value, COUNT(*) AS count").
+		Group("author.group_name").
+		Order("count DESC, value").
+		Limit(constants.MaxAuthorFacets).
+		Scan(&facets.Authors).Error; err != nil {
+		return nil, err
+	}
+
+	if err := query.Session(&gorm.Session{}).
+		Select("EXTRACT(YEAR FROM song.release_date)::int::text AS value, COUNT(*) AS count").
+		Group("value").
+		Order("value").
+		Scan(&facets.Years).Error; err != nil {
+		return nil, err
+	}
+
+	if err := query.Session(&gorm.Session{}).
+		Select("(FLOOR(EXTRACT(YEAR FROM song.release_date) / 10) * 10)::int::text AS value, COUNT(*) AS count").
+		Group("value").
+		Order("value").
+		Scan(&facets.Decades).Error; err != nil {
+		return nil, err
+	}
+
+	return &facets, nil
+}
 
+// applySongFilters adds the GetSongsDTO filters, except paging, to a query on
+// the song table.
+func applySongFilters(query *gorm.DB, gsdto *dtos.GetSongsDTO) *gorm.DB {
 	if gsdto.Id != "" {
-		query = query.Where("id LIKE ?", "%"+gsdto.Id+"%")
+		query = query.Where("song.id::text LIKE ?", "%"+gsdto.Id+"%")
 	}
 	if gsdto.Name != "" {
-		query = query.Where("name LIKE ?", "%"+gsdto.Name+"%")
+		query = query.Where("song.name LIKE ?", "%"+gsdto.Name+"%")
 	}
 	if gsdto.GroupName != "" {
-		query = query.Joins("JOIN author ON author.id = song.author_id").Where("author.group_name LIKE ?", "%"+strings.ToLower(gsdto.GroupName)+"%")
+		query = query.Where("song.author_id IN (SELECT id FROM author WHERE group_name LIKE ?)", "%"+strings.ToLower(gsdto.GroupName)+"%")
 	}
 	if gsdto.ReleaseDate != "" {
-		query = query.Where("release_date = ?", gsdto.ReleaseDate)
+		query = query.Where("song.release_date = ?", gsdto.ReleaseDate)
+	}
+	if gsdto.ReleaseDateFrom != "" {
+		query = query.Where("song.release_date >= ?", gsdto.ReleaseDateFrom)
+	}
+	if gsdto.ReleaseDateTo != "" {
+		// The bound is a date, so the whole of that day is included whatever the
+		// time of the release.
+		query = query.Where("song.release_date < ?::date + interval '1 day'", gsdto.ReleaseDateTo)
+	}
+	if gsdto.Year != 0 {
+		query = query.Where("song.release_date >= ? AND song.release_date < ?", yearStart(gsdto.Year), yearStart(gsdto.Year+1))
+	}
+	if gsdto.Decade != 0 {
+		query = query.Where("song.release_date >= ? AND song.release_date < ?", yearStart(gsdto.Decade), yearStart(gsdto.Decade+10))
 	}
 	if gsdto.Text != "" {
-		query = query.Where("text LIKE ?", "%"+gsdto.Text+"%")
+		query = query.Where("song.text LIKE ?", "%"+gsdto.Text+"%")
 	}
 	if gsdto.Link != "" {
-		query = query.Where("link LIKE ?", "%"+gsdto.Link+"%")
+		query = query.Where("song.link LIKE ?", "%"+gsdto.Link+"%")
 	}
 
-	offset := (gsdto.Page - 1) * gsdto.PageSize
-	query = query.Offset(offset).Limit(gsdto.PageSize)
+	return query
+}
+
+// ExportSongs streams every song matching the filters of gsdto to emit, in the
+// requested sort order or by group and song name. Paging fields are ignored. Rows are read from a
+// cursor one at a time, so the result set is never held in memory.
+func (sr *SongRepository) ExportSongs(ctx context.Context, gsdto *dtos.GetSongsDTO, emit func(*models.Song) error) (int, error) {
 
-	query = query.Debug().Preload("Author")
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered ExportSongs Repository with parameters: %+v", gsdto))
 
-	if err := query.Find(&songs).Error; err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+	keys, err := songSortKeys(gsdto.Sort, constants.DefaultExportSort)
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
 
-		return nil, err
+		return 0, err
 	}
 
-	if len(songs) == 0 {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, song.SongsNotFound.Error())
+	rows, err := applySongFilters(sr.db.WithContext(ctx).Model(&models.Song{}), gsdto).
+		Select("song.id, song.name, song.author_id, author.group_name, song.release_date, song.text, song.link, song.language::text").
+		Joins("JOIN author ON author.id = song.author_id").
+		Order(orderClause(keys)).
+		Rows()
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
 
-		return nil, song.SongsNotFound
+		return 0, err
 	}
+	defer rows.Close()
+
+	exported := 0
+	for rows.Next() {
+		var songToExport models.Song
+		if err := rows.Scan(
+			&songToExport.ID,
+			&songToExport.Name,
+			&songToExport.AuthorId,
+			&songToExport.Author.GroupName,
+			&songToExport.ReleaseDate,
+			&songToExport.Text,
+			&songToExport.Link,
+			&songToExport.Language,
+		); err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+			return exported, err
+		}
+		songToExport.Author.ID = songToExport.AuthorId
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Exiting GetSongs Repository with songs: %+v", songs))
+		if err := emit(&songToExport); err != nil {
+			return exported, err
+		}
+		exported++
+	}
+
+	if err := rows.Err(); err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return exported, err
+	}
 
-	return songs, nil
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting ExportSongs Repository with %d songs exported", exported))
+
+	return exported, nil
 }
 
 func (sr *SongRepository) DeleteSong(ctx context.Context, id uuid.UUID) (*models.Song, error) {
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered DeleteSong Repository with parameter: %s", id.String()))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered DeleteSong Repository with parameter: %s", id.String()))
 
 	var songToDelete models.Song
 
-	if err := sr.db.WithContext(ctx).Debug().Preload("Author").First(&songToDelete, "id = ?", id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			logrusCustom.LogWithLocation(logrus.ErrorLevel, song.SongsNotFound.Error())
+	err := sr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Preload("Author").First(&songToDelete, "id = ?", id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				logrusCustom.Log(ctx, logrus.ErrorLevel, song.SongsNotFound.Error())
 
-			return nil, song.SongsNotFound
+				return song.SongsNotFound
+			}
+
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+			return err
 		}
 
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
-		return nil, err
-	}
+		if err := tx.Delete(&models.Song{}, id).Error; err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
 
-	if err := sr.db.WithContext(ctx).Debug().Delete(&models.Song{}, id).Error; err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, song.SongsNotFound.Error())
+			return err
+		}
 
+		return addEvent(tx, outboxconstants.SongDeleted, &songToDelete)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Exiting DeleteSongs Repository with deleted song: %+v", songToDelete))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting DeleteSongs Repository with deleted song: %+v", songToDelete))
 
 	return &songToDelete, nil
 }
 
 func (sr *SongRepository) UpdateSong(ctx context.Context, fieldsToUpdate *models.Song) (*models.Song, error) {
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered UpdateSong Repository with parameter: %+v", fieldsToUpdate))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered UpdateSong Repository with parameter: %+v", fieldsToUpdate))
 
 	dataToUpdate := make(map[string]interface{})
 
@@ -128,102 +315,259 @@ func (sr *SongRepository) UpdateSong(ctx context.Context, fieldsToUpdate *models
 		dataToUpdate["link"] = fieldsToUpdate.Link
 	}
 
-	result := sr.db.WithContext(ctx).Debug().
-		Model(&models.Song{}).
-		Where("id = ?", fieldsToUpdate.ID).
-		Updates(dataToUpdate)
-	if result.Error != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, result.Error.Error())
-
-		return nil, result.Error
+	if fieldsToUpdate.Language != "" {
+		dataToUpdate["language"] = fieldsToUpdate.Language
+	} else if fieldsToUpdate.Text != "" {
+		dataToUpdate["language"] = textsearch.DetectLanguage(fieldsToUpdate.Text)
 	}
 
-	if result.RowsAffected == 0 {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, song.SongsNotFound.Error())
+	var updatedSong models.Song
 
-		return nil, song.SongsNotFound
-	}
+	err := sr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(&models.Song{}).
+			Where("id = ?", fieldsToUpdate.ID).
+			Updates(dataToUpdate)
+		if result.Error != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, result.Error.Error())
 
-	var updatedSong models.Song
-	if err := sr.db.WithContext(ctx).Debug().Preload("Author").First(&updatedSong, "id = ?", fieldsToUpdate.ID).Error; err != nil {
+			return result.Error
+		}
 
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			logrusCustom.LogWithLocation(logrus.ErrorLevel, song.SongsNotFound.Error())
+		if result.RowsAffected == 0 {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, song.SongsNotFound.Error())
 
-			return nil, song.SongsNotFound
+			return song.SongsNotFound
+		}
+
+		if err := tx.Preload("Author").First(&updatedSong, "id = ?", fieldsToUpdate.ID).Error; err != nil {
+
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				logrusCustom.Log(ctx, logrus.ErrorLevel, song.SongsNotFound.Error())
+
+				return song.SongsNotFound
+			}
+
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+			return err
 		}
 
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		return addEvent(tx, outboxconstants.SongUpdated, &updatedSong)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Exiting UpdateSongs Repository with updated song: %+v", updatedSong))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting UpdateSongs Repository with updated song: %+v", updatedSong))
 
 	return &updatedSong, nil
 }
 
 func (sr *SongRepository) CreateSong(ctx context.Context, releaseDate time.Time, group string, songName string, lyrics string, link string) (*models.Song, error) {
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered CreateSong Repository with parameter: releaseDate:%s, group:%s, songName:%s, lyrics:%s, link:%s",
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered CreateSong Repository with parameter: releaseDate:%s, group:%s, songName:%s, lyrics:%s, link:%s",
 		releaseDate, group, songName, lyrics, link))
 
-	var author models.Author
+	var songToCreate *models.Song
+
+	err := sr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var author models.Author
+
+		if err := tx.Where("group_name = ?", group).First(&author).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+
+				author = models.Author{
+					ID:        uuid.New(),
+					GroupName: group,
+				}
+				if err := tx.Create(&author).Error; err != nil {
+					logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+					return err
+				}
+			} else {
+				logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+				return song.AuthorAlreadyExists
+			}
+		}
 
-	if err := sr.db.WithContext(ctx).Debug().Where("group_name = ?", group).First(&author).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		songToCreate = &models.Song{
+			ID:          uuid.New(),
+			Name:        songName,
+			AuthorId:    author.ID,
+			Text:        lyrics,
+			Link:        link,
+			ReleaseDate: releaseDate,
+			Language:    textsearch.DetectLanguage(songName, lyrics),
+		}
 
-			author = models.Author{
-				ID:        uuid.New(),
-				GroupName: group,
-			}
-			if err := sr.db.WithContext(ctx).Create(&author).Error; err != nil {
-				logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
-				return nil, err
+		if err := tx.Create(&songToCreate).Error; err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == constants.DbUniqueConstrintErr {
+				logrusCustom.Log(ctx, logrus.ErrorLevel, song.AuthorSongDuplicate.Error())
+				return song.AuthorSongDuplicate
 			}
-		} else {
-			logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
-			return nil, song.AuthorAlreadyExists
+
+			return err
 		}
-	}
 
-	songToCreate := &models.Song{
-		ID:          uuid.New(),
-		Name:        songName,
-		AuthorId:    author.ID,
-		Text:        lyrics,
-		Link:        link,
-		ReleaseDate: releaseDate,
+		if err := tx.Preload("Author").First(&songToCreate).Error; err != nil {
+
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+			return err
+		}
+
+		return addEvent(tx, outboxconstants.SongCreated, songToCreate)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := sr.db.WithContext(ctx).Debug().Create(&songToCreate).Error; err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting CreateSong Repository with created song: %+v", songToCreate))
+
+	return songToCreate, nil
+}
+
+// ImportSongs inserts one batch of validated rows in a single transaction.
+// Missing authors are created, and rows clashing with an existing song of the
+// same author, or with an earlier row of the batch, are reported as duplicates
+// instead of failing the batch.
+func (sr *SongRepository) ImportSongs(ctx context.Context, rows []dtos.ImportRow) ([]dtos.ImportRowResult, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered ImportSongs Repository with %d rows", len(rows)))
+
+	results := make([]dtos.ImportRowResult, len(rows))
+
+	err := sr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		groupNames := make([]string, 0, len(rows))
+		newAuthors := make([]models.Author, 0, len(rows))
+		seen := make(map[string]bool, len(rows))
+		for _, row := range rows {
+			if seen[row.Song.Group] {
+				continue
+			}
+			seen[row.Song.Group] = true
+
+			groupNames = append(groupNames, row.Song.Group)
+			newAuthors = append(newAuthors, models.Author{ID: uuid.New(), GroupName: row.Song.Group})
+		}
+
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Omit(clause.Associations).Create(&newAuthors).Error; err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+			return err
+		}
+
+		var authors []models.Author
+		if err := tx.Where("group_name IN ?", groupNames).Find(&authors).Error; err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+			return err
+		}
+
+		authorsByName := make(map[string]models.Author, len(authors))
+		for _, author := range authors {
+			authorsByName[author.GroupName] = author
+		}
+
+		songs := make([]models.Song, len(rows))
+		songIds := make([]uuid.UUID, len(rows))
+		for i, row := range rows {
+			releaseDate, err := time.Parse("2006-01-02", row.Song.ReleaseDate)
+			if err != nil {
+				return err
+			}
+
+			songs[i] = models.Song{
+				ID:          uuid.New(),
+				Name:        row.Song.Song,
+				AuthorId:    authorsByName[row.Song.Group].ID,
+				ReleaseDate: releaseDate,
+				Text:        row.Song.Lyrics,
+				Link:        row.Song.Link,
+				Language:    textsearch.DetectLanguage(row.Song.Song, row.Song.Lyrics),
+			}
+			songIds[i] = songs[i].ID
+		}
+
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Omit(clause.Associations).Create(&songs).Error; err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+			return err
+		}
+
+		var insertedIds []uuid.UUID
+		if err := tx.Model(&models.Song{}).Where("id IN ?", songIds).Pluck("id", &insertedIds).Error; err != nil {
+			logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+			return err
+		}
+
+		inserted := make(map[uuid.UUID]bool, len(insertedIds))
+		for _, id := range insertedIds {
+			inserted[id] = true
+		}
+
+		events := make([]models.OutboxEvent, 0, len(insertedIds))
+		for i, row := range rows {
+			results[i] = dtos.ImportRowResult{Line: row.Line, Group: row.Song.Group, Song: row.Song.Song}
+
+			if !inserted[songs[i].ID] {
+				results[i].Status = constants.ImportStatusDuplicate
+				results[i].Error = song.AuthorSongDuplicate.Error()
+				continue
+			}
+
+			results[i].Status = constants.ImportStatusCreated
+			results[i].SongId = &songs[i].ID
+
+			songs[i].Author = authorsByName[row.Song.Group]
+			outboxEvent, err := outbox.NewOutboxEvent(outboxconstants.SongCreated, songs[i].ID, &songs[i])
+			if err != nil {
+				return err
+			}
+			events = append(events, *outboxEvent)
+		}
 
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == constants.DbUniqueConstrintErr {
-			logrusCustom.LogWithLocation(logrus.ErrorLevel, song.AuthorSongDuplicate.Error())
-			return nil, song.AuthorSongDuplicate
+		if len(events) == 0 {
+			return nil
 		}
 
+		return tx.Create(&events).Error
+	})
+	if err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
 		return nil, err
 	}
 
-	if err := sr.db.WithContext(ctx).Debug().Preload("Author").First(&songToCreate).Error; err != nil {
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting ImportSongs Repository with %d rows processed", len(results)))
 
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
-		return nil, err
+	return results, nil
+}
+
+// addEvent records a song lifecycle event in the outbox as part of tx, so the
+// event exists if and only if the change is committed.
+func addEvent(tx *gorm.DB, eventType string, changedSong *models.Song) error {
+	outboxEvent, err := outbox.NewOutboxEvent(eventType, changedSong.ID, changedSong)
+	if err != nil {
+		logrusCustom.Log(tx.Statement.Context, logrus.ErrorLevel, err.Error())
+
+		return err
 	}
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Exiting CreateSong Repository with created song: %+v", songToCreate))
+	if err := tx.Create(outboxEvent).Error; err != nil {
+		logrusCustom.Log(tx.Statement.Context, logrus.ErrorLevel, err.Error())
 
-	return songToCreate, nil
+		return err
+	}
+
+	return nil
 }
 
 func (sr *SongRepository) GetSong(ctx context.Context, id uuid.UUID) (*models.Song, error) {
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered GetSong Repository with parameter: id:%s", id.String()))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetSong Repository with parameter: id:%s", id.String()))
 
 	var songToGet models.Song
-	if err := sr.db.WithContext(ctx).Debug().First(&songToGet, "id = ?", id).Error; err != nil {
+	if err := sr.db.WithContext(ctx).First(&songToGet, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 
 			return nil, song.SongsNotFound
@@ -232,17 +576,17 @@ func (sr *SongRepository) GetSong(ctx context.Context, id uuid.UUID) (*models.So
 		return nil, err
 	}
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Exiting GetSong Repository with songs: %+v", songToGet))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetSong Repository with songs: %+v", songToGet))
 
 	return &songToGet, nil
 }
 
 func (sr *SongRepository) GetAuthorByName(ctx context.Context, groupName string) (*models.Author, error) {
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered GetAuthorByName Repository with parameter: group_name:%s", groupName))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetAuthorByName Repository with parameter: group_name:%s", groupName))
 
 	var authorToGet models.Author
-	if err := sr.db.WithContext(ctx).Debug().First(&authorToGet, "group_name = ?", groupName).Error; err != nil {
+	if err := sr.db.WithContext(ctx).First(&authorToGet, "group_name = ?", groupName).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 
 			return nil, song.AuthorNotFound
@@ -251,7 +595,84 @@ func (sr *SongRepository) GetAuthorByName(ctx context.Context, groupName string)
 		return nil, err
 	}
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Exiting GetAuthorByName Repository with author: %+v", authorToGet))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetAuthorByName Repository with author: %+v", authorToGet))
 
 	return &authorToGet, nil
 }
+
+// SearchSongs runs a full-text query against the song.search_vector column.
+// Unless a language is given, the query is parsed with every supported text
+// search configuration so that English and Russian songs match alike.
+func (sr *SongRepository) SearchSongs(ctx context.Context, ssdto *dtos.SearchSongsDTO) (*dtos.SongSearchPage, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered SearchSongs Repository with parameters: %+v", ssdto))
+
+	var tsQuery string
+	var tsQueryArgs []interface{}
+	if ssdto.Language != "" {
+		tsQuery = "websearch_to_tsquery(?::regconfig, ?)"
+		tsQueryArgs = []interface{}{ssdto.Language, ssdto.Query}
+	} else {
+		tsQuery = "websearch_to_tsquery('english', ?) || websearch_to_tsquery('russian', ?) || websearch_to_tsquery('simple', ?)"
+		tsQueryArgs = []interface{}{ssdto.Query, ssdto.Query, ssdto.Query}
+	}
+
+	var results []dtos.SongSearchResult
+
+	offset := (ssdto.Page - 1) * ssdto.PageSize
+
+	var total int64
+	if err := sr.db.WithContext(ctx).
+		Model(&models.Song{}).
+		Joins("CROSS JOIN (SELECT "+tsQuery+") AS q(query)", tsQueryArgs...).
+		Where("song.search_vector @@ q.query").
+		Count(&total).Error; err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	if err := sr.db.WithContext(ctx).
+		Model(&models.Song{}).
+		Select("song.*, ts_rank_cd(song.search_vector, q.query) AS rank, ts_headline(song.language, song.text, q.query, ?) AS headline", constants.SearchHeadlineOptions).
+		Joins("CROSS JOIN (SELECT "+tsQuery+") AS q(query)", tsQueryArgs...).
+		Where("song.search_vector @@ q.query").
+		Order("rank DESC, song.id").
+		Offset(offset).
+		Limit(ssdto.PageSize).
+		Find(&results).Error; err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, song.SongsNotFound.Error())
+
+		return nil, song.SongsNotFound
+	}
+
+	authorIds := make([]uuid.UUID, 0, len(results))
+	for _, result := range results {
+		authorIds = append(authorIds, result.AuthorId)
+	}
+
+	var authors []models.Author
+	if err := sr.db.WithContext(ctx).Where("id IN ?", authorIds).Find(&authors).Error; err != nil {
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
+
+		return nil, err
+	}
+
+	authorsById := make(map[uuid.UUID]models.Author, len(authors))
+	for _, author := range authors {
+		authorsById[author.ID] = author
+	}
+	for i := range results {
+		results[i].Author = authorsById[results[i].AuthorId]
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting SearchSongs Repository with %d of %d results", len(results), total))
+
+	return &dtos.SongSearchPage{Results: results, Total: total}, nil
+}
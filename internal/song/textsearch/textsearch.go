@@ -0,0 +1,21 @@
+package textsearch
+
+import (
+	"SongsLibrary/internal/song/constants"
+	"unicode"
+)
+
+// DetectLanguage picks the Postgres text search configuration for a song:
+// "russian" when any of the texts contains Cyrillic letters, "english"
+// otherwise.
+func DetectLanguage(texts ...string) string {
+	for _, text := range texts {
+		for _, r := range text {
+			if unicode.Is(unicode.Cyrillic, r) {
+				return constants.TextSearchRussian
+			}
+		}
+	}
+
+	return constants.TextSearchEnglish
+}
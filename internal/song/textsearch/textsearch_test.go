@@ -0,0 +1,14 @@
+package textsearch
+
+import (
+	"SongsLibrary/internal/song/constants"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	assert.Equal(t, constants.TextSearchEnglish, DetectLanguage("fortunate son", "some folks are born made to wave the flag"))
+	assert.Equal(t, constants.TextSearchRussian, DetectLanguage("фраер", "что ж ты, фраер, сдал назад"))
+	assert.Equal(t, constants.TextSearchRussian, DetectLanguage("intro", "ты смотри в мои глаза"))
+	assert.Equal(t, constants.TextSearchEnglish, DetectLanguage())
+}
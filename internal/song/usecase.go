@@ -8,14 +8,12 @@ import (
 )
 
 type UseCase interface {
-	GetSongs(context.Context, *dtos.GetSongsDTO) ([]models.Song, error)
+	GetSongs(context.Context, *dtos.GetSongsDTO) (*dtos.SongsPage, error)
 	DeleteSong(ctx context.Context, id uuid.UUID) (*models.Song, error)
 	UpdateSong(context.Context, *models.Song) (*models.Song, error)
 	CreateSong(ctx context.Context, group, song string) (*models.Song, error)
-	GetSongLyrics(ctx context.Context, dto *dtos.GetSongLyricsDTO) ([]string, error)
-}
-
-type MusixmatchUseCase interface {
-	GetSongData(ctx context.Context, groupName, song string) (string, string, string, string, string, error)
-	GetLyrics(ctx context.Context, ip string) (string, error)
+	GetSongLyrics(ctx context.Context, dto *dtos.GetSongLyricsDTO) (*dtos.LyricsPage, error)
+	SearchSongs(context.Context, *dtos.SearchSongsDTO) (*dtos.SongSearchPage, error)
+	ImportSongs(ctx context.Context, rows []dtos.ImportRow) (*dtos.ImportReport, error)
+	ExportSongs(ctx context.Context, gsdto *dtos.GetSongsDTO, emit func(dtos.ExportSong) error) (int, error)
 }
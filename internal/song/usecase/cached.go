@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/cache"
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/song"
+	"SongsLibrary/internal/song/dtos"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// CachedSongUseCase is a read-through cache in front of a song.UseCase. It
+// caches GetSongs pages per query and the verses of each song, so lyrics pages
+// are cut from memory. Creating, importing, updating or deleting songs through
+// it invalidates the affected entries. Changes made elsewhere, such as author
+// renames, show up once the entries expire.
+type CachedSongUseCase struct {
+	song.UseCase
+
+	songs  cache.Cache[*dtos.SongsPage]
+	verses cache.Cache[[]string]
+
+	// generation is bumped on every invalidation. Values loaded while it
+	// changed are not cached, as they may predate the change. mu keeps an
+	// invalidation from running between that check and the Set, which would
+	// let a stale value outlive the purge.
+	generation atomic.Uint64
+	mu         sync.Mutex
+}
+
+func NewCachedSongUseCase(useCase song.UseCase, songs cache.Cache[*dtos.SongsPage], verses cache.Cache[[]string]) *CachedSongUseCase {
+	return &CachedSongUseCase{UseCase: useCase, songs: songs, verses: verses}
+}
+
+func (csuc *CachedSongUseCase) GetSongs(ctx context.Context, gsdto *dtos.GetSongsDTO) (*dtos.SongsPage, error) {
+	key, err := json.Marshal(gsdto)
+	if err != nil {
+		return nil, err
+	}
+
+	if page, ok := csuc.songs.Get(string(key)); ok {
+		logrusCustom.Log(ctx, logrus.DebugLevel, fmt.Sprintf("Songs cache hit for %s", key))
+
+		return page, nil
+	}
+
+	generation := csuc.generation.Load()
+
+	page, err := csuc.UseCase.GetSongs(ctx, gsdto)
+	if err != nil {
+		return nil, err
+	}
+
+	csuc.setIfCurrent(generation, func() { csuc.songs.Set(string(key), page) })
+
+	return page, nil
+}
+
+func (csuc *CachedSongUseCase) GetSongLyrics(ctx context.Context, gsldto *dtos.GetSongLyricsDTO) (*dtos.LyricsPage, error) {
+	key := gsldto.Id.String()
+
+	if verses, ok := csuc.verses.Get(key); ok {
+		logrusCustom.Log(ctx, logrus.DebugLevel, fmt.Sprintf("Lyrics cache hit for %s", key))
+
+		return pageVerses(verses, gsldto.Page, gsldto.PageSize), nil
+	}
+
+	generation := csuc.generation.Load()
+
+	allVerses, err := csuc.UseCase.GetSongLyrics(ctx, &dtos.GetSongLyricsDTO{Id: gsldto.Id, Page: 1, PageSize: math.MaxInt})
+	if err != nil {
+		return nil, err
+	}
+
+	csuc.setIfCurrent(generation, func() { csuc.verses.Set(key, allVerses.Verses) })
+
+	return pageVerses(allVerses.Verses, gsldto.Page, gsldto.PageSize), nil
+}
+
+func (csuc *CachedSongUseCase) CreateSong(ctx context.Context, group, songName string) (*models.Song, error) {
+	defer csuc.invalidate(uuid.Nil)
+
+	return csuc.UseCase.CreateSong(ctx, group, songName)
+}
+
+func (csuc *CachedSongUseCase) ImportSongs(ctx context.Context, rows []dtos.ImportRow) (*dtos.ImportReport, error) {
+	defer csuc.invalidate(uuid.Nil)
+
+	return csuc.UseCase.ImportSongs(ctx, rows)
+}
+
+func (csuc *CachedSongUseCase) UpdateSong(ctx context.Context, fieldsToUpdate *models.Song) (*models.Song, error) {
+	defer csuc.invalidate(fieldsToUpdate.ID)
+
+	return csuc.UseCase.UpdateSong(ctx, fieldsToUpdate)
+}
+
+func (csuc *CachedSongUseCase) DeleteSong(ctx context.Context, id uuid.UUID) (*models.Song, error) {
+	defer csuc.invalidate(id)
+
+	return csuc.UseCase.DeleteSong(ctx, id)
+}
+
+// invalidate drops every cached GetSongs page, since any change can move songs
+// between pages, and the verses of the song with id unless it is uuid.Nil.
+func (csuc *CachedSongUseCase) invalidate(id uuid.UUID) {
+	csuc.mu.Lock()
+	defer csuc.mu.Unlock()
+
+	csuc.generation.Add(1)
+
+	csuc.songs.Purge()
+	if id != uuid.Nil {
+		csuc.verses.Delete(id.String())
+	}
+}
+
+// setIfCurrent caches a value loaded at generation with set, unless the cache
+// was invalidated since.
+func (csuc *CachedSongUseCase) setIfCurrent(generation uint64, set func()) {
+	csuc.mu.Lock()
+	defer csuc.mu.Unlock()
+
+	if csuc.generation.Load() == generation {
+		set()
+	}
+}
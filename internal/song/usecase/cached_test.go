@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/cache"
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/song/dtos"
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newCachedSongUseCase() (*CachedSongUseCase, *MockSongUseCase) {
+
+	mockUseCase := new(MockSongUseCase)
+
+	return NewCachedSongUseCase(mockUseCase, cache.NewLRU[*dtos.SongsPage](10, time.Minute), cache.NewLRU[[]string](10, time.Minute)), mockUseCase
+}
+
+func TestCachedGetSongs_InvalidatedOnCreate(t *testing.T) {
+	csuc, mockUseCase := newCachedSongUseCase()
+
+	gsdto := &dtos.GetSongsDTO{Name: "hysteria", Page: 1, PageSize: 10}
+	page := &dtos.SongsPage{Songs: []models.Song{{ID: uuid.New(), Name: "hysteria"}}, Total: 1}
+
+	mockUseCase.On("GetSongs", mock.Anything, gsdto).Return(page, nil)
+	mockUseCase.On("CreateSong", mock.Anything, "muse", "hysteria").Return(&models.Song{ID: uuid.New()}, nil)
+
+	for range 2 {
+		cachedPage, err := csuc.GetSongs(context.Background(), gsdto)
+
+		assert.NoError(t, err)
+		assert.Equal(t, page, cachedPage)
+	}
+	mockUseCase.AssertNumberOfCalls(t, "GetSongs", 1)
+
+	_, err := csuc.CreateSong(context.Background(), "muse", "hysteria")
+	assert.NoError(t, err)
+
+	_, err = csuc.GetSongs(context.Background(), &dtos.GetSongsDTO{Name: "hysteria", Page: 1, PageSize: 10})
+	assert.NoError(t, err)
+	mockUseCase.AssertNumberOfCalls(t, "GetSongs", 2)
+}
+
+func TestCachedGetSongLyrics_PagesFromCache(t *testing.T) {
+	csuc, mockUseCase := newCachedSongUseCase()
+
+	id := uuid.New()
+	verses := []string{"first", "second", "third"}
+
+	mockUseCase.On("GetSongLyrics", mock.Anything, &dtos.GetSongLyricsDTO{Id: id, Page: 1, PageSize: math.MaxInt}).
+		Return(&dtos.LyricsPage{Verses: verses, Total: 3}, nil)
+
+	lyricsPage, err := csuc.GetSongLyrics(context.Background(), &dtos.GetSongLyricsDTO{Id: id, Page: 1, PageSize: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, &dtos.LyricsPage{Verses: []string{"first", "second"}, Total: 3}, lyricsPage)
+
+	lyricsPage, err = csuc.GetSongLyrics(context.Background(), &dtos.GetSongLyricsDTO{Id: id, Page: 2, PageSize: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, &dtos.LyricsPage{Verses: []string{"third"}, Total: 3}, lyricsPage)
+
+	mockUseCase.AssertNumberOfCalls(t, "GetSongLyrics", 1)
+}
+
+func TestCachedGetSongLyrics_InvalidatedOnUpdateAndDelete(t *testing.T) {
+	csuc, mockUseCase := newCachedSongUseCase()
+
+	id := uuid.New()
+
+	mockUseCase.On("GetSongLyrics", mock.Anything, mock.Anything).Return(&dtos.LyricsPage{Verses: []string{"verse"}, Total: 1}, nil)
+	mockUseCase.On("UpdateSong", mock.Anything, mock.Anything).Return(&models.Song{ID: id}, nil)
+	mockUseCase.On("DeleteSong", mock.Anything, id).Return(&models.Song{ID: id}, nil)
+
+	lyricsDTO := &dtos.GetSongLyricsDTO{Id: id, Page: 1, PageSize: 10}
+
+	csuc.GetSongLyrics(context.Background(), lyricsDTO)
+	csuc.UpdateSong(context.Background(), &models.Song{ID: id, Text: "new verse"})
+	csuc.GetSongLyrics(context.Background(), lyricsDTO)
+	csuc.DeleteSong(context.Background(), id)
+	csuc.GetSongLyrics(context.Background(), lyricsDTO)
+
+	mockUseCase.AssertNumberOfCalls(t, "GetSongLyrics", 3)
+}
+
+func TestCachedGetSongs_ConcurrentInvalidationNotCached(t *testing.T) {
+	csuc, mockUseCase := newCachedSongUseCase()
+
+	gsdto := &dtos.GetSongsDTO{Page: 1, PageSize: 10}
+
+	mockUseCase.On("GetSongs", mock.Anything, gsdto).Run(func(mock.Arguments) {
+		csuc.invalidate(uuid.Nil)
+	}).Return(&dtos.SongsPage{}, nil)
+
+	csuc.GetSongs(context.Background(), gsdto)
+	csuc.GetSongs(context.Background(), gsdto)
+
+	mockUseCase.AssertNumberOfCalls(t, "GetSongs", 2)
+}
+
+// racingCache starts an invalidation right as a value is being cached, and
+// gives it time to run before storing the value.
+type racingCache struct {
+	cache.Cache[*dtos.SongsPage]
+
+	invalidate func()
+	wg         sync.WaitGroup
+}
+
+func (rc *racingCache) Set(key string, value *dtos.SongsPage) {
+	rc.wg.Add(1)
+	go func() {
+		defer rc.wg.Done()
+		rc.invalidate()
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	rc.Cache.Set(key, value)
+}
+
+func TestCachedGetSongs_InvalidationDuringSetNotCached(t *testing.T) {
+	mockUseCase := new(MockSongUseCase)
+	songs := &racingCache{Cache: cache.NewLRU[*dtos.SongsPage](10, time.Minute)}
+	csuc := NewCachedSongUseCase(mockUseCase, songs, cache.NewLRU[[]string](10, time.Minute))
+	songs.invalidate = func() { csuc.invalidate(uuid.Nil) }
+
+	gsdto := &dtos.GetSongsDTO{Page: 1, PageSize: 10}
+
+	mockUseCase.On("GetSongs", mock.Anything, gsdto).Return(&dtos.SongsPage{}, nil)
+
+	csuc.GetSongs(context.Background(), gsdto)
+	songs.wg.Wait()
+
+	songs.invalidate = func() {}
+	csuc.GetSongs(context.Background(), gsdto)
+
+	mockUseCase.AssertNumberOfCalls(t, "GetSongs", 2)
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/metrics"
+	"SongsLibrary/internal/metrics/constants"
+	"SongsLibrary/internal/song"
+	"SongsLibrary/internal/song/dtos"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"time"
+)
+
+var tracer = otel.Tracer("SongsLibrary/internal/song/usecase")
+
+// InstrumentedSongUseCase records the outcome and latency of every operation
+// of the wrapped song.UseCase and traces it, so that the spans of the
+// repository and the providers hang below the operation that caused them.
+type InstrumentedSongUseCase struct {
+	useCase song.UseCase
+	metrics *metrics.Metrics
+}
+
+func NewInstrumentedSongUseCase(useCase song.UseCase, metrics *metrics.Metrics) *InstrumentedSongUseCase {
+	return &InstrumentedSongUseCase{useCase: useCase, metrics: metrics}
+}
+
+func (isuc *InstrumentedSongUseCase) GetSongs(ctx context.Context, gsdto *dtos.GetSongsDTO) (page *dtos.SongsPage, err error) {
+	ctx, end := isuc.start(ctx, "GetSongs")
+	defer func() { end(err) }()
+
+	return isuc.useCase.GetSongs(ctx, gsdto)
+}
+
+func (isuc *InstrumentedSongUseCase) DeleteSong(ctx context.Context, id uuid.UUID) (deleted *models.Song, err error) {
+	ctx, end := isuc.start(ctx, "DeleteSong")
+	defer func() { end(err) }()
+
+	return isuc.useCase.DeleteSong(ctx, id)
+}
+
+func (isuc *InstrumentedSongUseCase) UpdateSong(ctx context.Context, songModel *models.Song) (updated *models.Song, err error) {
+	ctx, end := isuc.start(ctx, "UpdateSong")
+	defer func() { end(err) }()
+
+	return isuc.useCase.UpdateSong(ctx, songModel)
+}
+
+func (isuc *InstrumentedSongUseCase) CreateSong(ctx context.Context, group, songName string) (created *models.Song, err error) {
+	ctx, end := isuc.start(ctx, "CreateSong")
+	defer func() { end(err) }()
+
+	return isuc.useCase.CreateSong(ctx, group, songName)
+}
+
+func (isuc *InstrumentedSongUseCase) GetSongLyrics(ctx context.Context, dto *dtos.GetSongLyricsDTO) (page *dtos.LyricsPage, err error) {
+	ctx, end := isuc.start(ctx, "GetSongLyrics")
+	defer func() { end(err) }()
+
+	return isuc.useCase.GetSongLyrics(ctx, dto)
+}
+
+func (isuc *InstrumentedSongUseCase) SearchSongs(ctx context.Context, ssdto *dtos.SearchSongsDTO) (page *dtos.SongSearchPage, err error) {
+	ctx, end := isuc.start(ctx, "SearchSongs")
+	defer func() { end(err) }()
+
+	return isuc.useCase.SearchSongs(ctx, ssdto)
+}
+
+func (isuc *InstrumentedSongUseCase) ImportSongs(ctx context.Context, rows []dtos.ImportRow) (report *dtos.ImportReport, err error) {
+	ctx, end := isuc.start(ctx, "ImportSongs")
+	defer func() { end(err) }()
+
+	return isuc.useCase.ImportSongs(ctx, rows)
+}
+
+func (isuc *InstrumentedSongUseCase) ExportSongs(ctx context.Context, gsdto *dtos.GetSongsDTO, emit func(dtos.ExportSong) error) (exported int, err error) {
+	ctx, end := isuc.start(ctx, "ExportSongs")
+	defer func() { end(err) }()
+
+	return isuc.useCase.ExportSongs(ctx, gsdto, emit)
+}
+
+// start opens the span of operation. The returned function ends it and
+// records the outcome; only failures that are not the caller's fault mark the
+// span as failed.
+func (isuc *InstrumentedSongUseCase) start(ctx context.Context, operation string) (context.Context, func(error)) {
+	started := time.Now()
+
+	ctx, span := tracer.Start(ctx, "SongUseCase."+operation)
+
+	return ctx, func(err error) {
+		result := outcome(err)
+
+		isuc.metrics.ObserveOperation(operation, result, time.Since(started))
+
+		span.SetAttributes(attribute.String("outcome", result))
+		if result == constants.OutcomeError || result == constants.OutcomeUnavailable {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// outcome reduces the errors of the use case to a metrics outcome.
+func outcome(err error) string {
+	switch {
+	case err == nil:
+		return constants.OutcomeSuccess
+	case errors.Is(err, song.ProviderUnavailable):
+		return constants.OutcomeUnavailable
+	case errors.Is(err, song.SongsNotFound), errors.Is(err, song.AuthorNotFound), errors.Is(err, song.ErrorGetSongData), errors.Is(err, song.ErrorGetSongLyrics):
+		return constants.OutcomeNotFound
+	case errors.Is(err, song.AuthorAlreadyExists), errors.Is(err, song.AuthorSongDuplicate):
+		return constants.OutcomeConflict
+	case errors.Is(err, song.InvalidInputData), errors.Is(err, song.InvalidSongIdFormat), errors.Is(err, song.InvalidAuthorIdFormat),
+		errors.Is(err, song.InvalidCursor), errors.Is(err, song.InvalidReleaseDates), errors.Is(err, song.InvalidSort),
+		errors.Is(err, song.UnsupportedImportType), errors.Is(err, song.InvalidImportFile):
+		return constants.OutcomeInvalid
+	default:
+		return constants.OutcomeError
+	}
+}
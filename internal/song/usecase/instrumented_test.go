@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"SongsLibrary/internal/db/models"
+	"SongsLibrary/internal/metrics"
+	"SongsLibrary/internal/song"
+	"SongsLibrary/internal/song/dtos"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInstrumentedSongUseCase_RecordsOutcomes(t *testing.T) {
+	m := metrics.New()
+	mockUseCase := new(MockSongUseCase)
+	isuc := NewInstrumentedSongUseCase(mockUseCase, m)
+
+	gsdto := &dtos.GetSongsDTO{Page: 1, PageSize: 10}
+
+	mockUseCase.On("GetSongs", mock.Anything, gsdto).Return(nil, song.SongsNotFound)
+	mockUseCase.On("CreateSong", mock.Anything, "muse", "hysteria").Return(nil, song.ProviderUnavailable).Once()
+	mockUseCase.On("CreateSong", mock.Anything, "muse", "uprising").Return(&models.Song{Name: "uprising"}, nil)
+	mockUseCase.On("CreateSong", mock.Anything, "muse", "resistance").Return(nil, fmt.Errorf("connection reset"))
+
+	_, err := isuc.GetSongs(context.Background(), gsdto)
+	assert.Equal(t, song.SongsNotFound, err)
+
+	created, err := isuc.CreateSong(context.Background(), "muse", "uprising")
+	assert.NoError(t, err)
+	assert.Equal(t, "uprising", created.Name)
+
+	_, _ = isuc.CreateSong(context.Background(), "muse", "hysteria")
+	_, _ = isuc.CreateSong(context.Background(), "muse", "resistance")
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	for _, line := range []string{
+		`song_usecase_operations_total{operation="GetSongs",outcome="not_found"} 1`,
+		`song_usecase_operations_total{operation="CreateSong",outcome="success"} 1`,
+		`song_usecase_operations_total{operation="CreateSong",outcome="unavailable"} 1`,
+		`song_usecase_operations_total{operation="CreateSong",outcome="error"} 1`,
+	} {
+		assert.Contains(t, w.Body.String(), line)
+	}
+}
@@ -12,10 +12,10 @@ type MockSongUseCase struct {
 	mock.Mock
 }
 
-func (m *MockSongUseCase) GetSongs(ctx context.Context, gsdto *dtos.GetSongsDTO) ([]models.Song, error) {
+func (m *MockSongUseCase) GetSongs(ctx context.Context, gsdto *dtos.GetSongsDTO) (*dtos.SongsPage, error) {
 	args := m.Called(ctx, gsdto)
-	if songs, ok := args.Get(0).([]models.Song); ok {
-		return songs, args.Error(1)
+	if page, ok := args.Get(0).(*dtos.SongsPage); ok {
+		return page, args.Error(1)
 	}
 	return nil, args.Error(1)
 }
@@ -44,10 +44,43 @@ func (m *MockSongUseCase) CreateSong(ctx context.Context, group, song string) (*
 	return nil, args.Error(1)
 }
 
-func (m *MockSongUseCase) GetSongLyrics(ctx context.Context, dto *dtos.GetSongLyricsDTO) ([]string, error) {
+func (m *MockSongUseCase) GetSongLyrics(ctx context.Context, dto *dtos.GetSongLyricsDTO) (*dtos.LyricsPage, error) {
 	args := m.Called(ctx, dto)
-	if lyrics, ok := args.Get(0).([]string); ok {
-		return lyrics, args.Error(1)
+	if page, ok := args.Get(0).(*dtos.LyricsPage); ok {
+		return page, args.Error(1)
 	}
 	return nil, args.Error(1)
 }
+
+func (m *MockSongUseCase) SearchSongs(ctx context.Context, ssdto *dtos.SearchSongsDTO) (*dtos.SongSearchPage, error) {
+	args := m.Called(ctx, ssdto)
+	if page, ok := args.Get(0).(*dtos.SongSearchPage); ok {
+		return page, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSongUseCase) ImportSongs(ctx context.Context, rows []dtos.ImportRow) (*dtos.ImportReport, error) {
+	args := m.Called(ctx, rows)
+	if report, ok := args.Get(0).(*dtos.ImportReport); ok {
+		return report, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// ExportSongs passes the songs given to Return, if any, to emit.
+func (m *MockSongUseCase) ExportSongs(ctx context.Context, gsdto *dtos.GetSongsDTO, emit func(dtos.ExportSong) error) (int, error) {
+	args := m.Called(ctx, gsdto, emit)
+
+	songs, _ := args.Get(0).([]dtos.ExportSong)
+
+	exported := 0
+	for _, exportSong := range songs {
+		if err := emit(exportSong); err != nil {
+			return exported, err
+		}
+		exported++
+	}
+
+	return exported, args.Error(1)
+}
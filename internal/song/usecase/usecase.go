@@ -3,159 +3,217 @@ package usecase
 import (
 	"SongsLibrary/internal/db/models"
 	"SongsLibrary/internal/song"
+	"SongsLibrary/internal/song/constants"
 	"SongsLibrary/internal/song/dtos"
 	logrusCustom "SongsLibrary/pkg/logger"
 	"context"
 	"fmt"
-	songv1pb "github.com/DanKo-code/Protobuf-For-Songs-Library-Upgraded/protos/gen/go/song"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
-	"google.golang.org/grpc"
 	"strings"
-	"time"
 )
 
 type SongUseCase struct {
-	songRepo          song.Repository
-	musixMatchUseCase song.MusixmatchUseCase
-	gRPCClient        *grpc.ClientConn
+	songRepo         song.Repository
+	metadataProvider song.MetadataProvider
 }
 
-func NewSongUseCase(songRepo song.Repository, musixMatchUseCase song.MusixmatchUseCase, gRPCClient *grpc.ClientConn) *SongUseCase {
-	return &SongUseCase{songRepo: songRepo, musixMatchUseCase: musixMatchUseCase, gRPCClient: gRPCClient}
+func NewSongUseCase(songRepo song.Repository, metadataProvider song.MetadataProvider) *SongUseCase {
+	return &SongUseCase{songRepo: songRepo, metadataProvider: metadataProvider}
 }
 
-func (suc *SongUseCase) GetSongs(ctx context.Context, gsdto *dtos.GetSongsDTO) ([]models.Song, error) {
+func (suc *SongUseCase) GetSongs(ctx context.Context, gsdto *dtos.GetSongsDTO) (*dtos.SongsPage, error) {
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered GetSongs UseCase with parameters: %+v", gsdto))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetSongs UseCase with parameters: %+v", gsdto))
 
-	songs, err := suc.songRepo.GetSongs(ctx, gsdto)
+	page, err := suc.songRepo.GetSongs(ctx, gsdto)
 	if err != nil {
 		return nil, err
 	}
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Exiting GetSongs UseCase with songs: %+v", songs))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetSongs UseCase with songs: %+v, next cursor: %s", page.Songs, page.NextCursor))
 
-	return songs, nil
+	return page, nil
 }
 
 func (suc *SongUseCase) DeleteSong(ctx context.Context, id uuid.UUID) (*models.Song, error) {
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered DeleteSong UseCase with parameter: %+v", id.String()))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered DeleteSong UseCase with parameter: %+v", id.String()))
 
 	deletedSong, err := suc.songRepo.DeleteSong(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Exiting DeleteSongs UseCase with deleted song: %+v", deletedSong))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting DeleteSongs UseCase with deleted song: %+v", deletedSong))
 
 	return deletedSong, nil
 }
 
 func (suc *SongUseCase) UpdateSong(ctx context.Context, fieldsToUpdate *models.Song) (*models.Song, error) {
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered UpdateSongs UseCase with parameters: %+v", fieldsToUpdate))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered UpdateSongs UseCase with parameters: %+v", fieldsToUpdate))
 
 	updatedSong, err := suc.songRepo.UpdateSong(ctx, fieldsToUpdate)
 	if err != nil {
 		return nil, err
 	}
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Exiting UpdateSongs UseCase with updated song: %+v", updatedSong))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting UpdateSongs UseCase with updated song: %+v", updatedSong))
 
 	return updatedSong, nil
 }
 
 func (suc *SongUseCase) CreateSong(ctx context.Context, group, songName string) (*models.Song, error) {
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered CreateSongs UseCase with parameters: group:%s, song:%s", group, songName))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered CreateSongs UseCase with parameters: group:%s, song:%s", group, songName))
 
 	author, err := suc.songRepo.GetAuthorByName(ctx, songName)
 	if err != nil && err.Error() != song.AuthorNotFound.Error() {
 		return nil, err
 	}
 	if author != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, song.AuthorAlreadyExists.Error())
+		logrusCustom.Log(ctx, logrus.ErrorLevel, song.AuthorAlreadyExists.Error())
 
 		return nil, song.AuthorAlreadyExists
 	}
 
-	//monolith
-	/*ip, link, releaseDate, trackName, artistName, err := suc.musixMatchUseCase.GetSongData(ctx, group, songName)
+	metadata, err := suc.metadataProvider.GetSongMetadata(ctx, group, songName)
 	if err != nil {
-		return nil, err
-	}*/
-
-	//micro services
-	songv1pbClient := songv1pb.NewSongDataClient(suc.gRPCClient)
-
-	getSongDataResponse, err := songv1pbClient.GetSongData(ctx, &songv1pb.GetSongDataRequest{Group: group, SongName: songName})
-	if err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
-		return nil, err
-	}
-
-	ip := getSongDataResponse.GetIp()
-	link := getSongDataResponse.GetLink()
-	releaseDate := getSongDataResponse.GetReleaseDate()
-	trackName := getSongDataResponse.GetTrackName()
-	artistName := getSongDataResponse.GetArtistName()
-
-	lyrics, err := suc.musixMatchUseCase.GetLyrics(ctx, ip)
-	if err != nil {
-		return nil, err
-	}
-
-	releaseDateCasted, err := time.Parse("2006-01-02 15:04:05 -0700 MST", releaseDate)
-	if err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
-
 		return nil, err
 	}
 
-	createdSong, err := suc.songRepo.CreateSong(ctx, releaseDateCasted, artistName, trackName, lyrics, link)
+	createdSong, err := suc.songRepo.CreateSong(ctx, metadata.ReleaseDate, metadata.ArtistName, metadata.TrackName, metadata.Lyrics, metadata.Link)
 	if err != nil {
 		return nil, err
 	}
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Exiting CreateSongs UseCase with created song: %+v", createdSong))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting CreateSongs UseCase with created song: %+v", createdSong))
 
 	return createdSong, nil
 }
 
-func (suc *SongUseCase) GetSongLyrics(ctx context.Context, gsldto *dtos.GetSongLyricsDTO) ([]string, error) {
+func (suc *SongUseCase) GetSongLyrics(ctx context.Context, gsldto *dtos.GetSongLyricsDTO) (*dtos.LyricsPage, error) {
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Entered GetSongLyrics UseCase with parameters: %+v", gsldto))
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered GetSongLyrics UseCase with parameters: %+v", gsldto))
 
 	existingSong, err := suc.songRepo.GetSong(ctx, gsldto.Id)
 	if err != nil {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, err.Error())
+		logrusCustom.Log(ctx, logrus.ErrorLevel, err.Error())
 
 		return nil, err
 	}
 
 	if existingSong.Text == "" {
-		logrusCustom.LogWithLocation(logrus.ErrorLevel, song.ErrorGetSongLyrics.Error())
+		logrusCustom.Log(ctx, logrus.ErrorLevel, song.ErrorGetSongLyrics.Error())
 
 		return nil, song.ErrorGetSongLyrics
 	}
 
 	verses := strings.Split(existingSong.Text, "\n\n")
 
-	logrusCustom.LogWithLocation(logrus.DebugLevel, fmt.Sprintf("Exiting GetSongLyrics UseCase with song lyrics verses: %+v", verses))
+	logrusCustom.Log(ctx, logrus.DebugLevel, fmt.Sprintf("Exiting GetSongLyrics UseCase with song lyrics verses: %+v", verses))
 
-	offset := (gsldto.Page - 1) * gsldto.PageSize
+	lyricsPage := pageVerses(verses, gsldto.Page, gsldto.PageSize)
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting GetSongLyrics UseCase with song lyrics verses: %+v", lyricsPage.Verses))
+
+	return lyricsPage, nil
+}
+
+func pageVerses(verses []string, page, pageSize int) *dtos.LyricsPage {
+	offset := (page - 1) * pageSize
 	if offset > len(verses) {
-		return []string{}, nil
+		return &dtos.LyricsPage{Verses: []string{}, Total: int64(len(verses))}
 	}
 
-	end := offset + gsldto.PageSize
+	end := offset + pageSize
 	if end > len(verses) {
 		end = len(verses)
 	}
 
-	logrusCustom.LogWithLocation(logrus.InfoLevel, fmt.Sprintf("Exiting GetSongLyrics UseCase with song lyrics verses: %+v", verses[offset:end]))
+	return &dtos.LyricsPage{Verses: verses[offset:end], Total: int64(len(verses))}
+}
+
+func (suc *SongUseCase) SearchSongs(ctx context.Context, ssdto *dtos.SearchSongsDTO) (*dtos.SongSearchPage, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered SearchSongs UseCase with parameters: %+v", ssdto))
+
+	page, err := suc.songRepo.SearchSongs(ctx, ssdto)
+	if err != nil {
+		return nil, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting SearchSongs UseCase with %d of %d results", len(page.Results), page.Total))
+
+	return page, nil
+}
+
+// ImportSongs stores the valid rows in batches of constants.ImportBatchSize,
+// each batch in its own transaction, and reports the outcome of every row in
+// file order. Batches committed before a failing one are kept.
+func (suc *SongUseCase) ImportSongs(ctx context.Context, rows []dtos.ImportRow) (*dtos.ImportReport, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered ImportSongs UseCase with %d rows", len(rows)))
+
+	report := &dtos.ImportReport{Rows: make([]dtos.ImportRowResult, len(rows))}
+
+	validRows := make([]dtos.ImportRow, 0, len(rows))
+	validIndexes := make([]int, 0, len(rows))
+	for i, row := range rows {
+		if row.Error != "" {
+			report.Rows[i] = dtos.ImportRowResult{
+				Line:   row.Line,
+				Group:  row.Song.Group,
+				Song:   row.Song.Song,
+				Status: constants.ImportStatusInvalid,
+				Error:  row.Error,
+			}
+			report.Invalid++
+			continue
+		}
+
+		validRows = append(validRows, row)
+		validIndexes = append(validIndexes, i)
+	}
+
+	for start := 0; start < len(validRows); start += constants.ImportBatchSize {
+		end := min(start+constants.ImportBatchSize, len(validRows))
+
+		results, err := suc.songRepo.ImportSongs(ctx, validRows[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("import stopped at line %d: %w", validRows[start].Line, err)
+		}
+
+		for i, result := range results {
+			report.Rows[validIndexes[start+i]] = result
+
+			switch result.Status {
+			case constants.ImportStatusCreated:
+				report.Created++
+			case constants.ImportStatusDuplicate:
+				report.Duplicates++
+			}
+		}
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting ImportSongs UseCase with created: %d, duplicates: %d, invalid: %d", report.Created, report.Duplicates, report.Invalid))
+
+	return report, nil
+}
+
+func (suc *SongUseCase) ExportSongs(ctx context.Context, gsdto *dtos.GetSongsDTO, emit func(dtos.ExportSong) error) (int, error) {
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Entered ExportSongs UseCase with parameters: %+v", gsdto))
+
+	exported, err := suc.songRepo.ExportSongs(ctx, gsdto, func(songToExport *models.Song) error {
+		return emit(dtos.NewExportSong(songToExport))
+	})
+	if err != nil {
+		return exported, err
+	}
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exiting ExportSongs UseCase with %d songs exported", exported))
 
-	return verses[offset:end], nil
+	return exported, nil
 }
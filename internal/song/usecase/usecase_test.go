@@ -3,35 +3,25 @@ package usecase
 import (
 	"SongsLibrary/internal/db/models"
 	"SongsLibrary/internal/song"
+	"SongsLibrary/internal/song/constants"
 	"SongsLibrary/internal/song/dtos"
+	"SongsLibrary/internal/song/provider"
 	postgres "SongsLibrary/internal/song/repository/postgres"
-	logrusCustom "SongsLibrary/pkg/logger"
 	"context"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"net/http"
 	"testing"
 	"time"
 )
 
 func TestGetSongsUseCase_Success(t *testing.T) {
 	mockRepo := new(postgres.MockRepository)
-	logrusCustom.InitLogger()
 
-	musixMatchUseCase := CreateMusixMatchUseCase(
-		"https://api.musixmatch.com/ws/1.1/",
-		"track.search?q_artist=%s&q_track=%s&apikey=%s",
-		"track.lyrics.get?commontrack_id=%s&apikey=%s",
-		"738351c40ea2c5b8ab58f24665ce3bc0",
+	mockProvider := new(provider.MockProvider)
 
-		"https://api.genius.com/",
-		"RELEASE_DATE='search?q=%s %s",
-		"Bearer biWMNIIRWL62TeubvtPoP6JIeLHa1r7rDDG65ry0oXM0HagiD7YewmXChSCIMPy-",
-		&http.Client{},
-	)
-
-	suc := NewSongUseCase(mockRepo, musixMatchUseCase)
+	suc := NewSongUseCase(mockRepo, mockProvider)
 
 	gsdto := &dtos.GetSongsDTO{
 		Name:      "testsong",
@@ -52,34 +42,23 @@ func TestGetSongsUseCase_Success(t *testing.T) {
 		},
 	}
 
-	mockRepo.On("GetSongs", mock.Anything, gsdto).Return(mockSongs, nil)
+	mockRepo.On("GetSongs", mock.Anything, gsdto).Return(&dtos.SongsPage{Songs: mockSongs}, nil)
 
 	ctx := context.Background()
-	songs, err := suc.GetSongs(ctx, gsdto)
+	page, err := suc.GetSongs(ctx, gsdto)
 
 	assert.NoError(t, err)
-	assert.Equal(t, mockSongs, songs)
+	assert.Equal(t, mockSongs, page.Songs)
 
 	mockRepo.AssertExpectations(t)
 }
 
 func TestGetSongsUseCase_Fail(t *testing.T) {
 	mockRepo := new(postgres.MockRepository)
-	logrusCustom.InitLogger()
-
-	musixMatchUseCase := CreateMusixMatchUseCase(
-		"https://api.musixmatch.com/ws/1.1/",
-		"track.search?q_artist=%s&q_track=%s&apikey=%s",
-		"track.lyrics.get?commontrack_id=%s&apikey=%s",
-		"738351c40ea2c5b8ab58f24665ce3bc0",
 
-		"https://api.genius.com/",
-		"RELEASE_DATE='search?q=%s %s",
-		"Bearer biWMNIIRWL62TeubvtPoP6JIeLHa1r7rDDG65ry0oXM0HagiD7YewmXChSCIMPy-",
-		&http.Client{},
-	)
+	mockProvider := new(provider.MockProvider)
 
-	suc := NewSongUseCase(mockRepo, musixMatchUseCase)
+	suc := NewSongUseCase(mockRepo, mockProvider)
 
 	gsdto := &dtos.GetSongsDTO{
 		Name:      "testsong",
@@ -96,3 +75,110 @@ func TestGetSongsUseCase_Fail(t *testing.T) {
 	assert.Nil(t, songRes)
 	assert.Error(t, err, song.AuthorNotFound)
 }
+
+func TestCreateSongUseCase_Success(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+	mockProvider := new(provider.MockProvider)
+
+	suc := NewSongUseCase(mockRepo, mockProvider)
+
+	metadata := &song.Metadata{
+		Providers:   []string{provider.LocalName},
+		TrackName:   "fortunate son",
+		ArtistName:  "creedence clearwater revival",
+		ReleaseDate: time.Date(1969, 11, 2, 0, 0, 0, 0, time.UTC),
+		Link:        "http://example.com",
+		Lyrics:      "some folks are born made to wave the flag",
+	}
+
+	createdSong := &models.Song{
+		ID:          uuid.New(),
+		Name:        metadata.TrackName,
+		ReleaseDate: metadata.ReleaseDate,
+		Text:        metadata.Lyrics,
+		Link:        metadata.Link,
+	}
+
+	mockRepo.On("GetAuthorByName", mock.Anything, "fortunate son").Return(nil, song.AuthorNotFound)
+	mockProvider.On("GetSongMetadata", mock.Anything, "creedence clearwater revival", "fortunate son").Return(metadata, nil)
+	mockRepo.On("CreateSong", mock.Anything, metadata.ReleaseDate, metadata.ArtistName, metadata.TrackName, metadata.Lyrics, metadata.Link).Return(createdSong, nil)
+
+	songRes, err := suc.CreateSong(context.Background(), "creedence clearwater revival", "fortunate son")
+
+	assert.NoError(t, err)
+	assert.Equal(t, createdSong, songRes)
+	mockRepo.AssertExpectations(t)
+	mockProvider.AssertExpectations(t)
+}
+
+func TestCreateSongUseCase_ProviderFail(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+	mockProvider := new(provider.MockProvider)
+
+	suc := NewSongUseCase(mockRepo, mockProvider)
+
+	mockRepo.On("GetAuthorByName", mock.Anything, "testsong").Return(nil, song.AuthorNotFound)
+	mockProvider.On("GetSongMetadata", mock.Anything, "testgroup", "testsong").Return(nil, song.ErrorGetSongData)
+
+	songRes, err := suc.CreateSong(context.Background(), "testgroup", "testsong")
+
+	assert.Nil(t, songRes)
+	assert.ErrorIs(t, err, song.ErrorGetSongData)
+	mockRepo.AssertNotCalled(t, "CreateSong", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportSongsUseCase_Report(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	suc := NewSongUseCase(mockRepo, nil)
+
+	rows := []dtos.ImportRow{
+		{Line: 2, Song: dtos.ImportSongDTO{Group: "muse", Song: "hysteria", ReleaseDate: "2003-12-01"}},
+		{Line: 3, Song: dtos.ImportSongDTO{Group: "muse"}, Error: "song is required"},
+		{Line: 4, Song: dtos.ImportSongDTO{Group: "muse", Song: "uprising", ReleaseDate: "2009-09-07"}},
+	}
+
+	songId := uuid.New()
+	mockRepo.On("ImportSongs", mock.Anything, []dtos.ImportRow{rows[0], rows[2]}).Return([]dtos.ImportRowResult{
+		{Line: 2, Group: "muse", Song: "hysteria", Status: constants.ImportStatusCreated, SongId: &songId},
+		{Line: 4, Group: "muse", Song: "uprising", Status: constants.ImportStatusDuplicate, Error: song.AuthorSongDuplicate.Error()},
+	}, nil)
+
+	report, err := suc.ImportSongs(context.Background(), rows)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Duplicates)
+	assert.Equal(t, 1, report.Invalid)
+	assert.Equal(t, []int{2, 3, 4}, []int{report.Rows[0].Line, report.Rows[1].Line, report.Rows[2].Line})
+	assert.Equal(t, constants.ImportStatusInvalid, report.Rows[1].Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportSongsUseCase_Batches(t *testing.T) {
+	mockRepo := new(postgres.MockRepository)
+
+	suc := NewSongUseCase(mockRepo, nil)
+
+	rows := make([]dtos.ImportRow, constants.ImportBatchSize+1)
+	for i := range rows {
+		rows[i] = dtos.ImportRow{Line: i + 2, Song: dtos.ImportSongDTO{Group: "muse", Song: fmt.Sprintf("song %d", i), ReleaseDate: "2003-12-01"}}
+	}
+
+	created := func(batch []dtos.ImportRow) []dtos.ImportRowResult {
+		results := make([]dtos.ImportRowResult, len(batch))
+		for i, row := range batch {
+			results[i] = dtos.ImportRowResult{Line: row.Line, Status: constants.ImportStatusCreated}
+		}
+		return results
+	}
+
+	mockRepo.On("ImportSongs", mock.Anything, rows[:constants.ImportBatchSize]).Return(created(rows[:constants.ImportBatchSize]), nil).Once()
+	mockRepo.On("ImportSongs", mock.Anything, rows[constants.ImportBatchSize:]).Return(created(rows[constants.ImportBatchSize:]), nil).Once()
+
+	report, err := suc.ImportSongs(context.Background(), rows)
+
+	assert.NoError(t, err)
+	assert.Equal(t, len(rows), report.Created)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,7 @@
+package tracing
+
+import "errors"
+
+var (
+	UnknownExporter = errors.New("unknown span exporter, expected none, otlp or stdout")
+)
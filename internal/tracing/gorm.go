@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const spanKey = "tracing:span"
+
+var tracer = otel.Tracer("SongsLibrary/internal/tracing")
+
+// GormPlugin opens a client span for every query GORM runs, as a child of the
+// span in the context given to db.WithContext. The statement is recorded with
+// its placeholders, never with its values.
+type GormPlugin struct{}
+
+func NewGormPlugin() *GormPlugin {
+	return &GormPlugin{}
+}
+
+func (p *GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+
+	return errors.Join(
+		callback.Create().Before("gorm:create").Register("tracing:before_create", p.before("create")),
+		callback.Create().After("gorm:create").Register("tracing:after_create", p.after),
+		callback.Query().Before("gorm:query").Register("tracing:before_query", p.before("query")),
+		callback.Query().After("gorm:query").Register("tracing:after_query", p.after),
+		callback.Update().Before("gorm:update").Register("tracing:before_update", p.before("update")),
+		callback.Update().After("gorm:update").Register("tracing:after_update", p.after),
+		callback.Delete().Before("gorm:delete").Register("tracing:before_delete", p.before("delete")),
+		callback.Delete().After("gorm:delete").Register("tracing:after_delete", p.after),
+		callback.Row().Before("gorm:row").Register("tracing:before_row", p.before("row")),
+		callback.Row().After("gorm:row").Register("tracing:after_row", p.after),
+		callback.Raw().Before("gorm:raw").Register("tracing:before_raw", p.before("raw")),
+		callback.Raw().After("gorm:raw").Register("tracing:after_raw", p.after),
+	)
+}
+
+func (p *GormPlugin) before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement.Context == nil {
+			return
+		}
+
+		_, span := tracer.Start(db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemPostgreSQL, attribute.String("db.sql.table", db.Statement.Table)),
+		)
+
+		db.InstanceSet(spanKey, span)
+	}
+}
+
+func (p *GormPlugin) after(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,91 @@
+package tracing
+
+import (
+	"SongsLibrary/internal/tracing/constants"
+	logrusCustom "SongsLibrary/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"io"
+	"os"
+)
+
+// Config selects where spans go. Endpoint is the host:port of an OTLP/gRPC
+// collector; File receives the spans of the stdout exporter, standard output
+// when empty. SampleRatio applies to traces started here, incoming sampling
+// decisions are kept.
+type Config struct {
+	Exporter    string
+	Endpoint    string
+	Insecure    bool
+	File        string
+	SampleRatio float64
+	ServiceName string
+}
+
+// Init installs the W3C trace context and baggage propagators, so that trace
+// headers are honoured and forwarded even when no spans are exported, and the
+// global tracer provider of config. The returned function flushes the spans
+// still buffered and stops the exporter.
+func Init(ctx context.Context, config Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var (
+		exporter sdktrace.SpanExporter
+		closer   io.Closer
+		err      error
+	)
+
+	switch config.Exporter {
+	case "", constants.ExporterNone:
+		logrusCustom.Log(ctx, logrus.InfoLevel, "Tracing is disabled")
+
+		return func(context.Context) error { return nil }, nil
+	case constants.ExporterOTLP:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+		if config.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, options...)
+	case constants.ExporterStdout:
+		var out io.Writer = os.Stdout
+		if config.File != "" {
+			file, openErr := os.OpenFile(config.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if openErr != nil {
+				return nil, openErr
+			}
+			out, closer = file, file
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(out), stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("%w: %q", UnknownExporter, config.Exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(config.ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	logrusCustom.Log(ctx, logrus.InfoLevel, fmt.Sprintf("Exporting %.0f%% of traces with the %s exporter", config.SampleRatio*100, config.Exporter))
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			err = errors.Join(err, closer.Close())
+		}
+		return err
+	}, nil
+}
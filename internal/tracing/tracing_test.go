@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"SongsLibrary/internal/tracing/constants"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInit_StdoutExporterWritesFile(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "spans.json")
+
+	shutdown, err := Init(context.Background(), Config{
+		Exporter:    constants.ExporterStdout,
+		File:        path,
+		SampleRatio: 1,
+		ServiceName: "songs-library",
+	})
+	assert.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "SongUseCase.CreateSong")
+	span.End()
+
+	assert.NoError(t, shutdown(context.Background()))
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"Name": "SongUseCase.CreateSong"`)
+	assert.Contains(t, string(content), `"Value": "songs-library"`)
+}
+
+func TestInit_HonoursIncomingTraceHeaders(t *testing.T) {
+
+	shutdown, err := Init(context.Background(), Config{Exporter: constants.ExporterNone})
+	assert.NoError(t, err)
+	defer shutdown(context.Background())
+
+	incoming := propagation.MapCarrier{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), incoming)
+
+	outgoing := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, outgoing)
+
+	assert.Equal(t, incoming["traceparent"], outgoing["traceparent"])
+}
+
+func TestInit_UnknownExporter(t *testing.T) {
+
+	_, err := Init(context.Background(), Config{Exporter: "zipkin"})
+
+	assert.True(t, errors.Is(err, UnknownExporter))
+}
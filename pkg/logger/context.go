@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+type contextKey struct{}
+
+// root is the directory of the module, so that locations are relative to it.
+var root = func() string {
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		return ""
+	}
+
+	return filepath.ToSlash(filepath.Dir(filepath.Dir(filepath.Dir(file)))) + "/"
+}()
+
+// NewContext returns a copy of ctx carrying entry, which every Log call with
+// the returned context or one derived from it writes to.
+func NewContext(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, contextKey{}, entry)
+}
+
+// FromContext returns the entry carried by ctx, or an entry of the logrus
+// standard logger when there is none.
+func FromContext(ctx context.Context) *logrus.Entry {
+	if entry, ok := ctx.Value(contextKey{}).(*logrus.Entry); ok {
+		return entry
+	}
+
+	return logrus.NewEntry(logrus.StandardLogger())
+}
+
+// HasEntry reports whether ctx carries an entry.
+func HasEntry(ctx context.Context) bool {
+	_, ok := ctx.Value(contextKey{}).(*logrus.Entry)
+
+	return ok
+}
+
+// Log writes msg at level to the entry of ctx with the file and line of the
+// caller. The location is only looked up when level is enabled.
+func Log(ctx context.Context, level logrus.Level, msg string) {
+	entry := FromContext(ctx)
+	if !entry.Logger.IsLevelEnabled(level) {
+		return
+	}
+
+	_, file, line, ok := runtime.Caller(1)
+	if !ok {
+		entry.Log(level, msg)
+		return
+	}
+
+	entry.WithField("location", fmt.Sprintf("%s:%d", strings.TrimPrefix(file, root), line)).Log(level, msg)
+}
+
+// LogAt is Log for adapters logging on behalf of other code: location, a
+// "file:line" string such as the one of the code that issued a query, is
+// recorded instead of the caller of LogAt. location is only computed when
+// level is enabled.
+func LogAt(ctx context.Context, level logrus.Level, location func() string, msg string) {
+	entry := FromContext(ctx)
+	if !entry.Logger.IsLevelEnabled(level) {
+		return
+	}
+
+	entry.WithField("location", strings.TrimPrefix(location(), root)).Log(level, msg)
+}
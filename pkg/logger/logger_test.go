@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNew_WritesJSONToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "songs.log")
+
+	log, closer, err := New(Config{Level: "warn", Format: FormatJSON, Output: OutputFile, File: path, MaxSizeMB: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	log.Info("dropped")
+	log.WithField("song", "fortunate son").Warn("kept")
+	assert.NoError(t, closer.Close())
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var entry map[string]interface{}
+	assert.NoError(t, json.Unmarshal(content, &entry), "one compact JSON entry per line")
+	assert.Equal(t, "kept", entry["msg"])
+	assert.Equal(t, "fortunate son", entry["song"])
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	_, _, err := New(Config{Level: "loud", Format: FormatJSON, Output: OutputStdout})
+	assert.Error(t, err)
+
+	_, _, err = New(Config{Level: "info", Format: "xml", Output: OutputStdout})
+	assert.True(t, errors.Is(err, UnknownFormat))
+
+	_, _, err = New(Config{Level: "info", Format: FormatText, Output: "syslog"})
+	assert.True(t, errors.Is(err, UnknownOutput))
+}
+
+func TestLog_UsesEntryOfContext(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	log.SetLevel(logrus.InfoLevel)
+
+	ctx := NewContext(context.Background(), log.WithField("request_id", "42"))
+
+	Log(ctx, logrus.DebugLevel, "dropped")
+	Log(ctx, logrus.InfoLevel, "kept")
+
+	assert.Len(t, hook.AllEntries(), 1)
+	assert.Equal(t, "kept", hook.LastEntry().Message)
+	assert.Equal(t, "42", hook.LastEntry().Data["request_id"])
+	assert.Regexp(t, `^pkg/logger/logger_test\.go:\d+$`, hook.LastEntry().Data["location"])
+}
+
+func TestFromContext_FallsBackToStandardLogger(t *testing.T) {
+	assert.Same(t, logrus.StandardLogger(), FromContext(context.Background()).Logger)
+}
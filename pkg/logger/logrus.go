@@ -1,60 +1,90 @@
 package logger
 
 import (
+	"errors"
 	"fmt"
 	"github.com/sirupsen/logrus"
+	"gopkg.in/natefinch/lumberjack.v2"
 	"io"
 	"os"
-	"path/filepath"
-	"runtime"
 )
 
-var Logger *logrus.Logger
+const (
+	FormatJSON = "json"
+	FormatText = "text"
 
-func InitLogger() {
-	Logger = logrus.New()
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	OutputFile   = "file"
+)
 
-	Logger.SetLevel(logrus.DebugLevel)
+var (
+	UnknownFormat = errors.New("unknown log format, expected json or text")
+	UnknownOutput = errors.New("unknown log output, expected stdout, stderr or file")
+)
 
-	Logger.SetFormatter(&logrus.JSONFormatter{
-		PrettyPrint: true,
-	})
+// Config selects the level, format and sink of a Logger. The file output is
+// rotated once it reaches MaxSizeMB; at most MaxBackups rotated files younger
+// than MaxAgeDays are kept, 0 keeping all of them. Redaction is applied to
+// every entry before it is written.
+type Config struct {
+	Level      string
+	Format     string
+	Output     string
+	File       string
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+	Redaction  Redaction
+}
 
-	file, err := os.OpenFile("app.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-	if err == nil {
-		Logger.SetOutput(file)
-	} else {
-		Logger.SetOutput(os.Stdout)
-		return
+// New returns the logger of config. The returned closer releases the log file
+// and is a no-op for the standard streams.
+func New(config Config) (*logrus.Logger, io.Closer, error) {
+	level, err := logrus.ParseLevel(config.Level)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	multiWriter := io.MultiWriter(file, os.Stdout)
-	Logger.SetOutput(multiWriter)
-}
+	log := logrus.New()
+	log.SetLevel(level)
 
-func LogWithLocation(level logrus.Level, msg string) {
-	_, file, line, ok := runtime.Caller(1)
-	if !ok {
-		Logger.Log(level, msg)
-		return
+	var formatter logrus.Formatter
+	switch config.Format {
+	case FormatJSON:
+		formatter = &logrus.JSONFormatter{}
+	case FormatText:
+		formatter = &logrus.TextFormatter{FullTimestamp: true, DisableColors: true}
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", UnknownFormat, config.Format)
 	}
+	log.SetFormatter(NewRedactingFormatter(formatter, config.Redaction))
 
-	rootDir, err := os.Getwd()
-	if err != nil {
-		Logger.Log(level, msg)
-		return
-	}
+	var closer io.Closer = nopCloser{}
 
-	relPath, err := filepath.Rel(rootDir, file)
-	if err != nil {
-		Logger.Log(level, msg)
-		return
+	switch config.Output {
+	case OutputStdout:
+		log.SetOutput(os.Stdout)
+	case OutputStderr:
+		log.SetOutput(os.Stderr)
+	case OutputFile:
+		file := &lumberjack.Logger{
+			Filename:   config.File,
+			MaxSize:    config.MaxSizeMB,
+			MaxBackups: config.MaxBackups,
+			MaxAge:     config.MaxAgeDays,
+		}
+		log.SetOutput(file)
+		closer = file
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", UnknownOutput, config.Output)
 	}
 
-	relPath = filepath.ToSlash(relPath)
+	return log, closer, nil
+}
+
+type nopCloser struct{}
 
-	Logger.WithFields(
-		logrus.Fields{
-			"location": fmt.Sprintf("%s:%d", relPath, line),
-		}).Log(level, msg)
+func (nopCloser) Close() error {
+	return nil
 }
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/sirupsen/logrus"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Redacted replaces every masked value.
+const Redacted = "[REDACTED]"
+
+// minSecretLength keeps secrets too short to be told apart from ordinary text
+// from masking half of every entry.
+const minSecretLength = 4
+
+// Redaction lists what must never reach a log sink: literal Secrets, the
+// values of the QueryParams, which also covers key=value strings such as DSNs,
+// and the values of the Headers.
+type Redaction struct {
+	Secrets     []string
+	QueryParams []string
+	Headers     []string
+}
+
+// RedactingFormatter masks the values of its Redaction in the entries
+// rendered by the formatter it wraps. It runs after every field has been
+// rendered, so messages, fields and errors are all covered, whatever way the
+// value was printed: as is, quoted, JSON or URL escaped.
+type RedactingFormatter struct {
+	next     logrus.Formatter
+	secrets  *strings.Replacer
+	patterns []*regexp.Regexp
+}
+
+func NewRedactingFormatter(next logrus.Formatter, redaction Redaction) *RedactingFormatter {
+	formatter := &RedactingFormatter{next: next}
+
+	var variants []string
+	for _, secret := range redaction.Secrets {
+		if len(secret) < minSecretLength {
+			continue
+		}
+		variants = append(variants, encodings(secret)...)
+	}
+	// The longest variant has to win when one contains another.
+	slices.SortFunc(variants, func(a, b string) int { return len(b) - len(a) })
+	variants = slices.Compact(variants)
+
+	if len(variants) > 0 {
+		pairs := make([]string, 0, 2*len(variants))
+		for _, variant := range variants {
+			pairs = append(pairs, variant, Redacted)
+		}
+		formatter.secrets = strings.NewReplacer(pairs...)
+	}
+
+	for _, param := range redaction.QueryParams {
+		// The JSON formatter escapes & as \u0026.
+		formatter.patterns = append(formatter.patterns, regexp.MustCompile(`(?i)((?:\b|\\u0026)`+regexp.QuoteMeta(param)+`=)[^&\s"'\\]+`))
+	}
+	for _, header := range redaction.Headers {
+		// Matches "Name: value", Go maps such as Name:[value] and JSON
+		// objects, escaped or not.
+		formatter.patterns = append(formatter.patterns, regexp.MustCompile(`(?i)(\b`+regexp.QuoteMeta(header)+`(?:\\?")?\s*[:=]\s*\[?(?:\\?")?)[^\]"\\\r\n]+`))
+	}
+
+	return formatter
+}
+
+func (f *RedactingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	out, err := f.next.Format(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.redact(out), nil
+}
+
+// redact masks parameters and headers first, as a masked secret would no
+// longer be recognised as their value.
+func (f *RedactingFormatter) redact(out []byte) []byte {
+	for _, pattern := range f.patterns {
+		out = pattern.ReplaceAll(out, []byte("${1}"+Redacted))
+	}
+
+	if f.secrets != nil {
+		out = []byte(f.secrets.Replace(string(out)))
+	}
+
+	return out
+}
+
+// encodings returns secret as it can appear in a rendered entry.
+func encodings(secret string) []string {
+	variants := []string{secret, url.QueryEscape(secret), url.PathEscape(secret)}
+
+	quoted := strconv.Quote(secret)
+	variants = append(variants, quoted[1:len(quoted)-1])
+
+	for _, escapeHTML := range []bool{true, false} {
+		var buffer bytes.Buffer
+		encoder := json.NewEncoder(&buffer)
+		encoder.SetEscapeHTML(escapeHTML)
+		if err := encoder.Encode(secret); err == nil {
+			encoded := strings.TrimSpace(buffer.String())
+			variants = append(variants, encoded[1:len(encoded)-1])
+		}
+	}
+
+	// Messages are JSON escaped once more by the JSON formatter.
+	for _, variant := range slices.Clone(variants) {
+		if escaped, err := json.Marshal(variant); err == nil {
+			variants = append(variants, string(escaped[1:len(escaped)-1]))
+		}
+	}
+
+	return variants
+}
@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+var redaction = Redaction{
+	Secrets:     []string{"s3cr&t pass\"word", "mm-api-key", "change-me", "ab"},
+	QueryParams: []string{"apikey", "password"},
+	Headers:     []string{"Authorization", "X-API-Key"},
+}
+
+func format(t *testing.T, formatter logrus.Formatter, entry *logrus.Entry) string {
+	t.Helper()
+
+	out, err := NewRedactingFormatter(formatter, redaction).Format(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(out)
+}
+
+func TestRedactingFormatter_MasksSecrets(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://api.musixmatch.com/ws/1.1/track.lyrics.get?commontrack_id=1&apikey=unlisted-key", nil)
+	req.Header.Set("Authorization", "Bearer unlisted-token")
+
+	messages := []string{
+		"Loaded dsn: host=db user=songs password=unlisted-password dbname=songs",
+		"Loaded password: s3cr&t pass\"word",
+		fmt.Sprintf("Builded GetSongLyrics REQ: %+v", req),
+		fmt.Sprintf("Escaped secret: %s", url.QueryEscape("s3cr&t pass\"word")),
+		(&url.Error{Op: "Get", URL: req.URL.String(), Err: errors.New("connection refused")}).Error(),
+		`Forwarded {"Authorization": "Bearer unlisted-token", "x-api-key": "change-me"}`,
+	}
+
+	for _, formatter := range []logrus.Formatter{&logrus.JSONFormatter{}, &logrus.TextFormatter{DisableColors: true}} {
+		for _, message := range messages {
+			entry := logrus.NewEntry(logrus.New()).WithFields(logrus.Fields{"apiKey": "mm-api-key", "error": errors.New("status 401 for change-me")})
+			entry.Message = message
+			entry.Level = logrus.InfoLevel
+
+			out := format(t, formatter, entry)
+
+			for _, secret := range []string{"s3cr", "mm-api-key", "change-me", "unlisted-key", "unlisted-token", "unlisted-password"} {
+				assert.NotContains(t, out, secret, "%T: %s", formatter, message)
+			}
+			assert.Contains(t, out, Redacted)
+		}
+	}
+}
+
+func TestRedactingFormatter_KeepsOtherText(t *testing.T) {
+	entry := logrus.NewEntry(logrus.New())
+	entry.Message = "Entered GetSongs Hanlder with parameters: group:abba, page:1"
+	entry.Level = logrus.InfoLevel
+
+	out := format(t, &logrus.TextFormatter{DisableColors: true}, entry)
+
+	assert.Contains(t, out, "group:abba", "secrets shorter than 4 characters are ignored")
+	assert.NotContains(t, out, Redacted)
+}
@@ -0,0 +1,3 @@
+module github.com/DanKo-code/Protobuf-For-Songs-Library-Upgraded
+
+go 1.23
@@ -0,0 +1,747 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.2
+// source: song/songsLibrary.proto
+
+package songsLibraryv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Song_GetSongs_FullMethodName      = "/songsLibrary.Song/GetSongs"
+	Song_DeleteSong_FullMethodName    = "/songsLibrary.Song/DeleteSong"
+	Song_UpdateSong_FullMethodName    = "/songsLibrary.Song/UpdateSong"
+	Song_CreateSong_FullMethodName    = "/songsLibrary.Song/CreateSong"
+	Song_GetSongLyrics_FullMethodName = "/songsLibrary.Song/GetSongLyrics"
+	Song_SearchSongs_FullMethodName   = "/songsLibrary.Song/SearchSongs"
+	Song_ExportSongs_FullMethodName   = "/songsLibrary.Song/ExportSongs"
+)
+
+// SongClient is the client API for Song service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SongClient interface {
+	GetSongs(ctx context.Context, in *GetSongsRequest, opts ...grpc.CallOption) (*GetSongsResponseList, error)
+	DeleteSong(ctx context.Context, in *DeleteSongsRequest, opts ...grpc.CallOption) (*DeleteSongsResponse, error)
+	UpdateSong(ctx context.Context, in *UpdateSongRequest, opts ...grpc.CallOption) (*UpdateSongResponse, error)
+	CreateSong(ctx context.Context, in *CreateSongRequest, opts ...grpc.CallOption) (*CreateSongResponse, error)
+	GetSongLyrics(ctx context.Context, in *GetSongLyricsRequest, opts ...grpc.CallOption) (*GetSongLyricsResponse, error)
+	SearchSongs(ctx context.Context, in *SearchSongsRequest, opts ...grpc.CallOption) (*SearchSongsResponseList, error)
+	ExportSongs(ctx context.Context, in *ExportSongsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportSongsResponse], error)
+}
+
+type songClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSongClient(cc grpc.ClientConnInterface) SongClient {
+	return &songClient{cc}
+}
+
+func (c *songClient) GetSongs(ctx context.Context, in *GetSongsRequest, opts ...grpc.CallOption) (*GetSongsResponseList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSongsResponseList)
+	err := c.cc.Invoke(ctx, Song_GetSongs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songClient) DeleteSong(ctx context.Context, in *DeleteSongsRequest, opts ...grpc.CallOption) (*DeleteSongsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSongsResponse)
+	err := c.cc.Invoke(ctx, Song_DeleteSong_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songClient) UpdateSong(ctx context.Context, in *UpdateSongRequest, opts ...grpc.CallOption) (*UpdateSongResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSongResponse)
+	err := c.cc.Invoke(ctx, Song_UpdateSong_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songClient) CreateSong(ctx context.Context, in *CreateSongRequest, opts ...grpc.CallOption) (*CreateSongResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSongResponse)
+	err := c.cc.Invoke(ctx, Song_CreateSong_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songClient) GetSongLyrics(ctx context.Context, in *GetSongLyricsRequest, opts ...grpc.CallOption) (*GetSongLyricsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSongLyricsResponse)
+	err := c.cc.Invoke(ctx, Song_GetSongLyrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songClient) SearchSongs(ctx context.Context, in *SearchSongsRequest, opts ...grpc.CallOption) (*SearchSongsResponseList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchSongsResponseList)
+	err := c.cc.Invoke(ctx, Song_SearchSongs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songClient) ExportSongs(ctx context.Context, in *ExportSongsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportSongsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Song_ServiceDesc.Streams[0], Song_ExportSongs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportSongsRequest, ExportSongsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Song_ExportSongsClient = grpc.ServerStreamingClient[ExportSongsResponse]
+
+// SongServer is the server API for Song service.
+// All implementations must embed UnimplementedSongServer
+// for forward compatibility.
+type SongServer interface {
+	GetSongs(context.Context, *GetSongsRequest) (*GetSongsResponseList, error)
+	DeleteSong(context.Context, *DeleteSongsRequest) (*DeleteSongsResponse, error)
+	UpdateSong(context.Context, *UpdateSongRequest) (*UpdateSongResponse, error)
+	CreateSong(context.Context, *CreateSongRequest) (*CreateSongResponse, error)
+	GetSongLyrics(context.Context, *GetSongLyricsRequest) (*GetSongLyricsResponse, error)
+	SearchSongs(context.Context, *SearchSongsRequest) (*SearchSongsResponseList, error)
+	ExportSongs(*ExportSongsRequest, grpc.ServerStreamingServer[ExportSongsResponse]) error
+	mustEmbedUnimplementedSongServer()
+}
+
+// UnimplementedSongServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSongServer struct{}
+
+func (UnimplementedSongServer) GetSongs(context.Context, *GetSongsRequest) (*GetSongsResponseList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSongs not implemented")
+}
+func (UnimplementedSongServer) DeleteSong(context.Context, *DeleteSongsRequest) (*DeleteSongsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSong not implemented")
+}
+func (UnimplementedSongServer) UpdateSong(context.Context, *UpdateSongRequest) (*UpdateSongResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSong not implemented")
+}
+func (UnimplementedSongServer) CreateSong(context.Context, *CreateSongRequest) (*CreateSongResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSong not implemented")
+}
+func (UnimplementedSongServer) GetSongLyrics(context.Context, *GetSongLyricsRequest) (*GetSongLyricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSongLyrics not implemented")
+}
+func (UnimplementedSongServer) SearchSongs(context.Context, *SearchSongsRequest) (*SearchSongsResponseList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchSongs not implemented")
+}
+func (UnimplementedSongServer) ExportSongs(*ExportSongsRequest, grpc.ServerStreamingServer[ExportSongsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportSongs not implemented")
+}
+func (UnimplementedSongServer) mustEmbedUnimplementedSongServer() {}
+func (UnimplementedSongServer) testEmbeddedByValue()              {}
+
+// UnsafeSongServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SongServer will
+// result in compilation errors.
+type UnsafeSongServer interface {
+	mustEmbedUnimplementedSongServer()
+}
+
+func RegisterSongServer(s grpc.ServiceRegistrar, srv SongServer) {
+	// If the following call pancis, it indicates UnimplementedSongServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Song_ServiceDesc, srv)
+}
+
+func _Song_GetSongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSongsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongServer).GetSongs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Song_GetSongs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongServer).GetSongs(ctx, req.(*GetSongsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Song_DeleteSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSongsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongServer).DeleteSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Song_DeleteSong_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongServer).DeleteSong(ctx, req.(*DeleteSongsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Song_UpdateSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongServer).UpdateSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Song_UpdateSong_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongServer).UpdateSong(ctx, req.(*UpdateSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Song_CreateSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongServer).CreateSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Song_CreateSong_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongServer).CreateSong(ctx, req.(*CreateSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Song_GetSongLyrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSongLyricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongServer).GetSongLyrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Song_GetSongLyrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongServer).GetSongLyrics(ctx, req.(*GetSongLyricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Song_SearchSongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchSongsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongServer).SearchSongs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Song_SearchSongs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongServer).SearchSongs(ctx, req.(*SearchSongsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Song_ExportSongs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportSongsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SongServer).ExportSongs(m, &grpc.GenericServerStream[ExportSongsRequest, ExportSongsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Song_ExportSongsServer = grpc.ServerStreamingServer[ExportSongsResponse]
+
+// Song_ServiceDesc is the grpc.ServiceDesc for Song service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Song_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "songsLibrary.Song",
+	HandlerType: (*SongServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSongs",
+			Handler:    _Song_GetSongs_Handler,
+		},
+		{
+			MethodName: "DeleteSong",
+			Handler:    _Song_DeleteSong_Handler,
+		},
+		{
+			MethodName: "UpdateSong",
+			Handler:    _Song_UpdateSong_Handler,
+		},
+		{
+			MethodName: "CreateSong",
+			Handler:    _Song_CreateSong_Handler,
+		},
+		{
+			MethodName: "GetSongLyrics",
+			Handler:    _Song_GetSongLyrics_Handler,
+		},
+		{
+			MethodName: "SearchSongs",
+			Handler:    _Song_SearchSongs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportSongs",
+			Handler:       _Song_ExportSongs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "song/songsLibrary.proto",
+}
+
+const (
+	Author_GetAuthors_FullMethodName     = "/songsLibrary.Author/GetAuthors"
+	Author_GetAuthor_FullMethodName      = "/songsLibrary.Author/GetAuthor"
+	Author_UpdateAuthor_FullMethodName   = "/songsLibrary.Author/UpdateAuthor"
+	Author_MergeAuthors_FullMethodName   = "/songsLibrary.Author/MergeAuthors"
+	Author_DeleteAuthor_FullMethodName   = "/songsLibrary.Author/DeleteAuthor"
+	Author_GetAuthorSongs_FullMethodName = "/songsLibrary.Author/GetAuthorSongs"
+)
+
+// AuthorClient is the client API for Author service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuthorClient interface {
+	GetAuthors(ctx context.Context, in *GetAuthorsRequest, opts ...grpc.CallOption) (*GetAuthorsResponseList, error)
+	GetAuthor(ctx context.Context, in *GetAuthorRequest, opts ...grpc.CallOption) (*GetAuthorResponse, error)
+	UpdateAuthor(ctx context.Context, in *UpdateAuthorRequest, opts ...grpc.CallOption) (*UpdateAuthorResponse, error)
+	MergeAuthors(ctx context.Context, in *MergeAuthorsRequest, opts ...grpc.CallOption) (*MergeAuthorsResponse, error)
+	DeleteAuthor(ctx context.Context, in *DeleteAuthorRequest, opts ...grpc.CallOption) (*DeleteAuthorResponse, error)
+	GetAuthorSongs(ctx context.Context, in *GetAuthorSongsRequest, opts ...grpc.CallOption) (*GetSongsResponseList, error)
+}
+
+type authorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthorClient(cc grpc.ClientConnInterface) AuthorClient {
+	return &authorClient{cc}
+}
+
+func (c *authorClient) GetAuthors(ctx context.Context, in *GetAuthorsRequest, opts ...grpc.CallOption) (*GetAuthorsResponseList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAuthorsResponseList)
+	err := c.cc.Invoke(ctx, Author_GetAuthors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorClient) GetAuthor(ctx context.Context, in *GetAuthorRequest, opts ...grpc.CallOption) (*GetAuthorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAuthorResponse)
+	err := c.cc.Invoke(ctx, Author_GetAuthor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorClient) UpdateAuthor(ctx context.Context, in *UpdateAuthorRequest, opts ...grpc.CallOption) (*UpdateAuthorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateAuthorResponse)
+	err := c.cc.Invoke(ctx, Author_UpdateAuthor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorClient) MergeAuthors(ctx context.Context, in *MergeAuthorsRequest, opts ...grpc.CallOption) (*MergeAuthorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeAuthorsResponse)
+	err := c.cc.Invoke(ctx, Author_MergeAuthors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorClient) DeleteAuthor(ctx context.Context, in *DeleteAuthorRequest, opts ...grpc.CallOption) (*DeleteAuthorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAuthorResponse)
+	err := c.cc.Invoke(ctx, Author_DeleteAuthor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorClient) GetAuthorSongs(ctx context.Context, in *GetAuthorSongsRequest, opts ...grpc.CallOption) (*GetSongsResponseList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSongsResponseList)
+	err := c.cc.Invoke(ctx, Author_GetAuthorSongs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthorServer is the server API for Author service.
+// All implementations must embed UnimplementedAuthorServer
+// for forward compatibility.
+type AuthorServer interface {
+	GetAuthors(context.Context, *GetAuthorsRequest) (*GetAuthorsResponseList, error)
+	GetAuthor(context.Context, *GetAuthorRequest) (*GetAuthorResponse, error)
+	UpdateAuthor(context.Context, *UpdateAuthorRequest) (*UpdateAuthorResponse, error)
+	MergeAuthors(context.Context, *MergeAuthorsRequest) (*MergeAuthorsResponse, error)
+	DeleteAuthor(context.Context, *DeleteAuthorRequest) (*DeleteAuthorResponse, error)
+	GetAuthorSongs(context.Context, *GetAuthorSongsRequest) (*GetSongsResponseList, error)
+	mustEmbedUnimplementedAuthorServer()
+}
+
+// UnimplementedAuthorServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuthorServer struct{}
+
+func (UnimplementedAuthorServer) GetAuthors(context.Context, *GetAuthorsRequest) (*GetAuthorsResponseList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuthors not implemented")
+}
+func (UnimplementedAuthorServer) GetAuthor(context.Context, *GetAuthorRequest) (*GetAuthorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuthor not implemented")
+}
+func (UnimplementedAuthorServer) UpdateAuthor(context.Context, *UpdateAuthorRequest) (*UpdateAuthorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAuthor not implemented")
+}
+func (UnimplementedAuthorServer) MergeAuthors(context.Context, *MergeAuthorsRequest) (*MergeAuthorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeAuthors not implemented")
+}
+func (UnimplementedAuthorServer) DeleteAuthor(context.Context, *DeleteAuthorRequest) (*DeleteAuthorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAuthor not implemented")
+}
+func (UnimplementedAuthorServer) GetAuthorSongs(context.Context, *GetAuthorSongsRequest) (*GetSongsResponseList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuthorSongs not implemented")
+}
+func (UnimplementedAuthorServer) mustEmbedUnimplementedAuthorServer() {}
+func (UnimplementedAuthorServer) testEmbeddedByValue()                {}
+
+// UnsafeAuthorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthorServer will
+// result in compilation errors.
+type UnsafeAuthorServer interface {
+	mustEmbedUnimplementedAuthorServer()
+}
+
+func RegisterAuthorServer(s grpc.ServiceRegistrar, srv AuthorServer) {
+	// If the following call pancis, it indicates UnimplementedAuthorServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Author_ServiceDesc, srv)
+}
+
+func _Author_GetAuthors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuthorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorServer).GetAuthors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Author_GetAuthors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorServer).GetAuthors(ctx, req.(*GetAuthorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Author_GetAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorServer).GetAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Author_GetAuthor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorServer).GetAuthor(ctx, req.(*GetAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Author_UpdateAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorServer).UpdateAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Author_UpdateAuthor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorServer).UpdateAuthor(ctx, req.(*UpdateAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Author_MergeAuthors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeAuthorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorServer).MergeAuthors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Author_MergeAuthors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorServer).MergeAuthors(ctx, req.(*MergeAuthorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Author_DeleteAuthor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAuthorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorServer).DeleteAuthor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Author_DeleteAuthor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorServer).DeleteAuthor(ctx, req.(*DeleteAuthorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Author_GetAuthorSongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuthorSongsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorServer).GetAuthorSongs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Author_GetAuthorSongs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorServer).GetAuthorSongs(ctx, req.(*GetAuthorSongsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Author_ServiceDesc is the grpc.ServiceDesc for Author service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Author_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "songsLibrary.Author",
+	HandlerType: (*AuthorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAuthors",
+			Handler:    _Author_GetAuthors_Handler,
+		},
+		{
+			MethodName: "GetAuthor",
+			Handler:    _Author_GetAuthor_Handler,
+		},
+		{
+			MethodName: "UpdateAuthor",
+			Handler:    _Author_UpdateAuthor_Handler,
+		},
+		{
+			MethodName: "MergeAuthors",
+			Handler:    _Author_MergeAuthors_Handler,
+		},
+		{
+			MethodName: "DeleteAuthor",
+			Handler:    _Author_DeleteAuthor_Handler,
+		},
+		{
+			MethodName: "GetAuthorSongs",
+			Handler:    _Author_GetAuthorSongs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "song/songsLibrary.proto",
+}
+
+const (
+	SongData_GetSongData_FullMethodName = "/songsLibrary.SongData/GetSongData"
+)
+
+// SongDataClient is the client API for SongData service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SongDataClient interface {
+	GetSongData(ctx context.Context, in *GetSongDataRequest, opts ...grpc.CallOption) (*GetSongDataResponse, error)
+}
+
+type songDataClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSongDataClient(cc grpc.ClientConnInterface) SongDataClient {
+	return &songDataClient{cc}
+}
+
+func (c *songDataClient) GetSongData(ctx context.Context, in *GetSongDataRequest, opts ...grpc.CallOption) (*GetSongDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSongDataResponse)
+	err := c.cc.Invoke(ctx, SongData_GetSongData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SongDataServer is the server API for SongData service.
+// All implementations must embed UnimplementedSongDataServer
+// for forward compatibility.
+type SongDataServer interface {
+	GetSongData(context.Context, *GetSongDataRequest) (*GetSongDataResponse, error)
+	mustEmbedUnimplementedSongDataServer()
+}
+
+// UnimplementedSongDataServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSongDataServer struct{}
+
+func (UnimplementedSongDataServer) GetSongData(context.Context, *GetSongDataRequest) (*GetSongDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSongData not implemented")
+}
+func (UnimplementedSongDataServer) mustEmbedUnimplementedSongDataServer() {}
+func (UnimplementedSongDataServer) testEmbeddedByValue()                  {}
+
+// UnsafeSongDataServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SongDataServer will
+// result in compilation errors.
+type UnsafeSongDataServer interface {
+	mustEmbedUnimplementedSongDataServer()
+}
+
+func RegisterSongDataServer(s grpc.ServiceRegistrar, srv SongDataServer) {
+	// If the following call pancis, it indicates UnimplementedSongDataServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SongData_ServiceDesc, srv)
+}
+
+func _SongData_GetSongData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSongDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongDataServer).GetSongData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongData_GetSongData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongDataServer).GetSongData(ctx, req.(*GetSongDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SongData_ServiceDesc is the grpc.ServiceDesc for SongData service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SongData_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "songsLibrary.SongData",
+	HandlerType: (*SongDataServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSongData",
+			Handler:    _SongData_GetSongData_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "song/songsLibrary.proto",
+}